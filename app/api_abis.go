@@ -16,6 +16,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/crud"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 	// EXISTING_CODE
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 	// EXISTING_CODE
 )
 
@@ -74,4 +75,18 @@ func (a *App) GetAbisBuckets(payload *types.Payload) (*types.Buckets, error) {
 }
 
 // EXISTING_CODE
+// ChooseAbiFile asks for an ABI or compiler artifact to import. It returns an empty path
+// if the user cancels.
+func (a *App) ChooseAbiFile() (string, error) {
+	return wailsRuntime.OpenFileDialog(a.ctx, wailsRuntime.OpenDialogOptions{
+		Title: "Import ABI",
+		Filters: []wailsRuntime.FileFilter{
+			{
+				DisplayName: "ABI or Artifact Files (*.json)",
+				Pattern:     "*.json",
+			},
+		},
+	})
+}
+
 // EXISTING_CODE
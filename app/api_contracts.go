@@ -13,6 +13,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/contracts"
 
 	//
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/crud"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 	// EXISTING_CODE
 	// EXISTING_CODE
@@ -31,6 +32,15 @@ func (a *App) GetContractsPage(
 	return ret, err
 }
 
+func (a *App) ContractsCrud(
+	payload *types.Payload,
+	op crud.Operation,
+	item *any,
+) error {
+	collection := contracts.GetContractsCollection(payload)
+	return collection.Crud(payload, op, item)
+}
+
 func (a *App) GetContractsSummary(payload *types.Payload) types.Summary {
 	collection := contracts.GetContractsCollection(payload)
 	return collection.GetSummary(payload)
@@ -56,9 +66,14 @@ func (a *App) GetContractsBuckets(payload *types.Payload) (*types.Buckets, error
 }
 
 // EXISTING_CODE
-// GetContracts returns the contracts for frontend display
+// GetContracts returns the active project's contracts on its active chain
 func (a *App) GetContracts() []sdk.Contract {
-	contractPtrs := sdk.CreateContracts()
+	active := a.GetActiveProject()
+	if active == nil {
+		return []sdk.Contract{}
+	}
+	payload := &types.Payload{Collection: "contracts", ActiveChain: active.GetActiveChain()}
+	contractPtrs := contracts.RegisteredContracts(payload)
 	result := make([]sdk.Contract, len(contractPtrs))
 	for i, c := range contractPtrs {
 		result[i] = *c
@@ -66,4 +81,19 @@ func (a *App) GetContracts() []sdk.Contract {
 	return result
 }
 
+// ReadContractState calls the active contract's read functions at the latest block. Spenders
+// are the extra addresses to pass to allowance-style functions.
+func (a *App) ReadContractState(payload *types.Payload, spenders []string, refresh bool) (*contracts.ContractState, error) {
+	collection := contracts.GetContractsCollection(payload)
+	return collection.ReadState(payload, spenders, refresh)
+}
+
+// contractsRegistry is the active project, if one is open
+func (a *App) contractsRegistry() contracts.Registry {
+	if active := a.GetActiveProject(); active != nil {
+		return active
+	}
+	return nil
+}
+
 // EXISTING_CODE
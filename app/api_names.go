@@ -16,6 +16,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/crud"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 	// EXISTING_CODE
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 	// EXISTING_CODE
 )
 
@@ -66,4 +67,54 @@ func (a *App) GetNamesBuckets(payload *types.Payload) (*types.Buckets, error) {
 }
 
 // EXISTING_CODE
+// ChooseNamesFile asks for a CSV or JSON file of names to import. It returns an empty path
+// if the user cancels.
+func (a *App) ChooseNamesFile() (string, error) {
+	return wailsRuntime.OpenFileDialog(a.ctx, wailsRuntime.OpenDialogOptions{
+		Title: "Import Names",
+		Filters: []wailsRuntime.FileFilter{
+			{
+				DisplayName: "Names Files (*.csv, *.json)",
+				Pattern:     "*.csv;*.json",
+			},
+		},
+	})
+}
+
+// PreviewNamesImport reads a names file and reports, row by row, whether importing it would
+// add a name, update one, or conflict with an existing one
+func (a *App) PreviewNamesImport(path string) ([]*names.ImportRow, error) {
+	rows, err := names.ReadNamesFile(path)
+	if err != nil {
+		return nil, err
+	}
+	names.PreviewImport(rows, names.SharedNameFromAddress)
+	return rows, nil
+}
+
+// ImportNames writes the previewed rows the user chose to apply to the custom names database
+func (a *App) ImportNames(payload *types.Payload, rows []*names.ImportRow) (*names.ImportReport, error) {
+	collection := names.GetNamesCollection(payload)
+	return collection.ImportNames(payload, rows)
+}
+
+// ExportCustomNames asks where to save the custom names and writes them in a form
+// ImportNames reads back. It returns the file's path, or an empty path if the user cancels.
+func (a *App) ExportCustomNames() (string, error) {
+	path, err := wailsRuntime.SaveFileDialog(a.ctx, wailsRuntime.SaveDialogOptions{
+		Title:           "Export Custom Names",
+		DefaultFilename: "custom-names.csv",
+		Filters: []wailsRuntime.FileFilter{
+			{
+				DisplayName: "Names Files (*.csv, *.json)",
+				Pattern:     "*.csv;*.json",
+			},
+		},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	return path, names.ExportCustomNames(path)
+}
+
 // EXISTING_CODE
@@ -16,8 +16,10 @@ import (
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/preferences"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/project"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/signatures"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/skin"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/contracts"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/exports"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/names"
 
@@ -54,6 +56,8 @@ type App struct {
 	ensMap      map[string]base.Address
 	Dalle       *dalle.Context
 	skinManager *skin.SkinManager
+	// appliedLabels are the project labels the loaded facets' names were resolved with
+	appliedLabels []project.Label
 }
 
 func NewApp(assets embed.FS) (*App, *menu.Menu) {
@@ -136,6 +140,13 @@ func (a *App) Startup(ctx context.Context) {
 
 	// Restore previously opened projects from last session
 	a.restoreLastProjects()
+	a.applyPeriodConfig()
+	a.applyPricing()
+	a.applyComparitoorProviders()
+	contracts.ConfigureRegistry(a.contractsRegistry)
+	names.ConfigureOverlay(a.namesOverlay)
+	a.appliedLabels = a.GetProjectLabels()
+	a.openSignatures()
 
 	// Initialize file server directly on the dalle OutputDir
 	if out := storage.OutputDir(); out != "" {
@@ -177,6 +188,12 @@ func (a *App) BeforeClose(ctx context.Context) bool {
 		}
 	}
 
+	if db := signatures.Active(); db != nil {
+		if err := db.Save(); err != nil {
+			log.Printf("Error saving signature database: %v", err)
+		}
+	}
+
 	// Shutdown global file writer and flush any pending writes
 	writer := filewriter.GetGlobalWriter()
 	_ = writer.Shutdown()
@@ -356,8 +356,8 @@ func (m MockCollection) GetSummary(payload *types.Payload) types.Summary {
 	return types.Summary{}
 }
 
-func (m MockCollection) ExportData(payload *types.Payload) (string, error) {
-	return "", nil
+func (m MockCollection) ExportData(payload *types.Payload) (string, int, error) {
+	return "", 0, nil
 }
 
 func (m MockCollection) ChangeVisibility(payload *types.Payload) error {
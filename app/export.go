@@ -8,51 +8,15 @@
 package app
 
 import (
-	"fmt"
-
-	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/utils"
-	"github.com/TrueBlocks/trueblocks-approvals/pkg/logging"
-	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
 )
 
 // ExportData handles export requests with full context logging and CSV creation
 func (a *App) ExportData(payload *types.Payload) error {
-	activeProject, exists := a.Projects.GetActiveItem()
-	if !exists {
-		err := fmt.Errorf("no active project")
-		msgs.EmitError("export failed: no active project", err)
-		return err
-	}
-	payload.ProjectPath = activeProject.Path
-
-	collection := a.getCollection(payload, false)
-	if collection == nil {
-		err := fmt.Errorf("[ExportData] unsupported collection type: %s", payload.Collection)
-		msgs.EmitError("unsupported collection type", err)
-		return err
-	}
-
-	exportFilename, err := collection.ExportData(payload)
-	if err != nil {
-		msgs.EmitError("failed to export data", err)
-		return fmt.Errorf("failed to export data: %w", err)
-	}
-
-	cmd := "open \"" + exportFilename + "\""
-	exitCode := utils.System(cmd)
-	if exitCode != 0 {
-		logging.LogBEError(fmt.Sprintf("Failed to open export file, exit code: %d", exitCode))
-	}
-
-	statusMsg := fmt.Sprintf("Export completed: %s %s data", payload.Collection, payload.DataFacet)
-	if payload.ActiveAddress != "" && payload.ActiveAddress != "0x0" {
-		statusMsg += fmt.Sprintf(" for %s", payload.ActiveAddress[:10]+"...")
-	}
-	if payload.ActiveChain != "" {
-		statusMsg += fmt.Sprintf(" on %s", payload.ActiveChain)
-	}
-	msgs.EmitStatus(statusMsg)
+	return a.ExportFacets(payload, []types.DataFacet{payload.DataFacet})
+}
 
-	return nil
+// CancelExport stops a running export of the payload's facet
+func (a *App) CancelExport(payload *types.Payload) bool {
+	return types.CancelExport(payload)
 }
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/preferences"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/utils"
+)
+
+// ExportBundle exports every facet with rows of the payload's collection or, if wholeProject
+// is set, of every collection into one zip archive with a manifest.json describing its
+// contents. It returns the archive's path.
+func (a *App) ExportBundle(payload *types.Payload, wholeProject bool) (string, error) {
+	activeProject, exists := a.Projects.GetActiveItem()
+	if !exists {
+		err := fmt.Errorf("no active project")
+		msgs.EmitError("export failed: no active project", err)
+		return "", err
+	}
+	payload.ProjectPath = activeProject.Path
+	if payload.Format == "" {
+		payload.Format = a.GetFormat()
+	}
+
+	// Facets are exported to a staging folder that is removed once they are archived
+	staging, err := os.MkdirTemp("", "export-bundle")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	name := payload.Collection
+	collections := []string{payload.Collection}
+	if wholeProject {
+		name = activeProject.GetName()
+		collections = a.GetRegisteredViews()
+	}
+
+	appId := preferences.GetAppId()
+	manifest := types.NewBundleManifest(payload, appId.AppName, preferences.GetAppVersion(), activeProject.GetName())
+	for _, collectionName := range collections {
+		collectionPayload := *payload
+		collectionPayload.Collection = collectionName
+		collectionPayload.ProjectPath = filepath.Join(staging, filepath.Base(activeProject.Path))
+		collectionPayload.Compress = false
+		collectionPayload.Profile, collectionPayload.Template, collectionPayload.Columns = "", "", nil
+
+		collection := a.getCollection(&collectionPayload, true)
+		if collection == nil {
+			continue
+		}
+		cfg, err := collection.GetConfig()
+		if err != nil || cfg.Disabled {
+			continue
+		}
+		for _, facetName := range cfg.FacetOrder {
+			if cfg.Facets[facetName].Disabled {
+				continue
+			}
+			facetPayload := collectionPayload
+			facetPayload.DataFacet = types.DataFacet(facetName)
+			path, rows, err := collection.ExportData(&facetPayload)
+			if errors.Is(err, types.ErrExportCancelled) {
+				msgs.EmitStatus(fmt.Sprintf("Export cancelled: %s bundle", name))
+				return "", err
+			}
+			if err != nil {
+				msgs.EmitError("failed to export data", err)
+				return "", fmt.Errorf("failed to export %s %s: %w", collectionName, facetName, err)
+			}
+			if rows == 0 {
+				continue // never loaded or empty; stale facets still hold their rows
+			}
+			manifest.Entries = append(manifest.Entries, types.BundleEntry{
+				Collection: collectionName,
+				DataFacet:  facetPayload.DataFacet,
+				Rows:       rows,
+				Path:       path,
+			})
+		}
+	}
+	if len(manifest.Entries) == 0 {
+		err := fmt.Errorf("no facets with data to export")
+		msgs.EmitError("export failed", err)
+		return "", err
+	}
+
+	bundlePath, err := types.ExportBundlePath(payload, name)
+	if err != nil {
+		return "", err
+	}
+	if err := types.WriteExportBundle(bundlePath, manifest); err != nil {
+		msgs.EmitError("failed to write export bundle", err)
+		return "", err
+	}
+
+	if exitCode := utils.System("open \"" + filepath.Dir(bundlePath) + "\""); exitCode != 0 {
+		logging.LogBEError(fmt.Sprintf("Failed to open export folder, exit code: %d", exitCode))
+	}
+	msgs.EmitStatus(fmt.Sprintf("Export completed: %d facets bundled in %s", len(manifest.Entries), filepath.Base(bundlePath)))
+	return bundlePath, nil
+}
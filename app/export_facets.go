@@ -0,0 +1,81 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/utils"
+)
+
+// ExportFacets exports several of a collection's facets in one request. With the sqlite
+// format they are written as tables in a single database.
+func (a *App) ExportFacets(payload *types.Payload, facets []types.DataFacet) error {
+	activeProject, exists := a.Projects.GetActiveItem()
+	if !exists {
+		err := fmt.Errorf("no active project")
+		msgs.EmitError("export failed: no active project", err)
+		return err
+	}
+	payload.ProjectPath = activeProject.Path
+	if payload.Format == "" {
+		payload.Format = a.GetFormat()
+	}
+	payload.Compress = payload.Compress || a.GetCompress()
+
+	collection := a.getCollection(payload, false)
+	if collection == nil {
+		err := fmt.Errorf("[ExportData] unsupported collection type: %s", payload.Collection)
+		msgs.EmitError("unsupported collection type", err)
+		return err
+	}
+
+	var exportFilename string
+	for _, facet := range facets {
+		facetPayload := *payload
+		facetPayload.DataFacet = facet
+		columns, err := activeProject.ExportColumns(&facetPayload, a.Preferences.Org.ExportTemplates)
+		if err != nil {
+			msgs.EmitError("failed to export data", err)
+			return err
+		}
+		facetPayload.Columns = columns
+		exportFilename, _, err = collection.ExportData(&facetPayload)
+		if errors.Is(err, types.ErrExportCancelled) {
+			msgs.EmitStatus(fmt.Sprintf("Export cancelled: %s %s data", payload.Collection, facet))
+			return nil
+		}
+		if err != nil {
+			msgs.EmitError("failed to export data", err)
+			return fmt.Errorf("failed to export data: %w", err)
+		}
+	}
+	if len(facets) > 1 && payload.Format != "sqlite" {
+		exportFilename = filepath.Dir(exportFilename)
+	}
+
+	cmd := "open \"" + exportFilename + "\""
+	exitCode := utils.System(cmd)
+	if exitCode != 0 {
+		logging.LogBEError(fmt.Sprintf("Failed to open export file, exit code: %d", exitCode))
+	}
+
+	names := make([]string, len(facets))
+	for i, facet := range facets {
+		names[i] = string(facet)
+	}
+	statusMsg := fmt.Sprintf("Export completed: %s %s data", payload.Collection, strings.Join(names, ", "))
+	if payload.ActiveAddress != "" && payload.ActiveAddress != "0x0" {
+		statusMsg += fmt.Sprintf(" for %s", payload.ActiveAddress[:10]+"...")
+	}
+	if payload.ActiveChain != "" {
+		statusMsg += fmt.Sprintf(" on %s", payload.ActiveChain)
+	}
+	msgs.EmitStatus(statusMsg)
+
+	return nil
+}
@@ -0,0 +1,11 @@
+package app
+
+import (
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+)
+
+// GetExportProfiles returns the export profiles, such as tax software formats, that apply to
+// the payload's facet
+func (a *App) GetExportProfiles(payload *types.Payload) []types.ExportProfile {
+	return types.GetExportProfiles(payload)
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/preferences"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+)
+
+// GetExportTemplates returns the saved export templates of the payload's facet, either the
+// active project's or those shared through the organization preferences
+func (a *App) GetExportTemplates(payload *types.Payload, shared bool) []preferences.ExportTemplate {
+	templates := a.Preferences.Org.ExportTemplates
+	if !shared {
+		activeProject, exists := a.Projects.GetActiveItem()
+		if !exists {
+			return []preferences.ExportTemplate{}
+		}
+		templates = activeProject.GetExportTemplates()
+	}
+	ret := []preferences.ExportTemplate{}
+	for _, t := range templates {
+		if t.Collection == payload.Collection && t.DataFacet == string(payload.DataFacet) {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
+
+// SaveExportTemplate saves an export template in the active project or, if shared, in the
+// organization preferences
+func (a *App) SaveExportTemplate(template preferences.ExportTemplate, shared bool) error {
+	if !shared {
+		activeProject, exists := a.Projects.GetActiveItem()
+		if !exists {
+			return fmt.Errorf("no active project")
+		}
+		return activeProject.SetExportTemplate(template)
+	}
+	if err := template.Validate(); err != nil {
+		return err
+	}
+	org := a.Preferences.Org
+	org.ExportTemplates = preferences.PutExportTemplate(append([]preferences.ExportTemplate{}, org.ExportTemplates...), template)
+	return a.SetOrgPreferences(&org)
+}
+
+// RemoveExportTemplate removes an export template from the active project or, if shared, from
+// the organization preferences
+func (a *App) RemoveExportTemplate(template preferences.ExportTemplate, shared bool) error {
+	if !shared {
+		activeProject, exists := a.Projects.GetActiveItem()
+		if !exists {
+			return fmt.Errorf("no active project")
+		}
+		return activeProject.RemoveExportTemplate(template.Name, template.Collection, types.DataFacet(template.DataFacet))
+	}
+	org := a.Preferences.Org
+	org.ExportTemplates = preferences.DeleteExportTemplate(org.ExportTemplates, template.Name, template.Collection, template.DataFacet)
+	return a.SetOrgPreferences(&org)
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/project"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/names"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// GetProjectLabels returns the active project's private address labels
+func (a *App) GetProjectLabels() []project.Label {
+	if active := a.GetActiveProject(); active != nil {
+		return active.GetLabels()
+	}
+	return []project.Label{}
+}
+
+// SetProjectLabel adds or replaces a private label in the active project. It is saved in
+// the project file only.
+func (a *App) SetProjectLabel(address, name, tags string) error {
+	active := a.GetActiveProject()
+	if active == nil {
+		return fmt.Errorf("no active project")
+	}
+	if !base.IsValidAddress(address) || len(address) != 42 {
+		return fmt.Errorf("%q is not an address", address)
+	}
+	label := project.Label{Address: base.HexToAddress(address), Name: name, Tags: tags}
+	if err := active.PutLabel(label); err != nil {
+		return err
+	}
+	a.applyLabels()
+	return nil
+}
+
+// RemoveProjectLabel removes the active project's label for an address
+func (a *App) RemoveProjectLabel(address string) error {
+	active := a.GetActiveProject()
+	if active == nil {
+		return fmt.Errorf("no active project")
+	}
+	if err := active.RemoveLabel(base.HexToAddress(address)); err != nil {
+		return err
+	}
+	a.applyLabels()
+	return nil
+}
+
+// namesOverlay is the active project, if one is open
+func (a *App) namesOverlay() names.Overlay {
+	if active := a.GetActiveProject(); active != nil {
+		return active
+	}
+	return nil
+}
+
+// applyLabels resets the loaded facets when the active project's labels differ from the
+// ones their names were resolved with, so that a project's labels never show in another's
+// views
+func (a *App) applyLabels() {
+	labels := a.GetProjectLabels()
+	if slices.Equal(labels, a.appliedLabels) {
+		return
+	}
+	a.appliedLabels = labels
+
+	chain, addresses := "", []base.Address{base.ZeroAddr}
+	if active := a.GetActiveProject(); active != nil {
+		chain = active.GetActiveChain()
+		addresses = append(addresses, active.GetAddresses()...)
+	}
+	for _, view := range a.GetRegisteredViews() {
+		if view == "names" {
+			continue // lists the names database itself
+		}
+		for _, address := range addresses {
+			payload := types.Payload{Collection: view, ActiveChain: chain, ActiveAddress: address.Hex()}
+			collection := a.getCollection(&payload, true)
+			if collection == nil {
+				continue
+			}
+			cfg, err := collection.GetConfig()
+			if err != nil {
+				continue
+			}
+			for _, facet := range cfg.FacetOrder {
+				facetPayload := payload
+				facetPayload.DataFacet = types.DataFacet(facet)
+				if !collection.NeedsUpdate(&facetPayload) {
+					collection.Reset(&facetPayload)
+				}
+			}
+		}
+	}
+	msgs.EmitManager("labels_changed")
+}
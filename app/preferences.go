@@ -1,8 +1,12 @@
 package app
 
 import (
+	"fmt"
+
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/preferences"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/pricing"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/comparitoor"
 )
 
 // GetUserPreferences returns the current user preferences
@@ -13,7 +17,13 @@ func (a *App) GetUserPreferences() *preferences.UserPreferences {
 // SetUserPreferences updates and persists user preferences
 func (a *App) SetUserPreferences(userPrefs *preferences.UserPreferences) error {
 	a.Preferences.User = *userPrefs
-	return preferences.SetUserPreferences(userPrefs)
+	if err := preferences.SetUserPreferences(userPrefs); err != nil {
+		return err
+	}
+	a.applyPeriodConfig()
+	a.applyPricing()
+	a.applyComparitoorProviders()
+	return nil
 }
 
 // GetOrgPreferences returns the current organization preferences
@@ -137,6 +147,23 @@ func (a *App) SetFormat(format string) {
 	}
 }
 
+// GetCompress returns whether exports are gzipped
+func (a *App) GetCompress() bool {
+	a.prefsMu.RLock()
+	defer a.prefsMu.RUnlock()
+	return a.Preferences.App.LastCompress
+}
+
+// SetCompress updates the application export compression preference
+func (a *App) SetCompress(compress bool) {
+	a.prefsMu.Lock()
+	defer a.prefsMu.Unlock()
+	a.Preferences.App.LastCompress = compress
+	if err := preferences.SetAppPreferences(&a.Preferences.App); err != nil {
+		msgs.EmitError("failed to save compression preference", err)
+	}
+}
+
 // IsDialogSilenced checks if a specific dialog is silenced
 func (a *App) IsDialogSilenced(dialogKey string) bool {
 	a.prefsMu.RLock()
@@ -351,3 +378,32 @@ func (a *App) SetDetailSectionState(sectionKey string, collapsed bool) error {
 	}
 	return nil
 }
+
+// applyPricing configures the fiat price source named in the user's preferences and asks
+// views to refresh their values
+func (a *App) applyPricing() {
+	user := a.Preferences.User
+	var src pricing.Source
+	switch user.PriceSource {
+	case "":
+	case "file":
+		fileSrc, err := pricing.NewFileSource(user.PriceFile, user.FiatCurrency)
+		if err != nil {
+			msgs.EmitError("Loading price file failed", err)
+			break
+		}
+		src = fileSrc
+	case "oracle":
+		src = pricing.NewCachedSource(pricing.NewOracleSource(user.PriceFeeds, user.FiatCurrency), 0)
+	default:
+		msgs.EmitError("Unknown price source", fmt.Errorf("%q", user.PriceSource))
+	}
+	pricing.Configure(src, user.FiatCurrency)
+	msgs.EmitManager("pricing_changed")
+}
+
+// applyComparitoorProviders picks the comparitoor's sources from the user's API keys and
+// CSV files. Loaded comparisons keep their data until they are reloaded.
+func (a *App) applyComparitoorProviders() {
+	comparitoor.ConfigureProviders(comparitoor.ProvidersFromPreferences(&a.Preferences.User))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/preferences"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/project"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/contracts"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 
@@ -39,6 +40,9 @@ func (a *App) NewProject(name string, currentAddress string) error {
 		}
 	}
 
+	a.applyPeriodConfig()
+	a.applyLabels()
+
 	// Emit event for frontend synchronization (after everything is complete)
 	msgs.EmitManager("project_created")
 	return nil
@@ -191,6 +195,7 @@ func (a *App) ClearActiveProject() error {
 	}
 
 	a.Projects.ActiveID = ""
+	a.applyLabels()
 	msgs.EmitManager("active_project_cleared")
 	return nil
 }
@@ -286,6 +291,9 @@ func (a *App) SwitchToProject(id string) error {
 
 	err := a.Projects.SetActiveItem(id)
 	if err == nil {
+		a.applyPeriodConfig()
+		a.applyLabels()
+		contracts.ResetRegistryFacets()
 		msgs.EmitManager("project_switched")
 	}
 	return err
@@ -322,12 +330,20 @@ func (a *App) CloseProject(id string) error {
 
 	// Get project path before closing
 	projectPath := project.GetPath()
+	activeID := a.Projects.ActiveID
 
 	// Close the project
 	if err := a.Projects.Close(id); err != nil {
 		return err
 	}
 
+	// Closing the active project activates another, or none
+	if a.Projects.ActiveID != activeID {
+		a.applyPeriodConfig()
+		a.applyLabels()
+		contracts.ResetRegistryFacets()
+	}
+
 	// Remove from LastProjects array (for session restoration)
 	msgs.EmitStatus(fmt.Sprintf("Removing project from LastProjects: %s", projectPath))
 	lenBefore := len(a.Preferences.App.LastProjects)
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/preferences"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/signatures"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/abis"
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// openSignatures loads the local signature database and seeds it from the ABIs cached for
+// the active chain. A large dump takes a while to read, so it happens in the background.
+func (a *App) openSignatures() {
+	_, appFolder := preferences.GetConfigFolders()
+	chain := ""
+	if active := a.GetActiveProject(); active != nil {
+		chain = active.GetActiveChain()
+	}
+	go func() {
+		db, err := signatures.Open(filepath.Join(appFolder, "signatures.txt"))
+		if err != nil {
+			msgs.EmitError("Loading signature database failed", err)
+			return
+		}
+		signatures.Configure(db)
+		if chain == "" {
+			return
+		}
+		if _, err := abis.SeedSignatures(chain); err != nil {
+			msgs.EmitError("Seeding signature database failed", err)
+		}
+	}()
+}
+
+// ChooseSignatureFile asks for a signature dump to import. It returns an empty path if the
+// user cancels.
+func (a *App) ChooseSignatureFile() (string, error) {
+	return wailsRuntime.OpenFileDialog(a.ctx, wailsRuntime.OpenDialogOptions{
+		Title: "Import Signatures",
+		Filters: []wailsRuntime.FileFilter{
+			{
+				DisplayName: "Signature Dumps (*.txt, *.csv, *.tsv)",
+				Pattern:     "*.txt;*.csv;*.tsv",
+			},
+		},
+	})
+}
+
+// ImportSignatures adds the function selectors and event topics in a text dump to the
+// local signature database
+func (a *App) ImportSignatures(path string) (signatures.Report, error) {
+	report, err := signatures.ImportFile(path)
+	if err != nil {
+		return report, err
+	}
+	msgs.EmitStatus(fmt.Sprintf("imported %d signatures (%d rejected); %d selectors and %d topics known",
+		report.Added, report.Rejected, report.Functions, report.Events))
+	return report, nil
+}
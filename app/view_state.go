@@ -3,9 +3,11 @@ package app
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/project"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/store"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
 )
 
@@ -126,3 +128,50 @@ func (a *App) SetActivePeriod(period types.Period) error {
 	}
 	return fmt.Errorf("no active project")
 }
+
+// ------------------------------------------------------------------------------------
+// GetPeriodConfig returns the period settings in effect: the active project's override if
+// it has one, otherwise the user's preferences
+func (a *App) GetPeriodConfig() types.PeriodConfig {
+	if a.Projects != nil {
+		if active := a.GetActiveProject(); active != nil {
+			if cfg := active.GetPeriodConfig(); cfg != nil {
+				return *cfg
+			}
+		}
+	}
+	user := a.Preferences.User
+	return types.PeriodConfig{
+		Timezone:        user.Timezone,
+		WeekStart:       time.Weekday(user.WeekStart),
+		FiscalYearStart: time.Month(user.FiscalYearStart),
+	}
+}
+
+// ------------------------------------------------------------------------------------
+// SetProjectPeriodConfig overrides the user's period settings for the active project;
+// nil reverts the project to the user's settings
+func (a *App) SetProjectPeriodConfig(cfg *types.PeriodConfig) error {
+	if active := a.GetActiveProject(); active != nil {
+		if err := active.SetPeriodConfig(cfg); err != nil {
+			return err
+		}
+		a.applyPeriodConfig()
+		return nil
+	}
+	return fmt.Errorf("no active project")
+}
+
+// applyPeriodConfig pushes the effective period settings to the stores and asks views to re-aggregate
+func (a *App) applyPeriodConfig() {
+	cfg := a.GetPeriodConfig()
+	if cfg == store.GetPeriodConfig() {
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		msgs.EmitError("Invalid period settings, using UTC", err)
+		cfg = types.PeriodConfig{}
+	}
+	store.SetPeriodConfig(cfg)
+	msgs.EmitManager("active_period_changed")
+}
@@ -2,10 +2,12 @@ package app
 
 import (
 	"testing"
+	"time"
 
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/manager"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/preferences"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/project"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -325,3 +327,17 @@ func TestSetViewAndFacet(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPeriodConfigWithoutProjectUsesUserSettings(t *testing.T) {
+	user := preferences.UserPreferences{Timezone: "America/New_York", WeekStart: 1, FiscalYearStart: 4}
+	expected := types.PeriodConfig{Timezone: "America/New_York", WeekStart: time.Monday, FiscalYearStart: time.April}
+
+	noManager := &App{Preferences: &preferences.Preferences{User: user}}
+	assert.Equal(t, expected, noManager.GetPeriodConfig())
+
+	noProject := &App{
+		Projects:    manager.NewManager[*project.Project]("project"),
+		Preferences: &preferences.Preferences{User: user},
+	}
+	assert.Equal(t, expected, noProject.GetPeriodConfig())
+}
@@ -1,28 +1,58 @@
 import { useCallback, useEffect, useState } from 'react';
 
-import { GetFormat, SetFormat, SilenceDialog } from '@app';
+import {
+  GetCompress,
+  GetExportProfiles,
+  GetExportTemplates,
+  GetFormat,
+  SetCompress,
+  SetFormat,
+  SilenceDialog,
+} from '@app';
 import { StyledButton, StyledModal } from '@components';
-import { Checkbox, Group, Radio, Stack, Text } from '@mantine/core';
+import { Checkbox, Group, Radio, Select, Stack, Text } from '@mantine/core';
+import { preferences, types } from '@models';
 import { LogError } from '@utils';
 
+// ExportScope is what an export covers: the current facet, every loaded facet of the view
+// or every loaded facet of the project. The latter two are bundled in a zip with a manifest.
+export type ExportScope = 'facet' | 'view' | 'project';
+
+export interface ExportOptions {
+  template?: string;
+  scope: ExportScope;
+}
+
 export interface ExportFormatModalProps {
   opened: boolean;
   onClose: () => void;
-  onFormatSelected: (format: string) => void;
+  onFormatSelected: (format: string, options: ExportOptions) => void;
+  payload?: types.Payload | null;
 }
 
 const formatOptions = [
   { value: 'csv', label: 'CSV - Comma separated values (.csv)' },
   { value: 'txt', label: 'TXT - Tab separated values (.txt)' },
   { value: 'json', label: 'JSON - JavaScript Object Notation (.json)' },
+  { value: 'ndjson', label: 'NDJSON - One JSON object per line (.ndjson)' },
+  {
+    value: 'sqlite',
+    label: 'SQLite - One table per facet in a shared database (.sqlite)',
+  },
 ];
 
 export const ExportFormatModal = ({
   opened,
   onClose,
   onFormatSelected,
+  payload,
 }: ExportFormatModalProps) => {
   const [selectedFormat, setSelectedFormat] = useState<string>('csv');
+  const [profiles, setProfiles] = useState<types.ExportProfile[]>([]);
+  const [templates, setTemplates] = useState<preferences.ExportTemplate[]>([]);
+  const [template, setTemplate] = useState<string>('');
+  const [scope, setScope] = useState<ExportScope>('facet');
+  const [compress, setCompress] = useState(false);
   const [dontShowAgain, setDontShowAgain] = useState(false);
   const [loading, setLoading] = useState(false);
 
@@ -30,9 +60,10 @@ export const ExportFormatModal = ({
   useEffect(() => {
     if (opened) {
       setLoading(true);
-      GetFormat()
-        .then((lastFormat: string) => {
+      Promise.all([GetFormat(), GetCompress()])
+        .then(([lastFormat, lastCompress]) => {
           setSelectedFormat(lastFormat || 'csv');
+          setCompress(lastCompress);
         })
         .catch((error: Error) => {
           LogError(`[ExportFormatModal] Error loading format: ${error}`);
@@ -44,11 +75,54 @@ export const ExportFormatModal = ({
     }
   }, [opened]);
 
+  // Offer the export profiles, such as tax software formats, and the saved column templates
+  // of the facet being exported
+  useEffect(() => {
+    setTemplate('');
+    setScope('facet');
+    if (!opened || !payload) {
+      setProfiles([]);
+      setTemplates([]);
+      return;
+    }
+    GetExportProfiles(payload)
+      .then((result) => setProfiles(result || []))
+      .catch((error: Error) => {
+        LogError(`[ExportFormatModal] Error loading export profiles: ${error}`);
+        setProfiles([]);
+      });
+    Promise.all([
+      GetExportTemplates(payload, false),
+      GetExportTemplates(payload, true),
+    ])
+      .then(([own, shared]) => {
+        // A project's template shadows a shared one with the same name
+        const names = new Set((own || []).map((t) => t.name));
+        setTemplates([
+          ...(own || []),
+          ...(shared || []).filter((t) => !names.has(t.name)),
+        ]);
+      })
+      .catch((error: Error) => {
+        LogError(`[ExportFormatModal] Error loading export templates: ${error}`);
+        setTemplates([]);
+      });
+  }, [opened, payload]);
+
+  const isProfileFormat = useCallback(
+    (format: string) => profiles.some((profile) => profile.name === format),
+    [profiles],
+  );
+
   const handleFormatSelect = useCallback(
     async (format: string) => {
       try {
-        // Save the selected format preference
-        await SetFormat(format);
+        // Save the selected format and compression preferences. Profiles apply to one
+        // facet only so they are not remembered as the format.
+        if (!isProfileFormat(format)) {
+          await SetFormat(format);
+        }
+        await SetCompress(compress);
 
         // If user chose "don't show again", silence the dialog
         if (dontShowAgain) {
@@ -56,17 +130,31 @@ export const ExportFormatModal = ({
           LogError('[ExportFormatModal] Export format dialog silenced');
         }
 
-        // Close modal and proceed with export
+        // Close modal and proceed with export. Profiles have their own columns.
         onClose();
-        onFormatSelected(format);
+        onFormatSelected(format, {
+          template: (!isProfileFormat(format) && template) || undefined,
+          scope,
+        });
       } catch (error) {
         LogError(`[ExportFormatModal] Error saving preferences: ${error}`);
         // Still proceed with export even if preference saving fails
         onClose();
-        onFormatSelected(format);
+        onFormatSelected(format, {
+          template: (!isProfileFormat(format) && template) || undefined,
+          scope,
+        });
       }
     },
-    [dontShowAgain, onClose, onFormatSelected],
+    [
+      compress,
+      dontShowAgain,
+      isProfileFormat,
+      onClose,
+      onFormatSelected,
+      scope,
+      template,
+    ],
   );
 
   const handleCancel = () => {
@@ -108,9 +196,63 @@ export const ExportFormatModal = ({
                 disabled={loading}
               />
             ))}
+            {profiles.length > 0 && (
+              <Text variant="dimmed" size="sm" mt="xs">
+                Or reshape it for import into tax software (.csv):
+              </Text>
+            )}
+            {profiles.map((profile) => (
+              <Radio
+                key={profile.name}
+                value={profile.name}
+                label={profile.label}
+                disabled={loading}
+              />
+            ))}
           </Stack>
         </Radio.Group>
 
+        {payload && (
+          <Select
+            label="Export"
+            value={scope}
+            onChange={(value) => setScope((value as ExportScope) || 'facet')}
+            data={[
+              { value: 'facet', label: 'This facet' },
+              { value: 'view', label: 'Every loaded facet of this view (.zip)' },
+              { value: 'project', label: 'Every loaded facet of the project (.zip)' },
+            ]}
+            allowDeselect={false}
+            disabled={loading}
+          />
+        )}
+
+        {templates.length > 0 &&
+          scope === 'facet' &&
+          !isProfileFormat(selectedFormat) && (
+            <Select
+              label="Columns"
+              value={template}
+              onChange={(value) => setTemplate(value || '')}
+              data={[
+                { value: '', label: 'Visible columns' },
+                ...templates.map((t) => ({
+                  value: t.name,
+                  label: `${t.name} (${t.columns.length} columns)`,
+                })),
+              ]}
+              allowDeselect={false}
+              disabled={loading}
+            />
+          )}
+
+        <Checkbox
+          checked={compress}
+          onChange={(event) => setCompress(event.currentTarget.checked)}
+          label="Compress with gzip (.gz)"
+          disabled={loading || selectedFormat === 'sqlite'}
+        />
+
         <Checkbox
           checked={dontShowAgain}
           onChange={(event) => setDontShowAgain(event.currentTarget.checked)}
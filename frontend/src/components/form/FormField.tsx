@@ -33,6 +33,7 @@ export type DataDisplayType =
   | 'value'
   | 'float64'
   | 'float'
+  | 'fiat'
   // Special types (from CSV)
   | 'path'
   | 'url'
@@ -63,6 +64,7 @@ export const NUMERIC_TYPES: Set<DataDisplayType> = new Set([
   'value',
   'float64',
   'float',
+  'fiat',
   'fileSize',
   'timestamp',
   'datetime',
@@ -262,6 +262,11 @@ export const TYPE_RENDERER_REGISTRY: Record<string, TypeRendererConfig> = {
   float64: {
     displayRenderer: (value) => formatNumberWithFallback(value, 2, '0.00'),
   },
+  // Fiat values are computed per page, so they are not sortable; unpriced rows show a dash
+  fiat: {
+    displayRenderer: (value) =>
+      isNullOrEmpty(value) ? '-' : formatNumberWithFallback(value, 2, '0.00'),
+  },
   datetime: {
     displayRenderer: (value, { field, keyProp }) => (
       <DateTimeRenderer value={value} field={field} keyProp={keyProp || ''} />
@@ -81,7 +81,8 @@ export const Table = <T extends Record<string, unknown>>({
   const displayColumns = processColumns(columns, detailCollapsed, actionCount);
   const { pagination, goToPage } = usePagination(viewStateKey);
   const { filter, setFiltering } = useFiltering(viewStateKey);
-  const { getPendingRowAction, setPendingRowAction } = useViewContext();
+  const { getPendingRowAction, setPendingRowAction, updateColumns } =
+    useViewContext();
   const { currentPage, pageSize, totalItems } = pagination;
   const totalPages = Math.ceil(totalItems / pageSize);
 
@@ -95,6 +96,16 @@ export const Table = <T extends Record<string, unknown>>({
 
   const isModalOpenRef = useRef(false);
 
+  const { viewName, facetName } = viewStateKey;
+  const visibleColumns = displayColumns
+    .map((col) => col.key || '')
+    .filter((key) => key && key !== 'actions')
+    .join(',');
+  useEffect(() => {
+    if (!visibleColumns) return;
+    updateColumns({ viewName, facetName }, visibleColumns.split(','));
+  }, [viewName, facetName, visibleColumns, updateColumns]);
+
   useEffect(() => {
     isModalOpenRef.current = isModalOpen;
   }, [isModalOpen]);
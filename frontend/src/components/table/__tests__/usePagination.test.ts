@@ -12,6 +12,7 @@ const baseMockContext = {
   updateSorting: vi.fn(),
   getFiltering: vi.fn(),
   updateFiltering: vi.fn(),
+  updateColumns: vi.fn(),
   restoreProjectFilterStates: vi.fn(),
   getPendingRowAction: vi.fn().mockReturnValue(null),
   setPendingRowAction: vi.fn(),
@@ -5,6 +5,7 @@ import {
   useContext,
   useEffect,
   useMemo,
+  useRef,
   useState,
 } from 'react';
 
@@ -94,6 +95,10 @@ interface ViewContextType {
   ) => void;
   getFiltering: (viewStateKey: project.ViewStateKey) => string;
   updateFiltering: (viewStateKey: project.ViewStateKey, filter: string) => void;
+  updateColumns: (
+    viewStateKey: project.ViewStateKey,
+    columns: string[],
+  ) => void;
   getPendingRowAction: (
     viewStateKey: project.ViewStateKey,
   ) => types.RowActionPayload | null;
@@ -113,6 +118,7 @@ export const ViewContext = createContext<ViewContextType>({
   updateSorting: () => {},
   getFiltering: () => '',
   updateFiltering: () => {},
+  updateColumns: () => {},
   getPendingRowAction: () => null,
   setPendingRowAction: () => {},
   restoreProjectFilterStates: async () => {},
@@ -124,6 +130,7 @@ export const ViewContextProvider = ({ children }: { children: ReactNode }) => {
   const [viewSorting, setViewSorting] = useState<ViewSortState>({});
   const [viewFiltering, setViewFiltering] = useState<ViewFilterState>({});
   const [viewRowAction, setViewRowAction] = useState<ViewRowActionState>({});
+  const persistedColumns = useRef<Record<string, string>>({});
   const [location] = useLocation();
   useEffect(() => {
     const viewName = location.replace(/^\/+/, '') || 'projects'; // Default to 'projects' for root
@@ -257,6 +264,46 @@ export const ViewContextProvider = ({ children }: { children: ReactNode }) => {
     [],
   );
 
+  // Columns are persisted so that exports without a template default to what
+  // the table shows. Nothing is written unless they change.
+  const updateColumns = useCallback(
+    (viewStateKey: project.ViewStateKey, columns: string[]) => {
+      const key = viewStateKeyToString(viewStateKey);
+      const joined = columns.join(',');
+      if (persistedColumns.current[key] === joined) return;
+      persistedColumns.current[key] = joined;
+
+      // Fire-and-forget background persistence
+      (async () => {
+        try {
+          const viewStates = await GetProjectViewState(viewStateKey.viewName);
+          const facetName = viewStateKey.facetName;
+
+          const existingState = viewStates[facetName] || {
+            sorting: {},
+            filtering: {},
+            other: {},
+          };
+          if ((existingState.columns || []).join(',') === joined) return;
+
+          const updatedState: project.ViewFacetState = {
+            ...existingState,
+            columns,
+          };
+          const updatedViewStates = {
+            ...viewStates,
+            [facetName]: updatedState,
+          };
+
+          await SetProjectViewState(viewStateKey.viewName, updatedViewStates);
+        } catch (error) {
+          LogError(`Failed to persist column state to backend: ${error}`);
+        }
+      })();
+    },
+    [],
+  );
+
   const getPendingRowAction = useCallback(
     (viewStateKey: project.ViewStateKey) => {
       const key = viewStateKeyToString(viewStateKey);
@@ -320,6 +367,7 @@ export const ViewContextProvider = ({ children }: { children: ReactNode }) => {
       }
 
       setViewPagination({});
+      persistedColumns.current = {};
     } catch (error) {
       LogError(`Failed to restore project filter states: ${error}`);
     }
@@ -335,6 +383,7 @@ export const ViewContextProvider = ({ children }: { children: ReactNode }) => {
       updateSorting,
       getFiltering,
       updateFiltering,
+      updateColumns,
       getPendingRowAction,
       setPendingRowAction,
       restoreProjectFilterStates,
@@ -348,6 +397,7 @@ export const ViewContextProvider = ({ children }: { children: ReactNode }) => {
       updateSorting,
       getFiltering,
       updateFiltering,
+      updateColumns,
       getPendingRowAction,
       setPendingRowAction,
       restoreProjectFilterStates,
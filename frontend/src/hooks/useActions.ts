@@ -1,6 +1,12 @@
 import { useCallback, useEffect, useMemo, useState } from 'react';
 
-import { ExecuteRowAction, ExportData, IsDialogSilenced } from '@app';
+import {
+  ExecuteRowAction,
+  ExportBundle,
+  ExportData,
+  IsDialogSilenced,
+} from '@app';
+import { ExportOptions } from '@components';
 import { useViewContext } from '@contexts';
 import { crud, project, sdk, types } from '@models';
 import {
@@ -396,7 +402,7 @@ export const useActions = <TPageData extends { totalItems: number }, TItem>(
 
   // Handle format selection from modal
   const handleFormatSelected = useCallback(
-    (format: string) => {
+    (format: string, options: ExportOptions) => {
       const payload = exportFormatModal.pendingPayload;
       if (!payload) {
         LogError('[handleFormatSelected] No pending payload found');
@@ -404,6 +410,15 @@ export const useActions = <TPageData extends { totalItems: number }, TItem>(
       }
 
       payload.format = format;
+      payload.template = options.template;
+      if (options.scope !== 'facet') {
+        ExportBundle(payload, options.scope === 'project').catch((error) => {
+          LogError(
+            `[EXPORT FRONTEND] Bundle export failed for ${collection}: ${error}`,
+          );
+        });
+        return;
+      }
       ExportData(payload)
         .then(() => {
           // do nothing - the backend did it all
@@ -1,7 +1,8 @@
 import { useEffect, useState } from 'react';
 
+import { CancelExport } from '@app';
 import { useEvent, useIconSets } from '@hooks';
-import { msgs } from '@models';
+import { msgs, types } from '@models';
 import { copyToClipboard } from '@utils';
 
 import './StatusBar.css';
@@ -10,6 +11,7 @@ export const StatusBar = () => {
   const [status, setStatus] = useState('');
   const [visible, setVisible] = useState(false);
   const [cn, setCn] = useState('okay');
+  const [exporting, setExporting] = useState<types.Payload | null>(null);
 
   const { Copy } = useIconSets();
   const handleCopyError = async () => {
@@ -36,14 +38,37 @@ export const StatusBar = () => {
     setVisible(true);
   });
 
+  useEvent(
+    msgs.EventType.EXPORT_PROGRESS,
+    (_message: string, progress?: ExportProgress) => {
+      if (!progress) return;
+      if (progress.done) {
+        setExporting(null);
+        return;
+      }
+      setExporting(progress);
+      setCn('okay');
+      setStatus(
+        `Exporting ${progress.dataFacet}: ${progress.written.toLocaleString()} of ${progress.total.toLocaleString()} rows`,
+      );
+      setVisible(true);
+    },
+  );
+
+  const handleCancelExport = () => {
+    if (exporting) {
+      CancelExport(types.Payload.createFrom(exporting));
+    }
+  };
+
   useEffect(() => {
-    if (!visible) return;
+    if (!visible || exporting) return;
     const timeout = cn === 'error' ? 8000 : 1500;
     const timer = setTimeout(() => {
       setVisible(false);
     }, timeout);
     return () => clearTimeout(timer);
-  }, [visible, status, cn]);
+  }, [visible, status, cn, exporting]);
 
   if (!visible) return null;
 
@@ -67,6 +92,24 @@ export const StatusBar = () => {
         />
       )}
       <span>{status}</span>
+      {exporting && (
+        <span
+          style={{ marginLeft: '8px', cursor: 'pointer', opacity: 0.7 }}
+          onClick={handleCancelExport}
+        >
+          Cancel
+        </span>
+      )}
     </div>
   );
 };
+
+// ExportProgress mirrors the backend's types.ExportProgressPayload
+type ExportProgress = types.Payload & {
+  path: string;
+  written: number;
+  total: number;
+  done: boolean;
+  cancelled?: boolean;
+  error?: string;
+};
@@ -14,6 +14,8 @@ export const PeriodLabels = {
   [Period.MONTHLY]: 'Monthly',
   [Period.QUARTERLY]: 'Quarterly',
   [Period.ANNUAL]: 'Annual',
+  [Period.FISCAL_QUARTERLY]: 'Fiscal Quarterly',
+  [Period.FISCAL_ANNUAL]: 'Fiscal Annual',
 } as const;
 
 // Options for Select components
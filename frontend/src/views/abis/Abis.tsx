@@ -9,9 +9,10 @@
 import { useCallback, useEffect, useMemo, useRef, useState } from 'react';
 
 import { GetAbisPage, Reload } from '@app';
-import { AbisCrud } from '@app';
+import { AbisCrud, ChooseSignatureFile, ImportSignatures } from '@app';
 import { BaseTab, usePagination } from '@components';
 import { Action, ConfirmModal, ExportFormatModal } from '@components';
+import { FormField } from '@components';
 import { createDetailPanel } from '@components';
 import { useFiltering, useSorting } from '@contexts';
 import {
@@ -26,13 +27,14 @@ import {
   useViewConfig,
 } from '@hooks';
 import { TabView } from '@layout';
-import { Group } from '@mantine/core';
+import { Alert, Group } from '@mantine/core';
 import { useHotkeys } from '@mantine/hooks';
 import { abis } from '@models';
 import { msgs, project, types } from '@models';
 import { Debugger, LogError, useErrorHandler } from '@utils';
 
 import { assertRouteConsistency } from '../routes';
+import { ImportAbiModal } from './ImportAbiModal';
 import { ROUTE } from './constants';
 
 export const Abis = () => {
@@ -54,6 +56,7 @@ export const Abis = () => {
   const { availableFacets, getCurrentDataFacet } = activeFacetHook;
 
   const [pageData, setPageData] = useState<abis.AbisPage | null>(null);
+  const [importOpened, setImportOpened] = useState(false);
   const viewStateKey = useMemo(
     (): project.ViewStateKey => ({
       viewName: ROUTE,
@@ -104,7 +107,7 @@ export const Abis = () => {
       case types.DataFacet.KNOWN:
         return pageData.abis || [];
       case types.DataFacet.FUNCTIONS:
-        return pageData.functions || [];
+        return withCollisions(pageData.functions || [], pageData.collidesWith);
       case types.DataFacet.EVENTS:
         return pageData.functions || [];
       default:
@@ -169,10 +172,33 @@ export const Abis = () => {
     getCurrentDataFacet,
   });
   const { handleAutoname, handleRemove } = handlers;
+
+  const handleImportSignatures = useCallback(async () => {
+    try {
+      const path = await ChooseSignatureFile();
+      if (path) await ImportSignatures(path);
+    } catch (err) {
+      LogError(`[Abis] import signatures: ${err}`);
+      handleError(err, 'Failed to import signatures');
+    }
+  }, [handleError]);
+
   const headerActions = useMemo(() => {
     if (!config.headerActions.length) return null;
     return (
       <Group gap="xs" style={{ flexShrink: 0 }}>
+        <Action
+          icon="Create"
+          onClick={() => setImportOpened(true)}
+          title="Import an ABI or compiler artifact for an address"
+          size="sm"
+        />
+        <Action
+          icon="File"
+          onClick={handleImportSignatures}
+          title="Import a signature dump for decoding unknown calls and logs"
+          size="sm"
+        />
         {config.headerActions.map((action) => {
           const handlerKey =
             `handle${action.type.charAt(0).toUpperCase() + action.type.slice(1)}` as keyof typeof handlers;
@@ -198,10 +224,15 @@ export const Abis = () => {
         })}
       </Group>
     );
-  }, [config.headerActions, config.isWalletConnected, handlers]);
+  }, [
+    config.headerActions,
+    config.isWalletConnected,
+    handlers,
+    handleImportSignatures,
+  ]);
 
   // === SECTION 6: UI Configuration ===
-  const currentColumns = useFacetColumns(
+  const facetColumns = useFacetColumns(
     viewConfig,
     getCurrentDataFacet,
     {
@@ -219,6 +250,13 @@ export const Abis = () => {
     pageData,
     config,
   );
+  const currentColumns = useMemo(
+    () =>
+      getCurrentDataFacet() === types.DataFacet.FUNCTIONS
+        ? withCollisionColumn(facetColumns)
+        : facetColumns,
+    [facetColumns, getCurrentDataFacet],
+  );
 
   const detailPanel = useMemo(
     () =>
@@ -244,22 +282,37 @@ export const Abis = () => {
 
   const perTabContent = useMemo(() => {
     if (isCanvas && formNode) return formNode;
+    const nCollisions =
+      getCurrentDataFacet() === types.DataFacet.FUNCTIONS
+        ? pageData?.nCollisions || 0
+        : 0;
     return (
-      <BaseTab<Record<string, unknown>>
-        data={currentData as unknown as Record<string, unknown>[]}
-        columns={currentColumns}
-        state={pageData?.state || types.StoreState.STALE}
-        error={error}
-        viewStateKey={viewStateKey}
-        headerActions={headerActions}
-        detailPanel={detailPanel}
-        onRemove={(rowData) => handleRemove(String(rowData.address || ''))}
-        onAutoname={(rowData) => handleAutoname(String(rowData.address || ''))}
-      />
+      <>
+        {nCollisions > 0 && (
+          <Alert variant="light" color="yellow" mb="sm">
+            {nCollisions} {nCollisions === 1 ? 'selector is' : 'selectors are'}{' '}
+            shared by more than one function signature. Calls to these
+            selectors may be decoded as the wrong function.
+          </Alert>
+        )}
+        <BaseTab<Record<string, unknown>>
+          data={currentData as unknown as Record<string, unknown>[]}
+          columns={currentColumns}
+          state={pageData?.state || types.StoreState.STALE}
+          error={error}
+          viewStateKey={viewStateKey}
+          headerActions={headerActions}
+          detailPanel={detailPanel}
+          onRemove={(rowData) => handleRemove(String(rowData.address || ''))}
+          onAutoname={(rowData) => handleAutoname(String(rowData.address || ''))}
+        />
+      </>
     );
   }, [
     currentData,
     currentColumns,
+    getCurrentDataFacet,
+    pageData?.nCollisions,
     pageData?.state,
     error,
     viewStateKey,
@@ -310,14 +363,55 @@ export const Abis = () => {
         message={confirmModal.message}
         dialogKey={confirmModal.dialogKey}
       />
+      <ImportAbiModal
+        opened={importOpened}
+        onCancel={() => setImportOpened(false)}
+        onSubmit={() => {
+          setImportOpened(false);
+          fetchData();
+        }}
+      />
       <ExportFormatModal
         opened={exportFormatModal.opened}
         onClose={exportFormatModal.onClose}
         onFormatSelected={exportFormatModal.onFormatSelected}
+        payload={exportFormatModal.pendingPayload}
       />
     </div>
   );
 };
 
 // EXISTING_CODE
+// Merges the functions that share each row's selector, which line up with the rows, into the row
+function withCollisions<T extends object>(
+  rows: T[],
+  collidesWith?: string[][],
+): T[] {
+  if (!collidesWith?.length) return rows;
+  return rows.map((row, i) =>
+    collidesWith[i]?.length
+      ? { ...row, collidesWith: collidesWith[i].join(', ') }
+      : row,
+  );
+}
+
+// Adds a column listing the other signatures that share a function's selector
+function withCollisionColumn(
+  columns: FormField<Record<string, unknown>>[],
+): FormField<Record<string, unknown>>[] {
+  const collides: FormField<Record<string, unknown>> = {
+    key: 'collidesWith',
+    name: 'collidesWith',
+    header: 'Collides With',
+    label: 'Collides With',
+    type: 'string',
+    sortable: false,
+    value: '',
+  };
+  const last = columns[columns.length - 1];
+  if (last?.key === 'actions') {
+    return [...columns.slice(0, -1), collides, last];
+  }
+  return [...columns, collides];
+}
 // EXISTING_CODE
@@ -0,0 +1,117 @@
+import { useState } from 'react';
+
+import { AbisCrud, ChooseAbiFile } from '@app';
+import { StyledButton, StyledModal } from '@components';
+import { usePayload } from '@hooks';
+import { Group, Stack, Text, TextInput } from '@mantine/core';
+import { crud, types } from '@models';
+import { LogError } from '@utils';
+
+import { ROUTE } from './constants';
+
+interface ImportAbiModalProps {
+  opened: boolean;
+  onSubmit: () => void;
+  onCancel: () => void;
+}
+
+// ImportAbiModal associates an ABI file or a compiler artifact, such as Hardhat's or
+// Foundry's build output, with an address
+export const ImportAbiModal = ({
+  opened,
+  onSubmit,
+  onCancel,
+}: ImportAbiModalProps) => {
+  const createPayload = usePayload(ROUTE);
+  const [address, setAddress] = useState('');
+  const [path, setPath] = useState('');
+  const [loading, setLoading] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+  const validAddress = /^0x[0-9a-fA-F]{40}$/.test(address);
+
+  const handleChoose = async () => {
+    try {
+      const selected = await ChooseAbiFile();
+      if (selected) setPath(selected);
+    } catch (err) {
+      LogError(`[Abis] choose file: ${err}`);
+      setError(String(err));
+    }
+  };
+
+  const handleImport = async () => {
+    if (loading || !validAddress || !path) return;
+    setLoading(true);
+    setError(null);
+    try {
+      const payload = createPayload(types.DataFacet.DOWNLOADED, address);
+      await AbisCrud(payload, crud.Operation.CREATE, { address, path });
+      setAddress('');
+      setPath('');
+      onSubmit();
+    } catch (err) {
+      LogError(`[Abis] import: ${err}`);
+      setError(String(err));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <StyledModal
+      opened={opened}
+      onClose={onCancel}
+      centered
+      withCloseButton
+      closeOnClickOutside
+      closeOnEscape
+      title="Import ABI"
+    >
+      <Stack gap="md">
+        <Text variant="dimmed" size="sm">
+          Use an ABI that is not on any explorer, such as a proxy's
+          implementation or a diamond's facets, for an address
+        </Text>
+
+        {error && (
+          <Text variant="error" size="sm">
+            Error: {error}
+          </Text>
+        )}
+
+        <TextInput
+          label="Address"
+          placeholder="0x…"
+          value={address}
+          onChange={(e) => setAddress(e.currentTarget.value.trim())}
+          error={address && !validAddress ? 'Not an address' : undefined}
+        />
+        <Group gap="xs" align="flex-end" wrap="nowrap">
+          <TextInput
+            label="ABI or artifact file"
+            placeholder="abi.json"
+            value={path}
+            onChange={(e) => setPath(e.currentTarget.value)}
+            style={{ flex: 1 }}
+          />
+          <StyledButton variant="outline" onClick={handleChoose}>
+            Choose…
+          </StyledButton>
+        </Group>
+
+        <Group justify="flex-end" gap="sm">
+          <StyledButton variant="outline" onClick={onCancel} disabled={loading}>
+            Cancel
+          </StyledButton>
+          <StyledButton
+            onClick={handleImport}
+            loading={loading}
+            disabled={!validAddress || !path}
+          >
+            Import
+          </StyledButton>
+        </Group>
+      </Stack>
+    </StyledModal>
+  );
+};
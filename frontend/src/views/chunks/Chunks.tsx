@@ -303,6 +303,7 @@ export const Chunks = () => {
         opened={exportFormatModal.opened}
         onClose={exportFormatModal.onClose}
         onFormatSelected={exportFormatModal.onFormatSelected}
+        payload={exportFormatModal.pendingPayload}
       />
     </div>
   );
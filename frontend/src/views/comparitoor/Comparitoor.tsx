@@ -102,7 +102,10 @@ export const Comparitoor = () => {
     const facet = getCurrentDataFacet();
     switch (facet) {
       case types.DataFacet.COMPARITOOR:
-        return pageData.transaction || [];
+        return withComparisons(
+          pageData.transaction || [],
+          pageData.comparisons,
+        );
       case types.DataFacet.CHIFRA:
         return pageData.transaction || [];
       case types.DataFacet.ETHERSCAN:
@@ -308,10 +311,31 @@ export const Comparitoor = () => {
         opened={exportFormatModal.opened}
         onClose={exportFormatModal.onClose}
         onFormatSelected={exportFormatModal.onFormatSelected}
+        payload={exportFormatModal.pendingPayload}
       />
     </div>
   );
 };
 
 // EXISTING_CODE
+// Merges the page's comparisons, which line up with its rows, into each row
+function withComparisons<T extends object>(
+  rows: T[],
+  comparisons?: comparitoor.Comparison[],
+): T[] {
+  if (!comparisons?.length) return rows;
+  return rows.map((row, i) => {
+    const c = comparisons[i];
+    if (!c?.class) return row;
+    return {
+      ...row,
+      class: c.class,
+      label: c.label,
+      category: c.category,
+      present: c.present,
+      missing: c.missing,
+      diffs: c.diffs,
+    };
+  });
+}
 // EXISTING_CODE
@@ -1,6 +1,9 @@
-import { useMemo } from 'react';
+import { useEffect, useMemo, useState } from 'react';
 
-import { comparitoor } from '@models';
+import { GetComparitoorSummary } from '@app';
+import { usePayload } from '@hooks';
+import { comparitoor, types } from '@models';
+import { LogError } from '@utils';
 
 export type AppearanceItem = {
   blockNum: string;
@@ -8,6 +11,7 @@ export type AppearanceItem = {
   value: string;
   missing?: boolean;
   unique?: boolean;
+  mismatch?: boolean;
 };
 
 export type ComparitoorSource = {
@@ -17,44 +21,91 @@ export type ComparitoorSource = {
   stats: { appearances: number; time?: number; unique: number };
 };
 
-const sourceDefs = [
-  { key: 'chifra', label: 'Chifra', field: 'chifra' },
-  { key: 'etherscan', label: 'EtherScan', field: 'etherscan' },
-  { key: 'covalent', label: 'Covalent', field: 'covalent' },
-  { key: 'alchemy', label: 'Alchemy', field: 'alchemy' },
-];
-
-type AnnotatedTxType = {
+// ComparedRow is a comparitoor row with its comparison merged in
+export type ComparedRow = {
   blockNumber?: number;
   transactionIndex?: number;
-  missing?: boolean;
-  unique?: boolean;
+  class?: string;
+  label?: string;
+  category?: string;
+  present?: string[];
+  missing?: string[];
+  diffs?: comparitoor.FieldDiff[];
 };
 
-export function useComparitoorData(page: comparitoor.ComparitoorPage | null) {
+const sourceDefs = [
+  { key: 'chifra', label: 'Chifra' },
+  { key: 'etherscan', label: 'EtherScan' },
+  { key: 'covalent', label: 'Covalent' },
+  { key: 'alchemy', label: 'Alchemy' },
+];
+
+export function useComparitoorData(rows: ComparedRow[]) {
   return useMemo(() => {
-    if (!page) return [];
     return sourceDefs.map((src) => {
-      const arr = page[src.field as keyof typeof page] as
-        | AnnotatedTxType[]
-        | undefined;
-      const data: AppearanceItem[] = (arr || []).map((tx) => ({
-        blockNum: tx.blockNumber !== undefined ? String(tx.blockNumber) : '',
-        txid:
-          tx.transactionIndex !== undefined ? String(tx.transactionIndex) : '',
-        value: `${tx.blockNumber ?? ''}.${tx.transactionIndex ?? ''}`,
-        missing: !!tx.missing,
-        unique: !!tx.unique,
-      }));
+      const data: AppearanceItem[] = rows.map((row) => {
+        const present = (row.present || []).includes(src.key);
+        return {
+          blockNum:
+            row.blockNumber !== undefined ? String(row.blockNumber) : '',
+          txid:
+            row.transactionIndex !== undefined
+              ? String(row.transactionIndex)
+              : '',
+          value: `${row.blockNumber ?? ''}.${row.transactionIndex ?? ''}`,
+          missing: !present,
+          unique: present && row.class === 'extra-in',
+          mismatch:
+            present &&
+            (row.diffs || []).some((d) => d.values?.[src.key] !== undefined),
+        };
+      });
       return {
         key: src.key,
         label: src.label,
         data,
         stats: {
-          appearances: arr?.length ?? 0,
+          appearances: data.filter((item) => !item.missing).length,
           unique: data.filter((item) => item.unique).length,
         },
       };
     });
-  }, [page]);
+  }, [rows]);
+}
+
+// Score and Scorecard mirror the scorecard the backend puts in the summary's customData
+export type Score = {
+  provider: string;
+  union: number;
+  found: number;
+  confirmed: number;
+  mismatched: number;
+  recall: number;
+  precision: number;
+  mismatchRate: number;
+};
+
+export type Scorecard = {
+  sources: string[];
+  failed?: Record<string, string>;
+  rangeSize: number;
+  overall: Score[];
+  byCategory: Record<string, Score[]>;
+  byRange: { startBlock: number; endBlock: number; scores: Score[] }[];
+};
+
+// useComparitoorScorecard reads the providers' scorecard whenever the rows change
+export function useComparitoorScorecard(rows: ComparedRow[]) {
+  const createPayload = usePayload('comparitoor');
+  const [scorecard, setScorecard] = useState<Scorecard | null>(null);
+
+  useEffect(() => {
+    GetComparitoorSummary(createPayload(types.DataFacet.COMPARITOOR))
+      .then((summary) => {
+        setScorecard((summary?.customData?.scorecard as Scorecard) || null);
+      })
+      .catch((err) => LogError(`[Comparitoor] scorecard: ${err}`));
+  }, [createPayload, rows]);
+
+  return scorecard;
 }
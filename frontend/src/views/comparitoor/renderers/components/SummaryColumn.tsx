@@ -2,9 +2,13 @@ import { useState } from 'react';
 
 import { StyledSelect } from '@components';
 import { BarChart } from '@mantine/charts';
-import { Divider, Paper, Stack, Text, Title } from '@mantine/core';
+import { Divider, Paper, Stack, Table, Text, Title } from '@mantine/core';
 
-import type { AppearanceItem } from '../../hooks/useComparitoorData';
+import type {
+  AppearanceItem,
+  ComparedRow,
+  Scorecard,
+} from '../../hooks/useComparitoorData';
 
 type ActiveType = {
   source: string;
@@ -19,6 +23,16 @@ const effectivenessOptions = [
   { value: 'present', label: 'Present' },
 ] as const;
 
+const categoryOptions = [
+  { value: 'overall', label: 'All transactions' },
+  { value: 'external', label: 'External' },
+  { value: 'internal', label: 'Internal' },
+  { value: 'token', label: 'Token transfers' },
+  { value: 'failed', label: 'Failed' },
+];
+
+const percent = (v: number) => `${(v * 100).toFixed(1)}%`;
+
 type Source = {
   key: string;
   label: string;
@@ -37,6 +51,8 @@ export type SummaryColumnProps = {
   rowValues: AppearanceItem[] | null;
   sourceKeys: string[];
   sources: Source[];
+  comparison?: ComparedRow;
+  scorecard?: Scorecard | null;
   unionStats?: UnionStats;
 };
 
@@ -75,10 +91,17 @@ export const SummaryColumn = ({
   rowValues,
   sourceKeys,
   sources,
+  comparison,
+  scorecard,
   unionStats,
 }: SummaryColumnProps) => {
   const [effectivenessMetric, setEffectivenessMetric] =
     useState<(typeof effectivenessOptions)[number]['value']>('total');
+  const [category, setCategory] = useState('overall');
+  const scores =
+    category === 'overall'
+      ? scorecard?.overall
+      : scorecard?.byCategory?.[category];
 
   return (
     <Paper
@@ -143,6 +166,42 @@ export const SummaryColumn = ({
             />
           </>
         ) : null}
+        {scorecard ? (
+          <>
+            <Divider my={8} label="Scorecard" labelPosition="center" />
+            <StyledSelect
+              data={categoryOptions}
+              value={category}
+              onChange={(value) => value && setCategory(value)}
+              size="xs"
+            />
+            <Table fz="xs" withRowBorders={false}>
+              <Table.Thead>
+                <Table.Tr>
+                  <Table.Th>Provider</Table.Th>
+                  <Table.Th>Recall</Table.Th>
+                  <Table.Th>Precision</Table.Th>
+                  <Table.Th>Mismatch</Table.Th>
+                </Table.Tr>
+              </Table.Thead>
+              <Table.Tbody>
+                {(scores || []).map((score) => (
+                  <Table.Tr key={score.provider}>
+                    <Table.Td>{score.provider}</Table.Td>
+                    <Table.Td>{percent(score.recall)}</Table.Td>
+                    <Table.Td>{percent(score.precision)}</Table.Td>
+                    <Table.Td>{percent(score.mismatchRate)}</Table.Td>
+                  </Table.Tr>
+                ))}
+              </Table.Tbody>
+            </Table>
+            {Object.entries(scorecard.failed || {}).map(([provider, err]) => (
+              <Text key={provider} size="xs" variant="error">
+                {provider} failed: {err}
+              </Text>
+            ))}
+          </>
+        ) : null}
         {active && rowValues ? (
           <>
             <Text variant="primary" size="sm" fw={600}>
@@ -158,6 +217,19 @@ export const SummaryColumn = ({
                 </div>
               ))}
             </Stack>
+            {comparison?.label ? (
+              <Text variant="primary" size="sm">
+                {comparison.label}
+              </Text>
+            ) : null}
+            {(comparison?.diffs || []).map((diff) => (
+              <Text key={diff.field} variant="warning" size="sm">
+                <b>{diff.field}</b>:{' '}
+                {Object.entries(diff.values || {})
+                  .map(([source, value]) => `${source}=${value}`)
+                  .join(', ')}
+              </Text>
+            ))}
           </>
         ) : null}
       </Stack>
@@ -19,9 +19,11 @@ import {
   Title,
   useMantineTheme,
 } from '@mantine/core';
-import { comparitoor } from '@models';
-
-import { useComparitoorData } from '../../../hooks/useComparitoorData';
+import {
+  ComparedRow,
+  useComparitoorData,
+  useComparitoorScorecard,
+} from '../../../hooks/useComparitoorData';
 import { SummaryColumn } from '../../components/SummaryColumn';
 
 export type AppearanceItem = {
@@ -30,6 +32,7 @@ export type AppearanceItem = {
   value: string;
   missing?: boolean;
   unique?: boolean;
+  mismatch?: boolean;
 };
 
 // EXISTING_CODE
@@ -37,7 +40,7 @@ export type AppearanceItem = {
 export const ComparitoorFacet = ({ params }: { params: RendererParams }) => {
   // EXISTING_CODE
   const { data } = params;
-  const pageData = data;
+  const rows = data as ComparedRow[];
   const address = '0x503017d7baf7fbc0fff7492b751025c6a78179b'; // Default address for now
   const containerRef = useRef<HTMLDivElement>(null);
   const theme = useMantineTheme();
@@ -88,21 +91,15 @@ export const ComparitoorFacet = ({ params }: { params: RendererParams }) => {
     setActive({ sourceIdx, itemIdx });
   }
 
-  // Transform raw pageData
-  const transformed = pageData
-    ? ({
-        transaction: pageData,
-        chifra: pageData,
-        etherscan: pageData,
-        covalent: pageData,
-        alchemy: pageData,
-        unionCount: pageData.length,
-        overlapCount: 0,
-        intersectionCount: 0,
-      } as unknown as comparitoor.ComparitoorPage)
-    : null;
-
-  const sources = useComparitoorData(transformed);
+  const sources = useComparitoorData(rows);
+  const scorecard = useComparitoorScorecard(rows);
+  const unionStats = {
+    unionCount: rows.length,
+    overlapCount: rows.filter((row) => (row.present || []).length > 1).length,
+    intersectionCount: rows.filter(
+      (row) => row.class === 'present-in-all' || row.class === 'field-mismatch',
+    ).length,
+  };
 
   // Data-driven row style: assign by item properties only
   // getRowStyle removed (no longer used)
@@ -172,7 +169,11 @@ export const ComparitoorFacet = ({ params }: { params: RendererParams }) => {
                     } else if (isMatching) {
                       borderStyle = `2px solid ${theme.colors.blue[2]}`;
                     }
-                    var variant = isMissing ? 'error' : 'warning';
+                    var variant = isMissing
+                      ? 'error'
+                      : item.mismatch
+                        ? 'warning'
+                        : 'primary';
                     return (
                       <Group
                         key={item.value + itemIdx}
@@ -193,9 +194,7 @@ export const ComparitoorFacet = ({ params }: { params: RendererParams }) => {
                         <Text variant={variant} size="sm">
                           {isMissing ? '[missing]' : item.value}
                         </Text>
-                        {item.value === '100.100' && src.key === 'chifra' ? (
-                          <MaterialIcon />
-                        ) : null}
+                        {item.unique ? <MaterialIcon /> : null}
                       </Group>
                     );
                   })}
@@ -235,15 +234,9 @@ export const ComparitoorFacet = ({ params }: { params: RendererParams }) => {
           }
           sourceKeys={sources.map((src) => src.key)}
           sources={sources}
-          unionStats={
-            transformed
-              ? {
-                  unionCount: transformed.unionCount,
-                  overlapCount: transformed.overlapCount,
-                  intersectionCount: transformed.intersectionCount,
-                }
-              : undefined
-          }
+          comparison={active ? rows[active.itemIdx] : undefined}
+          scorecard={scorecard}
+          unionStats={unionStats}
         />
       </Box>
       {/** Legend removed as per requirements */}
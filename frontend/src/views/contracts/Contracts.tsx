@@ -11,6 +11,7 @@ import { useCallback, useEffect, useMemo, useRef, useState } from 'react';
 import { GetContractsPage, Reload } from '@app';
 import { BaseTab, usePagination } from '@components';
 import { Action, ConfirmModal, ExportFormatModal } from '@components';
+import { FormField, StyledSelect } from '@components';
 import { createDetailPanel } from '@components';
 import { useFiltering, useSorting } from '@contexts';
 import {
@@ -68,6 +69,7 @@ export const Contracts = () => {
   const { pagination, setTotalItems, goToPage } = usePagination(viewStateKey);
   const { sort } = useSorting(viewStateKey);
   const { filter } = useFiltering(viewStateKey);
+  const [eventTopic, setEventTopic] = useState('');
 
   // === SECTION 3: Data Fetching ===
   const fetchData = useCallback(async () => {
@@ -78,7 +80,7 @@ export const Contracts = () => {
         pagination.currentPage * pagination.pageSize,
         pagination.pageSize,
         sort,
-        filter,
+        withEventFilter(getCurrentDataFacet(), filter, eventTopic),
       );
       setPageData(result);
       setTotalItems(result.totalItems || 0);
@@ -93,6 +95,7 @@ export const Contracts = () => {
     pagination.pageSize,
     sort,
     filter,
+    eventTopic,
     setTotalItems,
     handleError,
   ]);
@@ -102,11 +105,17 @@ export const Contracts = () => {
     const facet = getCurrentDataFacet();
     switch (facet) {
       case types.DataFacet.DASHBOARD:
-        return pageData.contracts || [];
+        return withDeployedBlocks(
+          pageData.contracts || [],
+          pageData.deployedBlocks,
+        );
       case types.DataFacet.EXECUTE:
-        return pageData.contracts || [];
+        return withDeployedBlocks(
+          pageData.contracts || [],
+          pageData.deployedBlocks,
+        );
       case types.DataFacet.EVENTS:
-        return pageData.logs || [];
+        return withEventValues(pageData.logs || [], pageData.eventValues);
       default:
         LogError('[Contracts] unexpected facet=' + String(facet));
         return [];
@@ -168,10 +177,37 @@ export const Contracts = () => {
     createPayload,
     getCurrentDataFacet,
   });
+  const eventViews = useMemo(
+    () =>
+      getCurrentDataFacet() === types.DataFacet.EVENTS
+        ? pageData?.events || []
+        : [],
+    [pageData?.events, getCurrentDataFacet],
+  );
+  const selectedEvent = useMemo(
+    () => eventViews.find((v) => v.topic === eventTopic),
+    [eventViews, eventTopic],
+  );
+
   const headerActions = useMemo(() => {
     if (!config.headerActions.length) return null;
     return (
       <Group gap="xs" style={{ flexShrink: 0 }}>
+        {eventViews.length > 0 && (
+          <StyledSelect
+            size="xs"
+            w={220}
+            value={eventTopic}
+            onChange={(value) => setEventTopic(value || '')}
+            data={[
+              { value: '', label: 'All events' },
+              ...eventViews.map((v) => ({
+                value: v.topic,
+                label: `${v.name} (${v.count})`,
+              })),
+            ]}
+          />
+        )}
         {config.headerActions.map((action) => {
           const handlerKey =
             `handle${action.type.charAt(0).toUpperCase() + action.type.slice(1)}` as keyof typeof handlers;
@@ -197,10 +233,16 @@ export const Contracts = () => {
         })}
       </Group>
     );
-  }, [config.headerActions, config.isWalletConnected, handlers]);
+  }, [
+    config.headerActions,
+    config.isWalletConnected,
+    handlers,
+    eventViews,
+    eventTopic,
+  ]);
 
   // === SECTION 6: UI Configuration ===
-  const currentColumns = useFacetColumns(
+  const facetColumns = useFacetColumns(
     viewConfig,
     getCurrentDataFacet,
     {
@@ -212,6 +254,10 @@ export const Contracts = () => {
     pageData,
     { rowActions: [] },
   );
+  const currentColumns = useMemo(
+    () => withEventColumns(facetColumns, selectedEvent),
+    [facetColumns, selectedEvent],
+  );
 
   const detailPanel = useMemo(
     () =>
@@ -304,10 +350,64 @@ export const Contracts = () => {
         opened={exportFormatModal.opened}
         onClose={exportFormatModal.onClose}
         onFormatSelected={exportFormatModal.onFormatSelected}
+        payload={exportFormatModal.pendingPayload}
       />
     </div>
   );
 };
 
 // EXISTING_CODE
+// Merges the page's deployment blocks, which line up with its rows, into each row
+function withDeployedBlocks<T extends object>(
+  rows: T[],
+  deployedBlocks?: number[],
+): T[] {
+  if (!deployedBlocks?.length) return rows;
+  return rows.map((row, i) =>
+    deployedBlocks[i] ? { ...row, deployedBlock: deployedBlocks[i] } : row,
+  );
+}
+
+// Narrows the events facet to the selected event's logs by its topic
+function withEventFilter(
+  facet: types.DataFacet,
+  filter: string,
+  eventTopic: string,
+): string {
+  if (facet !== types.DataFacet.EVENTS || !eventTopic) return filter;
+  return `event:${eventTopic} ${filter}`.trim();
+}
+
+// Merges each log's decoded parameters, which line up with the rows, into the row
+function withEventValues<T extends object>(
+  rows: T[],
+  eventValues?: Record<string, string>[],
+): T[] {
+  if (!eventValues?.length) return rows;
+  return rows.map((row, i) =>
+    eventValues[i] ? { ...eventValues[i], ...row } : row,
+  );
+}
+
+// Adds a sortable column for each of the selected event's parameters ahead of the actions
+function withEventColumns(
+  columns: FormField<Record<string, unknown>>[],
+  event?: contracts.EventView,
+): FormField<Record<string, unknown>>[] {
+  if (!event?.columns?.length) return columns;
+  const decoded = event.columns.map((col) => ({
+    key: col.key,
+    name: col.key,
+    header: col.header,
+    label: col.header,
+    type: col.type as FormField['type'],
+    sortable: col.sortable,
+    value: '',
+  }));
+  const last = columns[columns.length - 1];
+  if (last?.key === 'actions') {
+    return [...columns.slice(0, -1), ...decoded, last];
+  }
+  return [...columns, ...decoded];
+}
 // EXISTING_CODE
@@ -1,4 +1,4 @@
-import React, { useEffect, useMemo, useState } from 'react';
+import React, { useMemo, useState } from 'react';
 
 import { StyledBadge, StyledButton } from '@components';
 import {
@@ -9,254 +9,40 @@ import {
   Loader,
   Stack,
   Text,
+  TextInput,
   Tooltip,
 } from '@mantine/core';
-import { types } from '@models';
+import { contracts, types } from '@models';
 
 import { getReadFunctions } from './facetCreation';
+import { useContractState } from './useContractState';
 
 interface ContractDashboardProps {
   contractState: types.Contract;
 }
 
-interface FunctionResult {
-  function: types.Function;
-  loading: boolean;
-  lastUpdated?: number;
-}
-
 export const ContractDashboard: React.FC<ContractDashboardProps> = ({
   contractState,
 }) => {
-  const [functionResults, setFunctionResults] = useState<
-    Record<string, FunctionResult>
-  >({});
-  const [loading, setLoading] = useState(true);
-
-  const readFunctions = useMemo(() => {
-    const functions = contractState.abi
-      ? getReadFunctions(contractState.abi)
-      : [];
-    return functions;
-  }, [contractState.abi]);
-
-  // Initialize function results state
-  useEffect(() => {
-    const initialResults: Record<string, FunctionResult> = {};
-    readFunctions.forEach((func) => {
-      let functionWithResults = func;
-      if (contractState.readResults?.[func.name] !== undefined) {
-        const result = contractState.readResults[func.name];
-
-        // DINGLEBERRY: USE THE PARAMETER VALUES SINCE THIS NEVER IS NOT TRUE
-        // Compare readResults with ABI output values
-        // Find the matching function in the ABI by name
-        // const abiFunctions = contractState.abi?.functions || [];
-        // const matchingAbiFunction = abiFunctions.find(
-        //   (abiFunc) => abiFunc.name === func.name,
-        // );
-
-        // if (
-        //   matchingAbiFunction &&
-        //   matchingAbiFunction.outputs &&
-        //   matchingAbiFunction.outputs.length > 0
-        // ) {
-        //   matchingAbiFunction.outputs.forEach((output, index) => {
-        // const abiOutputValue = output.value;
-        // const readResultValue = Array.isArray(result)
-        //   ? result[index]
-        //   : result;
-        // // const isMatch =
-        // //   JSON.stringify(abiOutputValue) ===
-        // //   JSON.stringify(readResultValue);
-        // const status = isMatch ? '✅' : '❌';
-        // Log(
-        //   `Function ${func.name} output ${index} match: ${status} (ABI: ${JSON.stringify(
-        //     abiOutputValue,
-        //   )}, Read Result: ${JSON.stringify(readResultValue)})`,
-        // );
-        //   });
-        // }
-
-        if (func.outputs && func.outputs.length > 0) {
-          const updatedOutputs = func.outputs.map((output, index) => {
-            const outputValue =
-              func.outputs.length === 1
-                ? result
-                : Array.isArray(result)
-                  ? result[index]
-                  : result;
-
-            return types.Parameter.createFrom({
-              ...output,
-              value: outputValue,
-            });
-          });
-
-          functionWithResults = types.Function.createFrom({
-            ...func,
-            outputs: updatedOutputs,
-          });
-        }
-      }
-
-      initialResults[func.name] = {
-        function: functionWithResults,
-        loading: false,
-        lastUpdated: Number(contractState.lastUpdated),
-      };
-    });
-
-    setFunctionResults(initialResults);
-    setLoading(false);
-  }, [contractState, readFunctions]);
-
-  // Call a specific read function
-  const _callReadFunction = async (func: types.Function) => {
-    const currentResult = functionResults[func.name];
-    if (!currentResult) return;
-
-    setFunctionResults((prev) => ({
-      ...prev,
-      [func.name]: {
-        ...currentResult,
-        loading: true,
-      },
-    }));
-
-    try {
-      // TODO: Replace with actual API call to execute read function
-      const response = await fetch(
-        `/api/contracts/${contractState.address}/call/${func.name}`,
-        {
-          method: 'POST',
-          headers: { 'Content-Type': 'application/json' },
-          body: JSON.stringify({
-            function: func,
-            inputs: [], // Read functions typically have no inputs or use defaults
-          }),
-        },
-      );
-
-      if (!response.ok) {
-        throw new Error(`Failed to call function ${func.name}`);
-      }
-
-      const result = await response.json();
-
-      // Update the function with the new result
-      const currentFunction = currentResult.function;
-      if (currentFunction) {
-        // Create updated outputs with new values
-        const updatedOutputs = currentFunction.outputs.map(
-          (output: types.Parameter, index: number) =>
-            types.Parameter.createFrom({
-              ...output,
-              value: Array.isArray(result.data)
-                ? result.data[index]
-                : result.data,
-            }),
-        );
-
-        // Create updated function with new outputs
-        const updatedFunction = types.Function.createFrom({
-          ...currentFunction,
-          outputs: updatedOutputs,
-          message: undefined, // Clear any previous error
-        });
-
-        setFunctionResults((prev) => ({
-          ...prev,
-          [func.name]: {
-            function: updatedFunction,
-            loading: false,
-            lastUpdated: Date.now(),
-          },
-        }));
-      }
-    } catch (error) {
-      // Update function with error message
-      const currentFunction = currentResult.function;
-      if (currentFunction) {
-        const errorFunction = types.Function.createFrom({
-          ...currentFunction,
-          message: error instanceof Error ? error.message : 'Unknown error',
-        });
-
-        setFunctionResults((prev) => ({
-          ...prev,
-          [func.name]: {
-            function: errorFunction,
-            loading: false,
-            lastUpdated: currentResult.lastUpdated,
-          },
-        }));
-      }
-    }
-  };
-
-  // Get the display value from function outputs
-  const getFunctionResult = (result: FunctionResult): unknown => {
-    if (!result.function.outputs || result.function.outputs.length === 0) {
-      return null;
-    }
-
-    if (result.function.outputs.length === 1) {
-      return result.function.outputs[0]?.value;
-    }
-
-    // For multiple outputs, return an array of values
-    return result.function.outputs.map((output) => output.value);
-  };
-
-  // Check if function has an error
-  const getFunctionError = (result: FunctionResult): string | undefined => {
-    return result.function.message;
-  };
-
-  // Format the result value for display
-  const formatResult = (result: unknown): string => {
-    if (result === null || result === undefined) {
-      return 'null';
-    }
-
-    if (typeof result === 'string') {
-      return result;
-    }
-
-    if (typeof result === 'number' || typeof result === 'bigint') {
-      return result.toString();
-    }
-
-    if (typeof result === 'boolean') {
-      return result ? 'true' : 'false';
-    }
-
-    if (Array.isArray(result)) {
-      return `[${result.map((item) => formatResult(item)).join(', ')}]`;
-    }
-
-    if (typeof result === 'object') {
-      return JSON.stringify(result, null, 2);
-    }
-
-    return String(result);
-  };
+  const [spender, setSpender] = useState('');
+  const spenders = useMemo(
+    () => (/^0x[0-9a-fA-F]{40}$/.test(spender) ? [spender] : []),
+    [spender],
+  );
+  const { state, loading, error, refresh } = useContractState(
+    contractState.address?.toString() || '',
+    spenders,
+  );
 
-  // Check if we have any functions to display from ABI
-  const hasReadFunctions = readFunctions.length > 0;
+  const hasReadFunctions = useMemo(
+    () =>
+      contractState.abi ? getReadFunctions(contractState.abi).length > 0 : false,
+    [contractState.abi],
+  );
 
-  // Show loading state while data is being processed
-  if (loading) {
-    return (
-      <Stack gap="md" align="center" style={{ padding: '2rem' }}>
-        <Loader size="lg" />
-        <Text variant="dimmed" size="md">
-          Loading contract functions...
-        </Text>
-      </Stack>
-    );
-  }
+  const results = state?.results || [];
+  const noInput = results.filter((r) => !r.args?.length);
+  const perAddress = results.filter((r) => r.args?.length);
 
   if (!hasReadFunctions) {
     return (
@@ -268,184 +54,157 @@ export const ContractDashboard: React.FC<ContractDashboardProps> = ({
 
   return (
     <Stack gap="md">
-      <Group align="flex-start" gap="md" style={{ width: '100%' }}>
-        {/* Sidebar for functions with no inputs */}
-        <Card
-          shadow="sm"
-          padding="md"
-          radius="md"
-          withBorder
-          style={{ minWidth: '280px', maxWidth: '320px', flex: '0 0 auto' }}
-        >
-          <Stack gap="xs">
+      <Group justify="space-between" align="flex-end">
+        <TextInput
+          size="sm"
+          label="Spender"
+          placeholder="0x… for allowance-style functions"
+          value={spender}
+          onChange={(e) => setSpender(e.currentTarget.value.trim())}
+          w={420}
+        />
+        <Group gap="xs">
+          {state && (
             <Text variant="dimmed" size="sm">
-              Read Functions (No Input)
+              At block {state.blockNumber}
             </Text>
-            {Object.entries(functionResults)
-              .filter(
-                ([, result]) =>
-                  !result.function.inputs ||
-                  result.function.inputs.length === 0,
-              )
-              .map(([functionName, result]) => {
-                const func = result.function;
-                return (
-                  <div
-                    key={functionName}
-                    style={{
-                      borderBottom: '1px solid var(--mantine-color-gray-3)',
-                      paddingBottom: '8px',
-                      marginBottom: '8px',
-                    }}
-                  >
-                    <Group justify="space-between" align="center" gap="xs">
-                      <div style={{ flex: 1, minWidth: 0 }}>
-                        <Text variant="primary" size="sm" fw={600}>
-                          {functionName}
-                        </Text>
-                        <StyledBadge variant="light" size="sm">
-                          {func.outputs && func.outputs.length > 0
-                            ? func.outputs.length === 1
-                              ? func.outputs[0]?.type || 'unknown'
-                              : `(${func.outputs.map((output) => output.type).join(', ')})`
-                            : 'void'}
-                        </StyledBadge>
-                      </div>
-                      <Tooltip label="Refresh this function">
-                        <StyledButton
-                          size="sm"
-                          variant="subtle"
-                          onClick={() => {
-                            // TODO: Implement actual function calling when API is ready
-                          }}
-                          loading={result?.loading}
-                          style={{
-                            minWidth: 'auto',
-                            width: '20px',
-                            height: '20px',
-                            padding: 0,
-                          }}
-                        >
-                          ↻
-                        </StyledButton>
-                      </Tooltip>
-                    </Group>
-                    <div style={{ marginTop: '4px' }}>
-                      {result?.loading ? (
-                        <Group gap="xs">
-                          <Loader size="sm" />
-                          <Text variant="dimmed" size="sm">
-                            Loading...
-                          </Text>
-                        </Group>
-                      ) : result && getFunctionError(result) ? (
-                        <Text variant="error" size="sm">
-                          Error: {getFunctionError(result)}
-                        </Text>
-                      ) : (
-                        <Text variant="primary" size="sm">
-                          {result
-                            ? formatResult(getFunctionResult(result))
-                            : 'No result'}
-                        </Text>
-                      )}
-                    </div>
-                  </div>
-                );
-              })}
-          </Stack>
-        </Card>
-
-        {/* Main content area for functions with inputs */}
-        <div style={{ flex: 1 }}>
-          <Grid>
-            {Object.entries(functionResults)
-              .filter(
-                ([, result]) =>
-                  result.function.inputs && result.function.inputs.length > 0,
-              )
-              .map(([functionName, result]) => {
-                const func = result.function;
-                return (
-                  <Grid.Col
-                    key={functionName}
-                    span={{ base: 12, md: 6, lg: 4 }}
-                  >
-                    <Card shadow="sm" padding="md" radius="md" withBorder>
-                      <Stack gap="xs">
-                        <Group justify="space-between" align="flex-start">
-                          <div style={{ flex: 1 }}>
-                            <Text variant="primary" size="sm" fw={600}>
-                              {functionName}
-                              <Text variant="dimmed" size="sm">
-                                (input)
-                              </Text>
-                            </Text>
-                            <StyledBadge variant="light" size="sm">
-                              {func.outputs && func.outputs.length > 0
-                                ? func.outputs.length === 1
-                                  ? func.outputs[0]?.type || 'unknown'
-                                  : `(${func.outputs.map((output) => output.type).join(', ')})`
-                                : 'void'}
-                            </StyledBadge>
-                          </div>
-                          <Tooltip label="Refresh this function">
-                            <StyledButton
-                              size="sm"
-                              variant="subtle"
-                              onClick={() => {
-                                // TODO: Implement actual function calling when API is ready
-                              }}
-                              loading={result?.loading}
-                            >
-                              ↻
-                            </StyledButton>
-                          </Tooltip>
-                        </Group>
-
-                        <div>
-                          {result?.loading ? (
-                            <Group gap="xs">
-                              <Loader size="sm" />
-                              <Text variant="dimmed" size="sm">
-                                Loading...
-                              </Text>
-                            </Group>
-                          ) : result && getFunctionError(result) ? (
-                            <Alert
-                              variant="light"
-                              bd="1px solid var(--mantine-color-error-6)"
-                            >
-                              <Text variant="primary" size="sm">
-                                {getFunctionError(result)}
-                              </Text>
-                            </Alert>
-                          ) : (
-                            <div>
-                              <Text variant="primary" size="sm">
-                                {result
-                                  ? formatResult(getFunctionResult(result))
-                                  : 'No result'}
-                              </Text>
-                              {result?.lastUpdated && (
-                                <Text variant="dimmed" size="sm">
-                                  Updated:{' '}
-                                  {new Date(
-                                    result.lastUpdated,
-                                  ).toLocaleTimeString()}
-                                </Text>
-                              )}
-                            </div>
-                          )}
-                        </div>
-                      </Stack>
-                    </Card>
-                  </Grid.Col>
-                );
-              })}
-          </Grid>
-        </div>
+          )}
+          <Tooltip label="Read every function again at the latest block">
+            <StyledButton size="sm" onClick={refresh} loading={loading}>
+              Refresh
+            </StyledButton>
+          </Tooltip>
+        </Group>
       </Group>
+
+      {error && (
+        <Alert variant="light" color="red" title="Could not read contract">
+          {error}
+        </Alert>
+      )}
+
+      {!state && loading ? (
+        <Stack gap="md" align="center" style={{ padding: '2rem' }}>
+          <Loader size="lg" />
+          <Text variant="dimmed" size="md">
+            Reading contract state...
+          </Text>
+        </Stack>
+      ) : (
+        <Group align="flex-start" gap="md" style={{ width: '100%' }}>
+          <Card
+            shadow="sm"
+            padding="md"
+            radius="md"
+            withBorder
+            style={{ minWidth: '280px', maxWidth: '320px', flex: '0 0 auto' }}
+          >
+            <Stack gap="xs">
+              <Text variant="dimmed" size="sm">
+                Read Functions (No Input)
+              </Text>
+              {noInput.map((result) => (
+                <div
+                  key={callKey(result)}
+                  style={{
+                    borderBottom: '1px solid var(--mantine-color-gray-3)',
+                    paddingBottom: '8px',
+                    marginBottom: '8px',
+                  }}
+                >
+                  <Text variant="primary" size="sm" fw={600}>
+                    {result.name}
+                  </Text>
+                  <StyledBadge variant="light" size="sm">
+                    {outputTypes(result)}
+                  </StyledBadge>
+                  <div style={{ marginTop: '4px' }}>
+                    <ResultValue result={result} />
+                  </div>
+                </div>
+              ))}
+            </Stack>
+          </Card>
+
+          <div style={{ flex: 1 }}>
+            <Grid>
+              {perAddress.map((result) => (
+                <Grid.Col
+                  key={callKey(result)}
+                  span={{ base: 12, md: 6, lg: 4 }}
+                >
+                  <Card shadow="sm" padding="md" radius="md" withBorder>
+                    <Stack gap="xs">
+                      <Text variant="primary" size="sm" fw={600}>
+                        {result.name}
+                      </Text>
+                      <Text variant="dimmed" size="sm">
+                        ({(result.args || []).map(shortAddress).join(', ')})
+                      </Text>
+                      <StyledBadge variant="light" size="sm">
+                        {outputTypes(result)}
+                      </StyledBadge>
+                      <ResultValue result={result} />
+                    </Stack>
+                  </Card>
+                </Grid.Col>
+              ))}
+            </Grid>
+          </div>
+        </Group>
+      )}
     </Stack>
   );
 };
+
+const ResultValue = ({ result }: { result: contracts.ReadResult }) => {
+  if (result.error) {
+    return (
+      <Text variant="error" size="sm">
+        Error: {result.error}
+      </Text>
+    );
+  }
+  const values = (result.outputs || []).map((o) => o.value);
+  return (
+    <Text variant="primary" size="sm">
+      {formatResult(values.length === 1 ? values[0] : values)}
+    </Text>
+  );
+};
+
+const callKey = (result: contracts.ReadResult): string =>
+  `${result.signature || result.name}(${(result.args || []).join(',')})`;
+
+const shortAddress = (addr: string): string =>
+  addr.length > 12 ? `${addr.slice(0, 6)}…${addr.slice(-4)}` : addr;
+
+const outputTypes = (result: contracts.ReadResult): string => {
+  const outputs = result.outputs || [];
+  if (outputs.length === 0) return result.error ? 'error' : 'void';
+  if (outputs.length === 1) return outputs[0]?.type || 'unknown';
+  return `(${outputs.map((output) => output.type).join(', ')})`;
+};
+
+// Format the result value for display
+const formatResult = (result: unknown): string => {
+  if (result === null || result === undefined) {
+    return 'null';
+  }
+  if (typeof result === 'string') {
+    return result;
+  }
+  if (typeof result === 'number' || typeof result === 'bigint') {
+    return result.toString();
+  }
+  if (typeof result === 'boolean') {
+    return result ? 'true' : 'false';
+  }
+  if (Array.isArray(result)) {
+    return `[${result.map((item) => formatResult(item)).join(', ')}]`;
+  }
+  if (typeof result === 'object') {
+    return JSON.stringify(result, null, 2);
+  }
+  return String(result);
+};
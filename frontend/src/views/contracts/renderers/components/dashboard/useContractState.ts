@@ -0,0 +1,48 @@
+import { useCallback, useEffect, useState } from 'react';
+
+import { ReadContractState } from '@app';
+import { usePayload } from '@hooks';
+import { contracts, types } from '@models';
+import { LogError } from '@utils';
+
+// useContractState reads a contract's view and pure functions at the latest block. The
+// backend caches each block's reads, so refresh forces a new read of the same block.
+export function useContractState(contractAddress: string, spenders: string[]) {
+  const createPayload = usePayload('contracts');
+  const [state, setState] = useState<contracts.ContractState | null>(null);
+  const [loading, setLoading] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+  const spenderKey = spenders.join(',');
+
+  const read = useCallback(
+    async (refresh: boolean) => {
+      if (!contractAddress) return;
+      setLoading(true);
+      try {
+        const payload = createPayload(types.DataFacet.DASHBOARD);
+        payload.activeContract = contractAddress;
+        const result = await ReadContractState(
+          payload,
+          spenderKey ? spenderKey.split(',') : [],
+          refresh,
+        );
+        setState(result);
+        setError(null);
+      } catch (err) {
+        LogError(`[Contracts] read state: ${err}`);
+        setError(String(err));
+      } finally {
+        setLoading(false);
+      }
+    },
+    [contractAddress, createPayload, spenderKey],
+  );
+
+  useEffect(() => {
+    read(false);
+  }, [read]);
+
+  const refresh = useCallback(() => read(true), [read]);
+
+  return { state, loading, error, refresh };
+}
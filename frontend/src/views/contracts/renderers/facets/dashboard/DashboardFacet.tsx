@@ -17,7 +17,9 @@ import { ContractDashboard } from '../../components/dashboard/ContractDashboard'
 export const DashboardFacet = ({ params }: { params: RendererParams }) => {
   // EXISTING_CODE
   const { data } = params;
-  const contracts = (data || []) as unknown as types.Contract[];
+  const contracts = (data || []) as unknown as (types.Contract & {
+    deployedBlock?: number;
+  })[];
   const { activeContract } = useActiveProject();
 
   if (!contracts || contracts.length === 0) {
@@ -54,6 +56,16 @@ export const DashboardFacet = ({ params }: { params: RendererParams }) => {
             {contractState.name} ({contractState.address?.toString()})
           </Text>
         )}
+        {contractState.deployedBlock !== undefined && (
+          <Text variant="dimmed" size="sm">
+            Deployed at block {contractState.deployedBlock}
+          </Text>
+        )}
+        {contractState.lastError && (
+          <Alert variant="light" color="red" title="ABI unavailable">
+            {contractState.lastError}
+          </Alert>
+        )}
         <ContractDashboard contractState={contractState} />
       </Stack>
     </Container>
@@ -312,6 +312,7 @@ export const Dresses = () => {
         opened={exportFormatModal.opened}
         onClose={exportFormatModal.onClose}
         onFormatSelected={exportFormatModal.onFormatSelected}
+        payload={exportFormatModal.pendingPayload}
       />
     </div>
   );
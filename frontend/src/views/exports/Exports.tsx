@@ -25,20 +25,38 @@ import {
   useViewConfig,
 } from '@hooks';
 import { TabView } from '@layout';
-import { Group } from '@mantine/core';
+import { Group, Text } from '@mantine/core';
 import { useHotkeys } from '@mantine/hooks';
 import { exports } from '@models';
-import { msgs, project, types } from '@models';
+import { msgs, pricing, project, types } from '@models';
 import { Debugger, LogError, useErrorHandler } from '@utils';
 
 import { assertRouteConsistency } from '../routes';
+import {
+  ExportsRange,
+  ExportsRangeModal,
+  describeRange,
+} from './ExportsRangeModal';
 import { ROUTE } from './constants';
 import { renderers } from './renderers';
 
 export const Exports = () => {
   // === SECTION 2: Hook Initialization ===
   const renderCnt = useRef(0);
-  const createPayload = usePayload(ROUTE);
+  const createProjectPayload = usePayload(ROUTE);
+  // EXISTING_CODE
+  const [range, setRange] = useState<ExportsRange>({});
+  const [rangeOpened, setRangeOpened] = useState(false);
+  const createPayload = useCallback(
+    (dataFacet: types.DataFacet, targetAddress?: string) =>
+      types.Payload.createFrom({
+        ...createProjectPayload(dataFacet, targetAddress),
+        ...range,
+      }),
+    [createProjectPayload, range],
+  );
+  const rangeLabel = describeRange(range);
+  // EXISTING_CODE
   // === SECTION 2.5: Initial ViewConfig Load ===
   const { config: viewConfig } = useViewConfig({ viewName: ROUTE });
   assertRouteConsistency(ROUTE, viewConfig);
@@ -100,17 +118,19 @@ export const Exports = () => {
     const facet = getCurrentDataFacet();
     switch (facet) {
       case types.DataFacet.STATEMENTS:
-        return pageData.statements || [];
+        return withValues(pageData.statements || [], pageData.values);
       case types.DataFacet.ASSETS:
         return pageData.assets || [];
       case types.DataFacet.ASSETCHARTS:
         return pageData.statements || [];
       case types.DataFacet.BALANCES:
-        return pageData.balances || [];
+        return withValues(pageData.balances || [], pageData.values);
       case types.DataFacet.TRANSFERS:
         return pageData.transfers || [];
+      case types.DataFacet.COUNTERPARTIES:
+        return pageData.counterparties || [];
       case types.DataFacet.OPENAPPROVALS:
-        return pageData.openapprovals || [];
+        return withValues(pageData.openapprovals || [], pageData.values);
       case types.DataFacet.APPROVALTXS:
         return pageData.approvaltxs || [];
       case types.DataFacet.APPROVALLOGS:
@@ -151,6 +171,11 @@ export const Exports = () => {
   useEvent(msgs.EventType.CONTRACT_CHANGED, () => {
     fetchData();
   });
+  useEvent(msgs.EventType.MANAGER, (message?: string) => {
+    if (message === 'pricing_changed' || message === 'labels_changed') {
+      fetchData();
+    }
+  });
 
   useEffect(() => {
     fetchData();
@@ -190,6 +215,21 @@ export const Exports = () => {
     if (!config.headerActions.length) return null;
     return (
       <Group gap="xs" style={{ flexShrink: 0 }}>
+        <Action
+          icon="Update"
+          onClick={() => setRangeOpened(true)}
+          title={
+            rangeLabel
+              ? `Limited to ${rangeLabel}`
+              : 'Limit to a block or date range'
+          }
+          size="sm"
+        />
+        {rangeLabel && (
+          <Text size="xs" variant="dimmed">
+            {rangeLabel}
+          </Text>
+        )}
         {config.headerActions.map((action) => {
           const handlerKey =
             `handle${action.type.charAt(0).toUpperCase() + action.type.slice(1)}` as keyof typeof handlers;
@@ -215,7 +255,7 @@ export const Exports = () => {
         })}
       </Group>
     );
-  }, [config.headerActions, config.isWalletConnected, handlers]);
+  }, [config.headerActions, config.isWalletConnected, handlers, rangeLabel]);
 
   // === SECTION 6: UI Configuration ===
   const currentColumns = useFacetColumns(
@@ -318,14 +358,37 @@ export const Exports = () => {
         message={confirmModal.message}
         dialogKey={confirmModal.dialogKey}
       />
+      <ExportsRangeModal
+        opened={rangeOpened}
+        range={range}
+        onCancel={() => setRangeOpened(false)}
+        onSubmit={(next) => {
+          setRangeOpened(false);
+          setRange(next);
+          goToPage(0);
+        }}
+      />
       <ExportFormatModal
         opened={exportFormatModal.opened}
         onClose={exportFormatModal.onClose}
         onFormatSelected={exportFormatModal.onFormatSelected}
+        payload={exportFormatModal.pendingPayload}
       />
     </div>
   );
 };
 
 // EXISTING_CODE
+// Merges the page's fiat valuations, which line up with its rows, into fiatPrice/fiatValue
+function withValues<T extends object>(
+  rows: T[],
+  values?: pricing.Valuation[],
+): T[] {
+  if (!values?.length) return rows;
+  return rows.map((row, i) => {
+    const v = values[i];
+    if (!v?.priced) return row;
+    return { ...row, fiatPrice: v.price, fiatValue: v.value };
+  });
+}
 // EXISTING_CODE
@@ -0,0 +1,163 @@
+import { useEffect, useState } from 'react';
+
+import { StyledButton, StyledModal } from '@components';
+import { Group, Stack, Text, TextInput } from '@mantine/core';
+
+// ExportsRange limits every exports query to a block and/or date range. Dates are
+// YYYY-MM-DD, inclusive, in the reporting timezone. Empty fields are unbounded.
+export interface ExportsRange {
+  firstBlock?: number;
+  lastBlock?: number;
+  firstDate?: string;
+  lastDate?: string;
+}
+
+interface ExportsRangeModalProps {
+  opened: boolean;
+  range: ExportsRange;
+  onSubmit: (range: ExportsRange) => void;
+  onCancel: () => void;
+}
+
+const datePattern = /^\d{4}-\d{2}-\d{2}$/;
+const blockPattern = /^\d+$/;
+
+// describeRange summarizes a range for the header action's title, empty when unscoped
+export const describeRange = (range: ExportsRange): string => {
+  const parts: string[] = [];
+  if (range.firstBlock || range.lastBlock) {
+    parts.push(
+      `blocks ${range.firstBlock ?? 0}-${range.lastBlock ? range.lastBlock : 'latest'}`,
+    );
+  }
+  if (range.firstDate || range.lastDate) {
+    parts.push(
+      `dates ${range.firstDate || 'start'} to ${range.lastDate || 'today'}`,
+    );
+  }
+  return parts.join(', ');
+};
+
+// ExportsRangeModal edits the block and date range applied to the exports view
+export const ExportsRangeModal = ({
+  opened,
+  range,
+  onSubmit,
+  onCancel,
+}: ExportsRangeModalProps) => {
+  const [firstBlock, setFirstBlock] = useState('');
+  const [lastBlock, setLastBlock] = useState('');
+  const [firstDate, setFirstDate] = useState('');
+  const [lastDate, setLastDate] = useState('');
+
+  useEffect(() => {
+    if (!opened) return;
+    setFirstBlock(range.firstBlock ? String(range.firstBlock) : '');
+    setLastBlock(range.lastBlock ? String(range.lastBlock) : '');
+    setFirstDate(range.firstDate || '');
+    setLastDate(range.lastDate || '');
+  }, [opened, range]);
+
+  const badBlock = (v: string) => v !== '' && !blockPattern.test(v);
+  const badDate = (v: string) => v !== '' && !datePattern.test(v);
+  const reversedBlocks =
+    firstBlock !== '' &&
+    lastBlock !== '' &&
+    Number(firstBlock) > Number(lastBlock);
+  const reversedDates =
+    firstDate !== '' && lastDate !== '' && firstDate > lastDate;
+  const invalid =
+    badBlock(firstBlock) ||
+    badBlock(lastBlock) ||
+    badDate(firstDate) ||
+    badDate(lastDate) ||
+    reversedBlocks ||
+    reversedDates;
+
+  const handleApply = () => {
+    if (invalid) return;
+    onSubmit({
+      firstBlock: firstBlock ? Number(firstBlock) : undefined,
+      lastBlock: lastBlock ? Number(lastBlock) : undefined,
+      firstDate: firstDate || undefined,
+      lastDate: lastDate || undefined,
+    });
+  };
+
+  return (
+    <StyledModal
+      opened={opened}
+      onClose={onCancel}
+      centered
+      withCloseButton
+      closeOnClickOutside
+      closeOnEscape
+      title="Limit to a range"
+    >
+      <Stack gap="md">
+        <Text variant="dimmed" size="sm">
+          Every facet and export of this view covers only the given blocks and
+          dates. Dates are inclusive and use the reporting timezone. Leave a
+          field empty for no limit.
+        </Text>
+
+        <Group gap="xs" grow>
+          <TextInput
+            label="First block"
+            placeholder="0"
+            value={firstBlock}
+            onChange={(e) => setFirstBlock(e.currentTarget.value.trim())}
+            error={badBlock(firstBlock) ? 'Not a block number' : undefined}
+          />
+          <TextInput
+            label="Last block"
+            placeholder="latest"
+            value={lastBlock}
+            onChange={(e) => setLastBlock(e.currentTarget.value.trim())}
+            error={
+              badBlock(lastBlock)
+                ? 'Not a block number'
+                : reversedBlocks
+                  ? 'Before the first block'
+                  : undefined
+            }
+          />
+        </Group>
+        <Group gap="xs" grow>
+          <TextInput
+            label="First date"
+            placeholder="2025-01-01"
+            value={firstDate}
+            onChange={(e) => setFirstDate(e.currentTarget.value.trim())}
+            error={badDate(firstDate) ? 'Use YYYY-MM-DD' : undefined}
+          />
+          <TextInput
+            label="Last date"
+            placeholder="2025-12-31"
+            value={lastDate}
+            onChange={(e) => setLastDate(e.currentTarget.value.trim())}
+            error={
+              badDate(lastDate)
+                ? 'Use YYYY-MM-DD'
+                : reversedDates
+                  ? 'Before the first date'
+                  : undefined
+            }
+          />
+        </Group>
+
+        <Group justify="flex-end" gap="sm">
+          <StyledButton variant="outline" onClick={() => onSubmit({})}>
+            Clear
+          </StyledButton>
+          <StyledButton variant="outline" onClick={onCancel}>
+            Cancel
+          </StyledButton>
+          <StyledButton onClick={handleApply} disabled={invalid}>
+            Apply
+          </StyledButton>
+        </Group>
+      </Stack>
+    </StyledModal>
+  );
+};
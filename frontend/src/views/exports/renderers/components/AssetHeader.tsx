@@ -10,7 +10,36 @@ import {
 } from '@mantine/core';
 import { types } from '@models';
 
-export type MetricOption = 'frequency' | 'volume' | 'endBal';
+export type MetricOption =
+  | 'frequency'
+  | 'volume'
+  | 'endBal'
+  | 'counterparties'
+  | 'gas'
+  | 'endBalFiat'
+  | 'volumeFiat';
+
+export const METRIC_OPTIONS: { value: MetricOption; label: string }[] = [
+  { value: 'frequency', label: 'Statement Frequency' },
+  { value: 'volume', label: 'Volume' },
+  { value: 'endBal', label: 'Ending Balance' },
+  { value: 'counterparties', label: 'Unique Counterparties' },
+  { value: 'gas', label: 'Gas Spent' },
+  { value: 'endBalFiat', label: 'Ending Balance (Fiat)' },
+  { value: 'volumeFiat', label: 'Volume (Fiat)' },
+];
+
+export const isMetricOption = (value: string): value is MetricOption =>
+  METRIC_OPTIONS.some((option) => option.value === value);
+
+// Fiat metrics only have series when a price source is configured
+const availableMetricOptions = (bucketsData?: types.Buckets | null) => {
+  const names = Object.keys(bucketsData?.series || {});
+  if (names.length === 0) return METRIC_OPTIONS;
+  return METRIC_OPTIONS.filter((option) =>
+    names.some((name) => name.endsWith(`.${option.value}`)),
+  );
+};
 
 export interface AssetHeaderProps {
   assetKey?: string;
@@ -131,11 +160,7 @@ export const AssetHeader = ({
                 size="sm"
                 value={selectedMetric}
                 onChange={(value) => onMetricChange(value as MetricOption)}
-                data={[
-                  { value: 'frequency', label: 'Statement Frequency' },
-                  { value: 'volume', label: 'Volume' },
-                  { value: 'endBal', label: 'Ending Balance' },
-                ]}
+                data={availableMetricOptions(bucketsData)}
                 style={{ minWidth: 150 }}
               />
             )}
@@ -1,4 +1,9 @@
-export { AssetHeader, type MetricOption } from './AssetHeader';
+export {
+  AssetHeader,
+  METRIC_OPTIONS,
+  isMetricOption,
+  type MetricOption,
+} from './AssetHeader';
 export { AssetChart } from './AssetChart';
 export type { AssetHeaderProps } from './AssetHeader';
 export type { AssetChartProps } from './AssetChart';
@@ -9,12 +9,18 @@ import { useCallback, useEffect, useMemo, useState } from 'react';
 
 import { GetExportsBuckets, GetExportsMetric, SetExportsMetric } from '@app';
 import { RendererParams } from '@components';
-import { useEvent, usePayload } from '@hooks';
+import { useActiveProject, useEvent, usePayload } from '@hooks';
 import { SimpleGrid, Stack, Text } from '@mantine/core';
 import { msgs, types } from '@models';
 import { LogError, useErrorHandler } from '@utils';
 
-import { AssetChart, AssetHeader, type MetricOption } from '../../components';
+import {
+  AssetChart,
+  AssetHeader,
+  METRIC_OPTIONS,
+  type MetricOption,
+  isMetricOption,
+} from '../../components';
 
 // EXISTING_CODE
 
@@ -32,6 +38,7 @@ export const AssetChartsFacet = ({ params }: { params: RendererParams }) => {
   const [selectedMetric, setSelectedMetric] =
     useState<MetricOption>('frequency');
   const createPayload = usePayload('exports');
+  const { activePeriod } = useActiveProject();
   const { error, handleError, clearError } = useErrorHandler();
 
   // Load metric from backend preferences on component mount
@@ -39,17 +46,14 @@ export const AssetChartsFacet = ({ params }: { params: RendererParams }) => {
     const loadSelectedMetric = async () => {
       try {
         const saved = await GetExportsMetric('assetcharts');
-        if (saved && ['frequency', 'volume', 'endBal'].includes(saved)) {
-          setSelectedMetric(saved as MetricOption);
+        if (saved && isMetricOption(saved)) {
+          setSelectedMetric(saved);
         }
       } catch {
         // Fallback to localStorage if backend fails
         const localSaved = localStorage.getItem('assetCharts-selectedMetric');
-        if (
-          localSaved &&
-          ['frequency', 'volume', 'endBal'].includes(localSaved)
-        ) {
-          setSelectedMetric(localSaved as MetricOption);
+        if (localSaved && isMetricOption(localSaved)) {
+          setSelectedMetric(localSaved);
         }
       }
     };
@@ -71,7 +75,7 @@ export const AssetChartsFacet = ({ params }: { params: RendererParams }) => {
 
   // Cycle through metrics using hotkey (Cmd+M / Ctrl+M)
   const cycleMetric = useCallback(() => {
-    const metrics: MetricOption[] = ['frequency', 'volume', 'endBal'];
+    const metrics = METRIC_OPTIONS.map((option) => option.value);
     const currentIndex = metrics.indexOf(selectedMetric);
     const nextIndex = (currentIndex + 1) % metrics.length;
     const nextMetric = metrics[nextIndex];
@@ -106,11 +110,11 @@ export const AssetChartsFacet = ({ params }: { params: RendererParams }) => {
     }
   }, [createPayload, handleError, clearError]);
 
-  // Initial data fetch only - no deps to prevent re-renders
+  // Fetch on mount and when the active period changes the bucket size
   useEffect(() => {
     fetchBucketsData();
     // eslint-disable-next-line react-hooks/exhaustive-deps
-  }, []);
+  }, [activePeriod]);
 
   // Listen for DATA_LOADED events to keep buckets data synchronized with streaming updates
   useEvent(
@@ -125,6 +129,13 @@ export const AssetChartsFacet = ({ params }: { params: RendererParams }) => {
     },
   );
 
+  // Fiat series are rebuilt when the price source changes
+  useEvent(msgs.EventType.MANAGER, (message?: string) => {
+    if (message === 'pricing_changed') {
+      fetchBucketsData();
+    }
+  });
+
   // Save state to localStorage when it changes
   useEffect(() => {
     localStorage.setItem('assetCharts-sortDirection', sortDirection);
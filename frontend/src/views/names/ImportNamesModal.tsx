@@ -0,0 +1,282 @@
+import { useMemo, useState } from 'react';
+
+import { ChooseNamesFile, ImportNames, PreviewNamesImport } from '@app';
+import { StyledBadge, StyledButton, StyledModal } from '@components';
+import { usePayload } from '@hooks';
+import {
+  Checkbox,
+  Group,
+  ScrollArea,
+  Stack,
+  Table,
+  Text,
+  TextInput,
+} from '@mantine/core';
+import { names, types } from '@models';
+import { LogError } from '@utils';
+
+import { ROUTE } from './constants';
+
+interface ImportNamesModalProps {
+  opened: boolean;
+  onSubmit: (report: names.ImportReport) => void;
+  onCancel: () => void;
+}
+
+const STATUSES = ['add', 'update', 'conflict', 'unchanged', 'invalid'];
+
+const statusColor = (status: string) => {
+  switch (status) {
+    case 'add':
+      return 'var(--mantine-color-green-7)';
+    case 'update':
+      return 'var(--mantine-color-blue-7)';
+    case 'conflict':
+      return 'var(--mantine-color-yellow-7)';
+    case 'invalid':
+      return 'var(--mantine-color-red-7)';
+    default:
+      return 'var(--mantine-color-gray-6)';
+  }
+};
+
+// ImportNamesModal previews a CSV or JSON file of names against the names database and
+// imports the rows the user keeps checked into the custom names
+export const ImportNamesModal = ({
+  opened,
+  onSubmit,
+  onCancel,
+}: ImportNamesModalProps) => {
+  const createPayload = usePayload(ROUTE);
+  const [path, setPath] = useState('');
+  const [rows, setRows] = useState<names.ImportRow[]>([]);
+  const [loading, setLoading] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+
+  const counts = useMemo(() => {
+    const ret: Record<string, number> = {};
+    rows.forEach((row) => {
+      ret[row.status] = (ret[row.status] || 0) + 1;
+    });
+    return ret;
+  }, [rows]);
+  const nApply = rows.filter((row) => row.apply).length;
+
+  const reset = () => {
+    setPath('');
+    setRows([]);
+    setError(null);
+  };
+
+  const preview = async (selected: string) => {
+    setLoading(true);
+    setError(null);
+    try {
+      setRows((await PreviewNamesImport(selected)) || []);
+    } catch (err) {
+      LogError(`[Names] preview import: ${err}`);
+      setError(String(err));
+      setRows([]);
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const handleChoose = async () => {
+    try {
+      const selected = await ChooseNamesFile();
+      if (!selected) return;
+      setPath(selected);
+      await preview(selected);
+    } catch (err) {
+      LogError(`[Names] choose file: ${err}`);
+      setError(String(err));
+    }
+  };
+
+  const canApply = (row: names.ImportRow) =>
+    row.status !== 'invalid' && row.status !== 'unchanged';
+
+  const setApply = (
+    match: (row: names.ImportRow) => boolean,
+    apply: boolean,
+  ) => {
+    setRows((prev) =>
+      prev.map((row) =>
+        match(row) && canApply(row)
+          ? names.ImportRow.createFrom({ ...row, apply })
+          : row,
+      ),
+    );
+  };
+
+  const handleImport = async () => {
+    if (loading || nApply === 0) return;
+    setLoading(true);
+    setError(null);
+    try {
+      const payload = createPayload(types.DataFacet.CUSTOM);
+      const report = await ImportNames(payload, rows);
+      reset();
+      onSubmit(report);
+    } catch (err) {
+      LogError(`[Names] import: ${err}`);
+      setError(String(err));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const handleCancel = () => {
+    reset();
+    onCancel();
+  };
+
+  return (
+    <StyledModal
+      opened={opened}
+      onClose={handleCancel}
+      centered
+      withCloseButton
+      closeOnClickOutside={false}
+      closeOnEscape
+      size="xl"
+      title="Import Names"
+    >
+      <Stack gap="md">
+        <Text variant="dimmed" size="sm">
+          A CSV or JSON file with address, name, tags and source columns. Checked
+          rows are added to or update the custom names. Renaming an existing
+          entry is a conflict and is left unchecked.
+        </Text>
+
+        {error && (
+          <Text variant="error" size="sm">
+            Error: {error}
+          </Text>
+        )}
+
+        <Group gap="xs" align="flex-end" wrap="nowrap">
+          <TextInput
+            label="Names file"
+            placeholder="names.csv"
+            value={path}
+            readOnly
+            style={{ flex: 1 }}
+          />
+          <StyledButton variant="outline" onClick={handleChoose}>
+            Choose…
+          </StyledButton>
+        </Group>
+
+        {rows.length > 0 && (
+          <>
+            <Group gap="xs">
+              {STATUSES.filter((status) => counts[status]).map((status) => (
+                <StyledBadge
+                  key={status}
+                  variant="light"
+                  style={{ color: statusColor(status) }}
+                >
+                  {`${status}: ${counts[status]}`}
+                </StyledBadge>
+              ))}
+              <Group gap="xs" ml="auto">
+                <StyledButton
+                  size="xs"
+                  variant="outline"
+                  onClick={() => setApply(() => true, true)}
+                >
+                  Check all
+                </StyledButton>
+                <StyledButton
+                  size="xs"
+                  variant="outline"
+                  onClick={() => setApply(() => true, false)}
+                >
+                  Check none
+                </StyledButton>
+                <StyledButton
+                  size="xs"
+                  variant="outline"
+                  disabled={!counts['conflict']}
+                  onClick={() =>
+                    setApply((row) => row.status === 'conflict', true)
+                  }
+                >
+                  Overwrite conflicts
+                </StyledButton>
+              </Group>
+            </Group>
+
+            <ScrollArea h={360}>
+              <Table fz="xs" striped stickyHeader>
+                <Table.Thead>
+                  <Table.Tr>
+                    <Table.Th />
+                    <Table.Th>Line</Table.Th>
+                    <Table.Th>Status</Table.Th>
+                    <Table.Th>Address</Table.Th>
+                    <Table.Th>Name</Table.Th>
+                    <Table.Th>Tags</Table.Th>
+                    <Table.Th>Source</Table.Th>
+                  </Table.Tr>
+                </Table.Thead>
+                <Table.Tbody>
+                  {rows.map((row) => (
+                    <Table.Tr key={row.line}>
+                      <Table.Td>
+                        <Checkbox
+                          size="xs"
+                          checked={row.apply}
+                          disabled={!canApply(row)}
+                          onChange={(e) =>
+                            setApply(
+                              (r) => r.line === row.line,
+                              e.currentTarget.checked,
+                            )
+                          }
+                        />
+                      </Table.Td>
+                      <Table.Td>{row.line}</Table.Td>
+                      <Table.Td
+                        style={{ color: statusColor(row.status) }}
+                        title={row.reason}
+                      >
+                        {row.status}
+                        {row.reason ? ` (${row.reason})` : ''}
+                      </Table.Td>
+                      <Table.Td style={{ fontFamily: 'monospace' }}>
+                        {row.address}
+                      </Table.Td>
+                      <Table.Td>{row.name}</Table.Td>
+                      <Table.Td>{row.tags || row.existing?.tags}</Table.Td>
+                      <Table.Td>{row.source || row.existing?.source}</Table.Td>
+                    </Table.Tr>
+                  ))}
+                </Table.Tbody>
+              </Table>
+            </ScrollArea>
+          </>
+        )}
+
+        <Group justify="flex-end" gap="sm">
+          <StyledButton
+            variant="outline"
+            onClick={handleCancel}
+            disabled={loading}
+          >
+            Cancel
+          </StyledButton>
+          <StyledButton
+            onClick={handleImport}
+            loading={loading}
+            disabled={nApply === 0}
+          >
+            {`Import ${nApply} name${nApply === 1 ? '' : 's'}`}
+          </StyledButton>
+        </Group>
+      </Stack>
+    </StyledModal>
+  );
+};
@@ -9,7 +9,7 @@
 import { useCallback, useEffect, useMemo, useRef, useState } from 'react';
 
 import { GetNamesPage, Reload } from '@app';
-import { NamesCrud } from '@app';
+import { ExportCustomNames, NamesCrud } from '@app';
 import { BaseTab, usePagination } from '@components';
 import { Action, ConfirmModal, ExportFormatModal } from '@components';
 import { createDetailPanel } from '@components';
@@ -33,6 +33,8 @@ import { msgs, project, types } from '@models';
 import { Debugger, LogError, useErrorHandler } from '@utils';
 
 import { assertRouteConsistency } from '../routes';
+import { ImportNamesModal } from './ImportNamesModal';
+import { ProjectLabelsModal } from './ProjectLabelsModal';
 import { ROUTE } from './constants';
 
 export const Names = () => {
@@ -54,6 +56,8 @@ export const Names = () => {
   const { availableFacets, getCurrentDataFacet } = activeFacetHook;
 
   const [pageData, setPageData] = useState<names.NamesPage | null>(null);
+  const [importOpened, setImportOpened] = useState(false);
+  const [labelsOpened, setLabelsOpened] = useState(false);
   const viewStateKey = useMemo(
     (): project.ViewStateKey => ({
       viewName: ROUTE,
@@ -171,10 +175,38 @@ export const Names = () => {
     getCurrentDataFacet,
   });
   const { handleAutoname, handleRemove, handleToggle, handleUpdate } = handlers;
+
+  const handleExportCustom = useCallback(async () => {
+    try {
+      await ExportCustomNames();
+    } catch (err) {
+      LogError(`[Names] export custom names: ${err}`);
+      handleError(err, 'Failed to export custom names');
+    }
+  }, [handleError]);
+
   const headerActions = useMemo(() => {
     if (!config.headerActions.length) return null;
     return (
       <Group gap="xs" style={{ flexShrink: 0 }}>
+        <Action
+          icon="File"
+          onClick={() => setImportOpened(true)}
+          title="Import names from a CSV or JSON file"
+          size="sm"
+        />
+        <Action
+          icon="Publish"
+          onClick={handleExportCustom}
+          title="Export custom names for importing elsewhere"
+          size="sm"
+        />
+        <Action
+          icon="Pin"
+          onClick={() => setLabelsOpened(true)}
+          title="Private labels for this project only"
+          size="sm"
+        />
         {config.headerActions.map((action) => {
           const handlerKey =
             `handle${action.type.charAt(0).toUpperCase() + action.type.slice(1)}` as keyof typeof handlers;
@@ -200,7 +232,12 @@ export const Names = () => {
         })}
       </Group>
     );
-  }, [config.headerActions, config.isWalletConnected, handlers]);
+  }, [
+    config.headerActions,
+    config.isWalletConnected,
+    handlers,
+    handleExportCustom,
+  ]);
 
   // === SECTION 6: UI Configuration ===
   const currentColumns = useFacetColumns(
@@ -317,10 +354,23 @@ export const Names = () => {
         message={confirmModal.message}
         dialogKey={confirmModal.dialogKey}
       />
+      <ImportNamesModal
+        opened={importOpened}
+        onCancel={() => setImportOpened(false)}
+        onSubmit={() => {
+          setImportOpened(false);
+          fetchData();
+        }}
+      />
+      <ProjectLabelsModal
+        opened={labelsOpened}
+        onClose={() => setLabelsOpened(false)}
+      />
       <ExportFormatModal
         opened={exportFormatModal.opened}
         onClose={exportFormatModal.onClose}
         onFormatSelected={exportFormatModal.onFormatSelected}
+        payload={exportFormatModal.pendingPayload}
       />
     </div>
   );
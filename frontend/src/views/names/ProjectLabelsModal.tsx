@@ -0,0 +1,176 @@
+import { useCallback, useEffect, useState } from 'react';
+
+import { GetProjectLabels, RemoveProjectLabel, SetProjectLabel } from '@app';
+import { StyledButton, StyledModal } from '@components';
+import { Group, ScrollArea, Stack, Table, Text, TextInput } from '@mantine/core';
+import { project } from '@models';
+import { LogError, addressToHex } from '@utils';
+
+interface ProjectLabelsModalProps {
+  opened: boolean;
+  onClose: () => void;
+}
+
+// ProjectLabelsModal edits the active project's private address labels. They override the
+// names database in this project's views and are saved only in the project file.
+export const ProjectLabelsModal = ({
+  opened,
+  onClose,
+}: ProjectLabelsModalProps) => {
+  const [labels, setLabels] = useState<project.Label[]>([]);
+  const [address, setAddress] = useState('');
+  const [name, setName] = useState('');
+  const [tags, setTags] = useState('');
+  const [loading, setLoading] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+  const validAddress = /^0x[0-9a-fA-F]{40}$/.test(address);
+
+  const load = useCallback(async () => {
+    try {
+      setLabels((await GetProjectLabels()) || []);
+    } catch (err) {
+      LogError(`[Names] load labels: ${err}`);
+      setError(String(err));
+    }
+  }, []);
+
+  useEffect(() => {
+    if (opened) load();
+  }, [opened, load]);
+
+  const handleSave = async () => {
+    if (loading || !validAddress || !name.trim()) return;
+    setLoading(true);
+    setError(null);
+    try {
+      await SetProjectLabel(address, name, tags);
+      setAddress('');
+      setName('');
+      setTags('');
+      await load();
+    } catch (err) {
+      LogError(`[Names] save label: ${err}`);
+      setError(String(err));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const handleEdit = (label: project.Label) => {
+    setAddress(addressToHex(label.address));
+    setName(label.name);
+    setTags(label.tags || '');
+  };
+
+  const handleRemove = async (label: project.Label) => {
+    setError(null);
+    try {
+      await RemoveProjectLabel(addressToHex(label.address));
+      await load();
+    } catch (err) {
+      LogError(`[Names] remove label: ${err}`);
+      setError(String(err));
+    }
+  };
+
+  return (
+    <StyledModal
+      opened={opened}
+      onClose={onClose}
+      centered
+      withCloseButton
+      closeOnClickOutside
+      closeOnEscape
+      size="lg"
+      title="Project Labels"
+    >
+      <Stack gap="md">
+        <Text variant="dimmed" size="sm">
+          Private names for this project only. They take precedence over the
+          names database in this project&apos;s views and are saved in the
+          project file, never in the shared names.
+        </Text>
+
+        {error && (
+          <Text variant="error" size="sm">
+            Error: {error}
+          </Text>
+        )}
+
+        {labels.length > 0 && (
+          <ScrollArea.Autosize mah={280}>
+            <Table fz="xs" striped>
+              <Table.Tbody>
+                {labels.map((label) => {
+                  const hex = addressToHex(label.address);
+                  return (
+                    <Table.Tr key={hex}>
+                      <Table.Td style={{ fontFamily: 'monospace' }}>
+                        {hex}
+                      </Table.Td>
+                      <Table.Td>{label.name}</Table.Td>
+                      <Table.Td>{label.tags}</Table.Td>
+                      <Table.Td>
+                        <Group gap={4} justify="flex-end" wrap="nowrap">
+                          <StyledButton
+                            size="xs"
+                            variant="outline"
+                            onClick={() => handleEdit(label)}
+                          >
+                            Edit
+                          </StyledButton>
+                          <StyledButton
+                            size="xs"
+                            variant="outline"
+                            onClick={() => handleRemove(label)}
+                          >
+                            Remove
+                          </StyledButton>
+                        </Group>
+                      </Table.Td>
+                    </Table.Tr>
+                  );
+                })}
+              </Table.Tbody>
+            </Table>
+          </ScrollArea.Autosize>
+        )}
+
+        <TextInput
+          label="Address"
+          placeholder="0x…"
+          value={address}
+          onChange={(e) => setAddress(e.currentTarget.value.trim())}
+          error={address && !validAddress ? 'Not an address' : undefined}
+        />
+        <Group gap="xs" grow>
+          <TextInput
+            label="Label"
+            placeholder="client cold wallet 3"
+            value={name}
+            onChange={(e) => setName(e.currentTarget.value)}
+          />
+          <TextInput
+            label="Tags"
+            placeholder="optional"
+            value={tags}
+            onChange={(e) => setTags(e.currentTarget.value)}
+          />
+        </Group>
+
+        <Group justify="flex-end" gap="sm">
+          <StyledButton variant="outline" onClick={onClose} disabled={loading}>
+            Close
+          </StyledButton>
+          <StyledButton
+            onClick={handleSave}
+            loading={loading}
+            disabled={!validAddress || !name.trim()}
+          >
+            Save label
+          </StyledButton>
+        </Group>
+      </Stack>
+    </StyledModal>
+  );
+};
@@ -104,6 +104,8 @@ export const Status = () => {
         return pageData.caches || [];
       case types.DataFacet.CHAINS:
         return pageData.chains || [];
+      case types.DataFacet.METRICS:
+        return pageData.metrics || [];
       default:
         LogError('[Status] unexpected facet=' + String(facet));
         return [];
@@ -300,6 +302,7 @@ export const Status = () => {
         opened={exportFormatModal.opened}
         onClose={exportFormatModal.onClose}
         onFormatSelected={exportFormatModal.onFormatSelected}
+        payload={exportFormatModal.pendingPayload}
       />
     </div>
   );
@@ -21,6 +21,7 @@ import {names} from '../models';
 import {projects} from '../models';
 import {status} from '../models';
 import {app} from '../models';
+import {signatures} from '../models';
 
 export function AbisCrud(arg1:types.Payload,arg2:crud.Operation,arg3:any):Promise<void>;
 
@@ -28,12 +29,20 @@ export function AddAddressToProject(arg1:string):Promise<void>;
 
 export function AddAddressesToProject(arg1:string):Promise<void>;
 
+export function CancelExport(arg1:types.Payload):Promise<boolean>;
+
 export function CancelFetches():Promise<number>;
 
 export function ChangeImageStorageLocation(arg1:string):Promise<void>;
 
 export function ChangeVisibility(arg1:types.Payload):Promise<void>;
 
+export function ChooseAbiFile():Promise<string>;
+
+export function ChooseNamesFile():Promise<string>;
+
+export function ChooseSignatureFile():Promise<string>;
+
 export function ClearActiveProject():Promise<void>;
 
 export function ClearViewFacetState(arg1:project.ViewStateKey):Promise<void>;
@@ -44,6 +53,8 @@ export function CloseProject(arg1:string):Promise<void>;
 
 export function ConfigOk():Promise<void>;
 
+export function ContractsCrud(arg1:types.Payload,arg2:crud.Operation,arg3:any):Promise<void>;
+
 export function ConvertToAddress(arg1:string):Promise<base.Address|boolean>;
 
 export function DeleteCustomSkin(arg1:string):Promise<void>;
@@ -54,8 +65,14 @@ export function Encode(arg1:types.Function,arg2:Array<any>):Promise<string>;
 
 export function ExecuteRowAction(arg1:types.RowActionPayload):Promise<void>;
 
+export function ExportBundle(arg1:types.Payload,arg2:boolean):Promise<string>;
+
+export function ExportCustomNames():Promise<string>;
+
 export function ExportData(arg1:types.Payload):Promise<void>;
 
+export function ExportFacets(arg1:types.Payload,arg2:Array<types.DataFacet>):Promise<void>;
+
 export function ExportSkin(arg1:string):Promise<string>;
 
 export function FileNew(arg1:menu.CallbackData):Promise<void>;
@@ -116,6 +133,8 @@ export function GetComparitoorPage(arg1:types.Payload,arg2:number,arg3:number,ar
 
 export function GetComparitoorSummary(arg1:types.Payload):Promise<types.Summary>;
 
+export function GetCompress():Promise<boolean>;
+
 export function GetContext():Promise<context.Context>;
 
 export function GetContracts():Promise<Array<types.Contract>>;
@@ -146,6 +165,10 @@ export function GetDressesSummary(arg1:types.Payload):Promise<types.Summary>;
 
 export function GetElementsConfig():Promise<preferences.ElementsConfig>;
 
+export function GetExportProfiles(arg1:types.Payload):Promise<Array<types.ExportProfile>>;
+
+export function GetExportTemplates(arg1:types.Payload,arg2:boolean):Promise<Array<preferences.ExportTemplate>>;
+
 export function GetExportsBuckets(arg1:types.Payload):Promise<types.Buckets>;
 
 export function GetExportsConfig(arg1:types.Payload):Promise<types.ViewConfig>;
@@ -196,8 +219,12 @@ export function GetOpenProjects():Promise<Array<Record<string, any>>>;
 
 export function GetOrgPreferences():Promise<preferences.OrgPreferences>;
 
+export function GetPeriodConfig():Promise<types.PeriodConfig>;
+
 export function GetProjectAddress():Promise<base.Address>;
 
+export function GetProjectLabels():Promise<Array<project.Label>>;
+
 export function GetProjectViewState(arg1:string):Promise<Record<string, project.ViewFacetState>>;
 
 export function GetProjectsBuckets(arg1:types.Payload):Promise<types.Buckets>;
@@ -236,6 +263,10 @@ export function HandleProjectsRowAction(arg1:types.RowActionPayload):Promise<voi
 
 export function HasActiveProject():Promise<boolean>;
 
+export function ImportNames(arg1:types.Payload,arg2:Array<names.ImportRow>):Promise<names.ImportReport>;
+
+export function ImportSignatures(arg1:string):Promise<signatures.Report>;
+
 export function ImportSkin(arg1:string):Promise<void>;
 
 export function IsDialogSilenced(arg1:string):Promise<boolean>;
@@ -266,6 +297,10 @@ export function OpenURL(arg1:string):Promise<void>;
 
 export function PrepareTransaction(arg1:types.Payload,arg2:app.PrepareTransactionRequest):Promise<app.PrepareTransactionResult>;
 
+export function PreviewNamesImport(arg1:string):Promise<Array<names.ImportRow>>;
+
+export function ReadContractState(arg1:types.Payload,arg2:Array<string>,arg3:boolean):Promise<contracts.ContractState>;
+
 export function ReadToMe(arg1:types.Payload,arg2:string):Promise<string>;
 
 export function RegisterCollection(arg1:types.Collection):Promise<void>;
@@ -296,10 +331,16 @@ export function ReloadStatus(arg1:types.Payload):Promise<void>;
 
 export function RemoveAddressFromProject(arg1:string):Promise<void>;
 
+export function RemoveExportTemplate(arg1:preferences.ExportTemplate,arg2:boolean):Promise<void>;
+
+export function RemoveProjectLabel(arg1:string):Promise<void>;
+
 export function RestoreProjectContext(arg1:string):Promise<void>;
 
 export function SaveBounds(arg1:number,arg2:number,arg3:number,arg4:number):Promise<void>;
 
+export function SaveExportTemplate(arg1:preferences.ExportTemplate,arg2:boolean):Promise<void>;
+
 export function SaveProject():Promise<void>;
 
 export function SetActiveAddress(arg1:string):Promise<void>;
@@ -320,6 +361,8 @@ export function SetChromeCollapsed(arg1:boolean):Promise<void>;
 
 export function SetChunksMetric(arg1:string,arg2:string):Promise<void>;
 
+export function SetCompress(arg1:boolean):Promise<void>;
+
 export function SetDebugCollapsed(arg1:boolean):Promise<void>;
 
 export function SetDetailSectionState(arg1:string,arg2:boolean):Promise<void>;
@@ -346,6 +389,10 @@ export function SetOrgPreferences(arg1:preferences.OrgPreferences):Promise<void>
 
 export function SetProjectAddress(arg1:base.Address):Promise<void>;
 
+export function SetProjectLabel(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function SetProjectPeriodConfig(arg1:types.PeriodConfig):Promise<void>;
+
 export function SetProjectViewState(arg1:string,arg2:Record<string, project.ViewFacetState>):Promise<void>;
 
 export function SetSkin(arg1:string):Promise<void>;
@@ -14,6 +14,10 @@ export function AddAddressesToProject(arg1) {
   return window['go']['app']['App']['AddAddressesToProject'](arg1);
 }
 
+export function CancelExport(arg1) {
+  return window['go']['app']['App']['CancelExport'](arg1);
+}
+
 export function CancelFetches() {
   return window['go']['app']['App']['CancelFetches']();
 }
@@ -26,6 +30,18 @@ export function ChangeVisibility(arg1) {
   return window['go']['app']['App']['ChangeVisibility'](arg1);
 }
 
+export function ChooseAbiFile() {
+  return window['go']['app']['App']['ChooseAbiFile']();
+}
+
+export function ChooseNamesFile() {
+  return window['go']['app']['App']['ChooseNamesFile']();
+}
+
+export function ChooseSignatureFile() {
+  return window['go']['app']['App']['ChooseSignatureFile']();
+}
+
 export function ClearActiveProject() {
   return window['go']['app']['App']['ClearActiveProject']();
 }
@@ -46,6 +62,10 @@ export function ConfigOk() {
   return window['go']['app']['App']['ConfigOk']();
 }
 
+export function ContractsCrud(arg1,arg2,arg3) {
+  return window['go']['app']['App']['ContractsCrud'](arg1,arg2,arg3);
+}
+
 export function ConvertToAddress(arg1) {
   return window['go']['app']['App']['ConvertToAddress'](arg1);
 }
@@ -66,10 +86,22 @@ export function ExecuteRowAction(arg1) {
   return window['go']['app']['App']['ExecuteRowAction'](arg1);
 }
 
+export function ExportBundle(arg1,arg2) {
+  return window['go']['app']['App']['ExportBundle'](arg1,arg2);
+}
+
+export function ExportCustomNames() {
+  return window['go']['app']['App']['ExportCustomNames']();
+}
+
 export function ExportData(arg1) {
   return window['go']['app']['App']['ExportData'](arg1);
 }
 
+export function ExportFacets(arg1,arg2) {
+  return window['go']['app']['App']['ExportFacets'](arg1,arg2);
+}
+
 export function ExportSkin(arg1) {
   return window['go']['app']['App']['ExportSkin'](arg1);
 }
@@ -190,6 +222,10 @@ export function GetComparitoorSummary(arg1) {
   return window['go']['app']['App']['GetComparitoorSummary'](arg1);
 }
 
+export function GetCompress() {
+  return window['go']['app']['App']['GetCompress']();
+}
+
 export function GetContext() {
   return window['go']['app']['App']['GetContext']();
 }
@@ -250,6 +286,14 @@ export function GetElementsConfig() {
   return window['go']['app']['App']['GetElementsConfig']();
 }
 
+export function GetExportProfiles(arg1) {
+  return window['go']['app']['App']['GetExportProfiles'](arg1);
+}
+
+export function GetExportTemplates(arg1,arg2) {
+  return window['go']['app']['App']['GetExportTemplates'](arg1,arg2);
+}
+
 export function GetExportsBuckets(arg1) {
   return window['go']['app']['App']['GetExportsBuckets'](arg1);
 }
@@ -350,10 +394,18 @@ export function GetOrgPreferences() {
   return window['go']['app']['App']['GetOrgPreferences']();
 }
 
+export function GetPeriodConfig() {
+  return window['go']['app']['App']['GetPeriodConfig']();
+}
+
 export function GetProjectAddress() {
   return window['go']['app']['App']['GetProjectAddress']();
 }
 
+export function GetProjectLabels() {
+  return window['go']['app']['App']['GetProjectLabels']();
+}
+
 export function GetProjectViewState(arg1) {
   return window['go']['app']['App']['GetProjectViewState'](arg1);
 }
@@ -430,6 +482,14 @@ export function HasActiveProject() {
   return window['go']['app']['App']['HasActiveProject']();
 }
 
+export function ImportNames(arg1,arg2) {
+  return window['go']['app']['App']['ImportNames'](arg1,arg2);
+}
+
+export function ImportSignatures(arg1) {
+  return window['go']['app']['App']['ImportSignatures'](arg1);
+}
+
 export function ImportSkin(arg1) {
   return window['go']['app']['App']['ImportSkin'](arg1);
 }
@@ -490,6 +550,14 @@ export function PrepareTransaction(arg1, arg2) {
   return window['go']['app']['App']['PrepareTransaction'](arg1, arg2);
 }
 
+export function PreviewNamesImport(arg1) {
+  return window['go']['app']['App']['PreviewNamesImport'](arg1);
+}
+
+export function ReadContractState(arg1,arg2,arg3) {
+  return window['go']['app']['App']['ReadContractState'](arg1,arg2,arg3);
+}
+
 export function ReadToMe(arg1, arg2) {
   return window['go']['app']['App']['ReadToMe'](arg1, arg2);
 }
@@ -550,6 +618,14 @@ export function RemoveAddressFromProject(arg1) {
   return window['go']['app']['App']['RemoveAddressFromProject'](arg1);
 }
 
+export function RemoveExportTemplate(arg1,arg2) {
+  return window['go']['app']['App']['RemoveExportTemplate'](arg1,arg2);
+}
+
+export function RemoveProjectLabel(arg1) {
+  return window['go']['app']['App']['RemoveProjectLabel'](arg1);
+}
+
 export function RestoreProjectContext(arg1) {
   return window['go']['app']['App']['RestoreProjectContext'](arg1);
 }
@@ -558,6 +634,10 @@ export function SaveBounds(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['SaveBounds'](arg1, arg2, arg3, arg4);
 }
 
+export function SaveExportTemplate(arg1,arg2) {
+  return window['go']['app']['App']['SaveExportTemplate'](arg1,arg2);
+}
+
 export function SaveProject() {
   return window['go']['app']['App']['SaveProject']();
 }
@@ -598,6 +678,10 @@ export function SetChunksMetric(arg1, arg2) {
   return window['go']['app']['App']['SetChunksMetric'](arg1, arg2);
 }
 
+export function SetCompress(arg1) {
+  return window['go']['app']['App']['SetCompress'](arg1);
+}
+
 export function SetDebugCollapsed(arg1) {
   return window['go']['app']['App']['SetDebugCollapsed'](arg1);
 }
@@ -650,6 +734,14 @@ export function SetProjectAddress(arg1) {
   return window['go']['app']['App']['SetProjectAddress'](arg1);
 }
 
+export function SetProjectLabel(arg1, arg2, arg3) {
+  return window['go']['app']['App']['SetProjectLabel'](arg1, arg2, arg3);
+}
+
+export function SetProjectPeriodConfig(arg1) {
+  return window['go']['app']['App']['SetProjectPeriodConfig'](arg1);
+}
+
 export function SetProjectViewState(arg1, arg2) {
   return window['go']['app']['App']['SetProjectViewState'](arg1, arg2);
 }
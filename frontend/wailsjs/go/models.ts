@@ -7,6 +7,8 @@ export namespace abis {
 	    totalItems: number;
 	    expectedTotal: number;
 	    state: types.StoreState;
+	    collidesWith: string[][];
+	    nCollisions: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new AbisPage(source);
@@ -20,6 +22,8 @@ export namespace abis {
 	        this.totalItems = source["totalItems"];
 	        this.expectedTotal = source["expectedTotal"];
 	        this.state = source["state"];
+	        this.collidesWith = source["collidesWith"];
+	        this.nCollisions = source["nCollisions"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -200,24 +204,83 @@ export namespace chunks {
 
 export namespace comparitoor {
 	
+	export class FieldDiff {
+	    field: string;
+	    values: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new FieldDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.field = source["field"];
+	        this.values = source["values"];
+	    }
+	}
+	export class Comparison {
+	    key: string;
+	    blockNumber: number;
+	    transactionIndex: number;
+	    hash: base.Hash;
+	    class: string;
+	    label: string;
+	    category: string;
+	    present: string[];
+	    missing: string[];
+	    diffs?: FieldDiff[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Comparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.blockNumber = source["blockNumber"];
+	        this.transactionIndex = source["transactionIndex"];
+	        this.hash = this.convertValues(source["hash"], base.Hash);
+	        this.class = source["class"];
+	        this.label = source["label"];
+	        this.category = source["category"];
+	        this.present = source["present"];
+	        this.missing = source["missing"];
+	        this.diffs = this.convertValues(source["diffs"], FieldDiff);
+	    }
+	
+	convertValues(a: any, classs: any, asMap: boolean = false): any {
+	    if (!a) {
+	        return a;
+	    }
+	    if (a.slice && a.map) {
+	        return (a as any[]).map(elem => this.convertValues(elem, classs));
+	    } else if ("object" === typeof a) {
+	        if (asMap) {
+	            for (const key of Object.keys(a)) {
+	                a[key] = new classs(a[key]);
+	            }
+	            return a;
+	        }
+	        return new classs(a);
+	    }
+	    return a;
+	}
+	}
 	export class ComparitoorPage {
 	    facet: types.DataFacet;
 	    transaction: types.Transaction[];
 	    totalItems: number;
 	    expectedTotal: number;
 	    state: types.StoreState;
-	    chifra: types.Transaction[];
+	    comparisons?: Comparison[];
+	    classCounts?: Record<string, number>;
 	    chifraCount: number;
-	    etherscan: types.Transaction[];
 	    etherscanCount: number;
-	    covalent: types.Transaction[];
 	    covalentCount: number;
-	    alchemy: types.Transaction[];
 	    alchemyCount: number;
 	    overlapCount: number;
 	    unionCount: number;
 	    intersectionCount: number;
-	    overlapDetails?: Record<string, number>;
 	
 	    static createFrom(source: any = {}) {
 	        return new ComparitoorPage(source);
@@ -230,18 +293,58 @@ export namespace comparitoor {
 	        this.totalItems = source["totalItems"];
 	        this.expectedTotal = source["expectedTotal"];
 	        this.state = source["state"];
-	        this.chifra = this.convertValues(source["chifra"], types.Transaction);
+	        this.comparisons = this.convertValues(source["comparisons"], Comparison);
+	        this.classCounts = source["classCounts"];
 	        this.chifraCount = source["chifraCount"];
-	        this.etherscan = this.convertValues(source["etherscan"], types.Transaction);
 	        this.etherscanCount = source["etherscanCount"];
-	        this.covalent = this.convertValues(source["covalent"], types.Transaction);
 	        this.covalentCount = source["covalentCount"];
-	        this.alchemy = this.convertValues(source["alchemy"], types.Transaction);
 	        this.alchemyCount = source["alchemyCount"];
 	        this.overlapCount = source["overlapCount"];
 	        this.unionCount = source["unionCount"];
 	        this.intersectionCount = source["intersectionCount"];
-	        this.overlapDetails = source["overlapDetails"];
+	    }
+	
+	convertValues(a: any, classs: any, asMap: boolean = false): any {
+	    if (!a) {
+	        return a;
+	    }
+	    if (a.slice && a.map) {
+	        return (a as any[]).map(elem => this.convertValues(elem, classs));
+	    } else if ("object" === typeof a) {
+	        if (asMap) {
+	            for (const key of Object.keys(a)) {
+	                a[key] = new classs(a[key]);
+	            }
+	            return a;
+	        }
+	        return new classs(a);
+	    }
+	    return a;
+	}
+	}
+
+}
+
+export namespace contracts {
+	
+	export class ReadResult {
+	    name: string;
+	    signature: string;
+	    args?: string[];
+	    outputs: types.Parameter[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReadResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.signature = source["signature"];
+	        this.args = source["args"];
+	        this.outputs = this.convertValues(source["outputs"], types.Parameter);
+	        this.error = source["error"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -262,11 +365,80 @@ export namespace comparitoor {
 		    return a;
 		}
 	}
-
-}
-
-export namespace contracts {
+	export class ContractState {
+	    address: base.Address;
+	    holder: base.Address;
+	    blockNumber: number;
+	    results: ReadResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ContractState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = this.convertValues(source["address"], base.Address);
+	        this.holder = this.convertValues(source["holder"], base.Address);
+	        this.blockNumber = source["blockNumber"];
+	        this.results = this.convertValues(source["results"], ReadResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class EventView {
+	    name: string;
+	    signature: string;
+	    topic: string;
+	    count: number;
+	    columns: types.ColumnConfig[];
+	
+	    static createFrom(source: any = {}) {
+	        return new EventView(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.signature = source["signature"];
+	        this.topic = source["topic"];
+	        this.count = source["count"];
+	        this.columns = this.convertValues(source["columns"], types.ColumnConfig);
+	    }
 	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class ContractsPage {
 	    facet: types.DataFacet;
 	    contracts: types.Contract[];
@@ -274,6 +446,9 @@ export namespace contracts {
 	    totalItems: number;
 	    expectedTotal: number;
 	    state: types.StoreState;
+	    deployedBlocks: number[];
+	    events: EventView[];
+	    eventValues: Record<string, string>[];
 	
 	    static createFrom(source: any = {}) {
 	        return new ContractsPage(source);
@@ -287,6 +462,9 @@ export namespace contracts {
 	        this.totalItems = source["totalItems"];
 	        this.expectedTotal = source["expectedTotal"];
 	        this.state = source["state"];
+	        this.deployedBlocks = source["deployedBlocks"];
+	        this.events = this.convertValues(source["events"], EventView);
+	        this.eventValues = source["eventValues"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -427,12 +605,55 @@ export namespace dresses {
 
 export namespace exports {
 	
+	export class Counterparty {
+	    address: base.Address;
+	    addressName: string;
+	    transfers: number;
+	    sent: number;
+	    received: number;
+	    firstBlock: number;
+	    lastBlock: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Counterparty(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = this.convertValues(source["address"], base.Address);
+	        this.addressName = source["addressName"];
+	        this.transfers = source["transfers"];
+	        this.sent = source["sent"];
+	        this.received = source["received"];
+	        this.firstBlock = source["firstBlock"];
+	        this.lastBlock = source["lastBlock"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class ExportsPage {
 	    facet: types.DataFacet;
 	    approvallogs: types.Log[];
 	    approvaltxs: types.Transaction[];
 	    assets: types.Statement[];
 	    balances: types.Token[];
+	    counterparties: Counterparty[];
 	    logs: types.Log[];
 	    openapprovals: types.Approval[];
 	    receipts: types.Receipt[];
@@ -444,6 +665,8 @@ export namespace exports {
 	    totalItems: number;
 	    expectedTotal: number;
 	    state: types.StoreState;
+	    totals?: store.AggregateSummary[];
+	    values?: pricing.Valuation[];
 	
 	    static createFrom(source: any = {}) {
 	        return new ExportsPage(source);
@@ -456,6 +679,7 @@ export namespace exports {
 	        this.approvaltxs = this.convertValues(source["approvaltxs"], types.Transaction);
 	        this.assets = this.convertValues(source["assets"], types.Statement);
 	        this.balances = this.convertValues(source["balances"], types.Token);
+	        this.counterparties = this.convertValues(source["counterparties"], Counterparty);
 	        this.logs = this.convertValues(source["logs"], types.Log);
 	        this.openapprovals = this.convertValues(source["openapprovals"], types.Approval);
 	        this.receipts = this.convertValues(source["receipts"], types.Receipt);
@@ -467,6 +691,8 @@ export namespace exports {
 	        this.totalItems = source["totalItems"];
 	        this.expectedTotal = source["expectedTotal"];
 	        this.state = source["state"];
+	        this.totals = this.convertValues(source["totals"], store.AggregateSummary);
+	        this.values = this.convertValues(source["values"], pricing.Valuation);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -815,12 +1041,77 @@ export namespace msgs {
 	    FACET_CHANGED = "facet:changed",
 	    PROJECT_CLOSED = "project:closed",
 	    PROJECT_SWITCHED = "project:switched",
+	    EXPORT_PROGRESS = "export:progress",
 	}
 
 }
 
 export namespace names {
 	
+	export class ImportReport {
+	    added: number;
+	    updated: number;
+	    skipped: number;
+	    failed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.added = source["added"];
+	        this.updated = source["updated"];
+	        this.skipped = source["skipped"];
+	        this.failed = source["failed"];
+	    }
+	}
+	export class ImportRow {
+	    line: number;
+	    address: string;
+	    name: string;
+	    tags: string;
+	    source: string;
+	    status: string;
+	    reason?: string;
+	    existing?: types.Name;
+	    apply: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportRow(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.line = source["line"];
+	        this.address = source["address"];
+	        this.name = source["name"];
+	        this.tags = source["tags"];
+	        this.source = source["source"];
+	        this.status = source["status"];
+	        this.reason = source["reason"];
+	        this.existing = this.convertValues(source["existing"], types.Name);
+	        this.apply = source["apply"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class NamesPage {
 	    facet: types.DataFacet;
 	    names: types.Name[];
@@ -902,6 +1193,7 @@ export namespace preferences {
 	    lastTheme: string;
 	    lastSkin: string;
 	    lastFormat: string;
+	    lastCompress?: boolean;
 	    lastLanguage: string;
 	    lastProjects: OpenProject[];
 	    helpCollapsed: boolean;
@@ -929,6 +1221,7 @@ export namespace preferences {
 	        this.lastTheme = source["lastTheme"];
 	        this.lastSkin = source["lastSkin"];
 	        this.lastFormat = source["lastFormat"];
+	        this.lastCompress = source["lastCompress"];
 	        this.lastLanguage = source["lastLanguage"];
 	        this.lastProjects = this.convertValues(source["lastProjects"], OpenProject);
 	        this.helpCollapsed = source["helpCollapsed"];
@@ -1005,6 +1298,24 @@ export namespace preferences {
 	        this.hideProjectSelector = source["hideProjectSelector"];
 	    }
 	}
+	export class ExportTemplate {
+	    name: string;
+	    collection: string;
+	    dataFacet: string;
+	    columns: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportTemplate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.collection = source["collection"];
+	        this.dataFacet = source["dataFacet"];
+	        this.columns = source["columns"];
+	    }
+	}
 	export class Id {
 	    appName: string;
 	    baseName: string;
@@ -1037,6 +1348,7 @@ export namespace preferences {
 	    logLevel?: string;
 	    experimental?: boolean;
 	    supportUrl?: string;
+	    exportTemplates?: ExportTemplate[];
 	
 	    static createFrom(source: any = {}) {
 	        return new OrgPreferences(source);
@@ -1052,13 +1364,41 @@ export namespace preferences {
 	        this.logLevel = source["logLevel"];
 	        this.experimental = source["experimental"];
 	        this.supportUrl = source["supportUrl"];
+	        this.exportTemplates = this.convertValues(source["exportTemplates"], ExportTemplate);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class UserPreferences {
 	    version?: string;
 	    name?: string;
 	    email?: string;
 	    chains?: Chain[];
+	    timezone?: string;
+	    weekStart?: number;
+	    fiscalYearStart?: number;
+	    fiatCurrency?: string;
+	    priceSource?: string;
+	    priceFile?: string;
+	    priceFeeds?: Record<string, string>;
+	    providerKeys?: Record<string, string>;
+	    providerFiles?: Record<string, string>;
 	
 	    static createFrom(source: any = {}) {
 	        return new UserPreferences(source);
@@ -1070,6 +1410,15 @@ export namespace preferences {
 	        this.name = source["name"];
 	        this.email = source["email"];
 	        this.chains = this.convertValues(source["chains"], Chain);
+	        this.timezone = source["timezone"];
+	        this.weekStart = source["weekStart"];
+	        this.fiscalYearStart = source["fiscalYearStart"];
+	        this.fiatCurrency = source["fiatCurrency"];
+	        this.priceSource = source["priceSource"];
+	        this.priceFile = source["priceFile"];
+	        this.priceFeeds = source["priceFeeds"];
+	        this.providerKeys = source["providerKeys"];
+	        this.providerFiles = source["providerFiles"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1093,12 +1442,108 @@ export namespace preferences {
 
 }
 
+export namespace pricing {
+	
+	export class Valuation {
+	    currency?: string;
+	    price: number;
+	    value: number;
+	    source?: string;
+	    priced: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Valuation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currency = source["currency"];
+	        this.price = source["price"];
+	        this.value = source["value"];
+	        this.source = source["source"];
+	        this.priced = source["priced"];
+	    }
+	}
+
+}
+
 export namespace project {
 	
+	export class Contract {
+	    address: base.Address;
+	    chain: string;
+	    name?: string;
+	    deployedBlock?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Contract(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = this.convertValues(source["address"], base.Address);
+	        this.chain = source["chain"];
+	        this.name = source["name"];
+	        this.deployedBlock = source["deployedBlock"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Label {
+	    address: base.Address;
+	    name: string;
+	    tags?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Label(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = this.convertValues(source["address"], base.Address);
+	        this.name = source["name"];
+	        this.tags = source["tags"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class ViewFacetState {
 	    sorting?: Record<string, any>;
 	    filtering?: Record<string, any>;
 	    other?: Record<string, any>;
+	    columns?: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new ViewFacetState(source);
@@ -1109,6 +1554,7 @@ export namespace project {
 	        this.sorting = source["sorting"];
 	        this.filtering = source["filtering"];
 	        this.other = source["other"];
+	        this.columns = source["columns"];
 	    }
 	}
 	export class ViewStateKey {
@@ -1135,10 +1581,12 @@ export namespace project {
 	    activeAddress: base.Address;
 	    chains: string[];
 	    activeChain: string;
-	    contracts: string[];
+	    contracts: Contract[];
 	    activeContract: string;
 	    activePeriod: types.Period;
+	    periodConfig?: types.PeriodConfig;
 	    viewFacetStates: Record<string, ViewFacetState>;
+	    labels?: Label[];
 	
 	    static createFrom(source: any = {}) {
 	        return new Project(source);
@@ -1155,10 +1603,12 @@ export namespace project {
 	        this.activeAddress = this.convertValues(source["activeAddress"], base.Address);
 	        this.chains = source["chains"];
 	        this.activeChain = source["activeChain"];
-	        this.contracts = source["contracts"];
+	        this.contracts = this.convertValues(source["contracts"], Contract);
 	        this.activeContract = source["activeContract"];
 	        this.activePeriod = source["activePeriod"];
+	        this.periodConfig = this.convertValues(source["periodConfig"], types.PeriodConfig);
 	        this.viewFacetStates = this.convertValues(source["viewFacetStates"], ViewFacetState, true);
+	        this.labels = this.convertValues(source["labels"], Label);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1298,6 +1748,29 @@ export namespace sdk {
 
 }
 
+export namespace signatures {
+	
+	export class Report {
+	    added: number;
+	    rejected: number;
+	    functions: number;
+	    events: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.added = source["added"];
+	        this.rejected = source["rejected"];
+	        this.functions = source["functions"];
+	        this.events = source["events"];
+	    }
+	}
+
+}
+
 export namespace skin {
 	
 	export class Skin {
@@ -1379,12 +1852,88 @@ export namespace skin {
 
 }
 
+export namespace store {
+	
+	export class AggregateSummary {
+	    period: types.Period;
+	    timestamp: number;
+	    asset: string;
+	    symbol: string;
+	    decimals: number;
+	    count: number;
+	    sumIn: any;
+	    sumOut: any;
+	    netFlow: any;
+	    minBalance: any;
+	    maxBalance: any;
+	    gasSpent: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new AggregateSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.period = source["period"];
+	        this.timestamp = source["timestamp"];
+	        this.asset = source["asset"];
+	        this.symbol = source["symbol"];
+	        this.decimals = source["decimals"];
+	        this.count = source["count"];
+	        this.sumIn = source["sumIn"];
+	        this.sumOut = source["sumOut"];
+	        this.netFlow = source["netFlow"];
+	        this.minBalance = source["minBalance"];
+	        this.maxBalance = source["maxBalance"];
+	        this.gasSpent = source["gasSpent"];
+	    }
+	}
+	export class FetchMetrics {
+	    storeName: string;
+	    state: types.StoreState;
+	    fetches: number;
+	    items: number;
+	    lastDurationMs: number;
+	    timeToFirstMs: number;
+	    itemsPerSecond: number;
+	    errors: number;
+	    retries: number;
+	    cancellations: number;
+	    memoryBytes: number;
+	    lastFetchedAt: number;
+	    lastError: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FetchMetrics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.storeName = source["storeName"];
+	        this.state = source["state"];
+	        this.fetches = source["fetches"];
+	        this.items = source["items"];
+	        this.lastDurationMs = source["lastDurationMs"];
+	        this.timeToFirstMs = source["timeToFirstMs"];
+	        this.itemsPerSecond = source["itemsPerSecond"];
+	        this.errors = source["errors"];
+	        this.retries = source["retries"];
+	        this.cancellations = source["cancellations"];
+	        this.memoryBytes = source["memoryBytes"];
+	        this.lastFetchedAt = source["lastFetchedAt"];
+	        this.lastError = source["lastError"];
+	    }
+	}
+
+}
+
 export namespace status {
 	
 	export class StatusPage {
 	    facet: types.DataFacet;
 	    caches: types.CacheItem[];
 	    chains: types.Chain[];
+	    metrics: store.FetchMetrics[];
 	    status: types.Status[];
 	    totalItems: number;
 	    expectedTotal: number;
@@ -1399,6 +1948,7 @@ export namespace status {
 	        this.facet = source["facet"];
 	        this.caches = this.convertValues(source["caches"], types.CacheItem);
 	        this.chains = this.convertValues(source["chains"], types.Chain);
+	        this.metrics = this.convertValues(source["metrics"], store.FetchMetrics);
 	        this.status = this.convertValues(source["status"], types.Status);
 	        this.totalItems = source["totalItems"];
 	        this.expectedTotal = source["expectedTotal"];
@@ -1459,6 +2009,7 @@ export namespace types {
 	    ASSETCHARTS = "assetcharts",
 	    BALANCES = "balances",
 	    TRANSFERS = "transfers",
+	    COUNTERPARTIES = "counterparties",
 	    OPENAPPROVALS = "openapprovals",
 	    APPROVALTXS = "approvaltxs",
 	    APPROVALLOGS = "approvallogs",
@@ -1472,6 +2023,7 @@ export namespace types {
 	    STATUS = "status",
 	    CACHES = "caches",
 	    CHAINS = "chains",
+	    METRICS = "metrics",
 	}
 	export enum StoreState {
 	    STALE = "stale",
@@ -1486,6 +2038,8 @@ export namespace types {
 	    MONTHLY = "monthly",
 	    QUARTERLY = "quarterly",
 	    ANNUAL = "annual",
+	    FISCAL_QUARTERLY = "fiscalQuarterly",
+	    FISCAL_ANNUAL = "fiscalAnnual",
 	}
 	export class AbiCalcs {
 	    name?: string;
@@ -2495,9 +3049,30 @@ export namespace types {
 		}
 	}
 	
+	export class ExportProfile {
+	    name: string;
+	    label: string;
+	    collection: string;
+	    dataFacet: DataFacet;
+	    columns: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.label = source["label"];
+	        this.collection = source["collection"];
+	        this.dataFacet = source["dataFacet"];
+	        this.columns = source["columns"];
+	    }
+	}
 	export class FacetChartConfig {
 	    seriesStrategy?: string;
 	    seriesPrefixLen?: number;
+	    metrics?: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new FacetChartConfig(source);
@@ -2507,6 +3082,7 @@ export namespace types {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.seriesStrategy = source["seriesStrategy"];
 	        this.seriesPrefixLen = source["seriesPrefixLen"];
+	        this.metrics = source["metrics"];
 	    }
 	}
 	export class RowIdentifier {
@@ -3021,6 +3597,14 @@ export namespace types {
 	    targetSwitch?: boolean;
 	    format?: string;
 	    projectPath?: string;
+	    firstBlock?: number;
+	    lastBlock?: number;
+	    firstDate?: string;
+	    lastDate?: string;
+	    compress?: boolean;
+	    profile?: string;
+	    template?: string;
+	    columns?: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new Payload(source);
@@ -3039,6 +3623,30 @@ export namespace types {
 	        this.targetSwitch = source["targetSwitch"];
 	        this.format = source["format"];
 	        this.projectPath = source["projectPath"];
+	        this.firstBlock = source["firstBlock"];
+	        this.lastBlock = source["lastBlock"];
+	        this.firstDate = source["firstDate"];
+	        this.lastDate = source["lastDate"];
+	        this.compress = source["compress"];
+	        this.profile = source["profile"];
+	        this.template = source["template"];
+	        this.columns = source["columns"];
+	    }
+	}
+	export class PeriodConfig {
+	    timezone?: string;
+	    weekStart?: number;
+	    fiscalYearStart?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PeriodConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.timezone = source["timezone"];
+	        this.weekStart = source["weekStart"];
+	        this.fiscalYearStart = source["fiscalYearStart"];
 	    }
 	}
 	export class ProjectPayload {
@@ -3214,6 +3822,14 @@ export namespace types {
 	    targetSwitch?: boolean;
 	    format?: string;
 	    projectPath?: string;
+	    firstBlock?: number;
+	    lastBlock?: number;
+	    firstDate?: string;
+	    lastDate?: string;
+	    compress?: boolean;
+	    profile?: string;
+	    template?: string;
+	    columns?: string[];
 	    rowData: Record<string, any>;
 	    rowAction?: RowActionConfig;
 	    contextValues?: Record<string, any>;
@@ -3235,6 +3851,14 @@ export namespace types {
 	        this.targetSwitch = source["targetSwitch"];
 	        this.format = source["format"];
 	        this.projectPath = source["projectPath"];
+	        this.firstBlock = source["firstBlock"];
+	        this.lastBlock = source["lastBlock"];
+	        this.firstDate = source["firstDate"];
+	        this.lastDate = source["lastDate"];
+	        this.compress = source["compress"];
+	        this.profile = source["profile"];
+	        this.template = source["template"];
+	        this.columns = source["columns"];
 	        this.rowData = source["rowData"];
 	        this.rowAction = this.convertValues(source["rowAction"], RowActionConfig);
 	        this.contextValues = source["contextValues"];
@@ -0,0 +1,243 @@
+package facets
+
+import (
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/store"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
+)
+
+// ProjectFunc maps a source row to a derived row. Returning false drops the row.
+type ProjectFunc[In, Out any] func(item *In) (*Out, bool)
+
+// KeyFunc extracts the grouping or join key from a row
+type KeyFunc[T any] func(item *T) string
+
+// GroupFuncs define a group-by. NewGroup creates the row for a key the first time the key
+// is seen, and Fold returns the group's row with a source row (including the first) merged
+// in. Fold must build a new row rather than change group, which facets may be reading.
+type GroupFuncs[In, Out any] struct {
+	Key      KeyFunc[In]
+	NewGroup func(key string, first *In) *Out
+	Fold     func(group *Out, item *In) *Out
+}
+
+// JoinFuncs define an inner join on equal keys
+type JoinFuncs[L, R, Out any] struct {
+	LeftKey  KeyFunc[L]
+	RightKey KeyFunc[R]
+	Combine  func(left *L, right *R) *Out
+}
+
+// NewProjectionStore returns a store whose rows are project(row) for every row of src
+func NewProjectionStore[In, Out any](name string, src *store.Store[In], project ProjectFunc[In, Out]) *store.Store[Out] {
+	seen := make(map[*In]struct{})
+	d := &derivation[Out]{}
+	d.reset = func() {
+		seen = make(map[*In]struct{})
+	}
+	onItem := func(item *In, emit func(*Out)) {
+		if _, ok := seen[item]; ok {
+			return
+		}
+		seen[item] = struct{}{}
+		if out, ok := project(item); ok && out != nil {
+			emit(out)
+		}
+	}
+	d.replay = func(emit func(*Out)) {
+		for _, item := range src.GetItems(false) {
+			onItem(item, emit)
+		}
+	}
+	d.sources = []sourceState{src}
+	d.init(name)
+	src.RegisterObserver(newSourceObserver(d, onItem))
+	return d.out
+}
+
+// NewGroupByStore returns a store with one row per distinct key in src. A group's row is
+// replaced as new source rows arrive, so facets showing the store see running totals.
+func NewGroupByStore[In, Out any](name string, src *store.Store[In], fns GroupFuncs[In, Out]) *store.Store[Out] {
+	seen := make(map[*In]struct{})
+	groups := make(map[string]*Out)
+	d := &derivation[Out]{}
+	d.reset = func() {
+		seen = make(map[*In]struct{})
+		groups = make(map[string]*Out)
+	}
+	onItem := func(item *In, emit func(*Out)) {
+		if _, ok := seen[item]; ok {
+			return
+		}
+		seen[item] = struct{}{}
+		key := fns.Key(item)
+		group, exists := groups[key]
+		if !exists {
+			group = fns.Fold(fns.NewGroup(key, item), item)
+			groups[key] = group
+			emit(group)
+			return
+		}
+		folded := fns.Fold(group, item)
+		groups[key] = folded
+		d.out.ReplaceItem(group, folded)
+	}
+	d.replay = func(emit func(*Out)) {
+		for _, item := range src.GetItems(false) {
+			onItem(item, emit)
+		}
+	}
+	d.sources = []sourceState{src}
+	d.init(name)
+	src.RegisterObserver(newSourceObserver(d, onItem))
+	return d.out
+}
+
+// NewJoinStore returns a store with one row per matching (left, right) pair
+func NewJoinStore[L, R, Out any](name string, left *store.Store[L], right *store.Store[R], fns JoinFuncs[L, R, Out]) *store.Store[Out] {
+	seenLeft := make(map[*L]struct{})
+	seenRight := make(map[*R]struct{})
+	leftIndex := make(map[string][]*L)
+	rightIndex := make(map[string][]*R)
+	d := &derivation[Out]{}
+	d.reset = func() {
+		seenLeft = make(map[*L]struct{})
+		seenRight = make(map[*R]struct{})
+		leftIndex = make(map[string][]*L)
+		rightIndex = make(map[string][]*R)
+	}
+	onLeft := func(item *L, emit func(*Out)) {
+		if _, ok := seenLeft[item]; ok {
+			return
+		}
+		seenLeft[item] = struct{}{}
+		key := fns.LeftKey(item)
+		leftIndex[key] = append(leftIndex[key], item)
+		for _, r := range rightIndex[key] {
+			if out := fns.Combine(item, r); out != nil {
+				emit(out)
+			}
+		}
+	}
+	onRight := func(item *R, emit func(*Out)) {
+		if _, ok := seenRight[item]; ok {
+			return
+		}
+		seenRight[item] = struct{}{}
+		key := fns.RightKey(item)
+		rightIndex[key] = append(rightIndex[key], item)
+		for _, l := range leftIndex[key] {
+			if out := fns.Combine(l, item); out != nil {
+				emit(out)
+			}
+		}
+	}
+	d.replay = func(emit func(*Out)) {
+		for _, item := range left.GetItems(false) {
+			onLeft(item, emit)
+		}
+		for _, item := range right.GetItems(false) {
+			onRight(item, emit)
+		}
+	}
+	d.sources = []sourceState{left, right}
+	d.init(name)
+	left.RegisterObserver(newSourceObserver(d, onLeft))
+	right.RegisterObserver(newSourceObserver(d, onRight))
+	return d.out
+}
+
+// sourceState is the part of a source store a derivation needs to track readiness
+type sourceState interface {
+	GetState() types.StoreState
+}
+
+// derivation owns the output store of a derived facet and serializes all updates to it
+type derivation[Out any] struct {
+	out     *store.Store[Out]
+	sources []sourceState
+	reset   func()
+	replay  func(emit func(*Out))
+	mutex   sync.Mutex
+}
+
+func (d *derivation[Out]) init(name string) {
+	queryFunc := func(ctx *output.RenderCtx) error {
+		go func() {
+			defer close(ctx.ModelChan)
+			defer close(ctx.ErrorChan)
+			d.mutex.Lock()
+			defer d.mutex.Unlock()
+			d.reset()
+			d.replay(func(row *Out) {
+				if ctx.Ctx.Err() == nil {
+					d.out.AddItem(row, 0)
+				}
+			})
+		}()
+		return nil
+	}
+	processFunc := func(item interface{}) *Out {
+		return nil
+	}
+	d.out = store.NewStore(name, queryFunc, processFunc, nil)
+	d.out.SetRetryPolicy(store.NoRetryPolicy)
+}
+
+// handle applies one incremental source row to the output store
+func handle[In, Out any](d *derivation[Out], item *In, onItem func(*In, func(*Out))) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	onItem(item, func(row *Out) {
+		d.out.AddItem(row, 0)
+	})
+}
+
+// sourceChanged reacts to a source store reloading or finishing
+func (d *derivation[Out]) sourceChanged(state types.StoreState) {
+	switch state {
+	case types.StateFetching, types.StateStale:
+		// The reloading source has already dropped its rows, so rebuilding from what
+		// remains keeps the other sources' contributions without double counting
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+		d.reset()
+		if d.out.GetState() != types.StateStale || d.out.Count() > 0 {
+			d.out.Reset()
+		}
+		d.replay(func(row *Out) {
+			d.out.AddItem(row, 0)
+		})
+	case types.StateLoaded:
+		for _, src := range d.sources {
+			if src.GetState() != types.StateLoaded {
+				return
+			}
+		}
+		if d.out.GetState() != types.StateFetching {
+			d.out.ChangeState(types.StateLoaded, "Derived data up to date")
+		}
+	}
+}
+
+type sourceObserver[In, Out any] struct {
+	d      *derivation[Out]
+	onItem func(*In, func(*Out))
+}
+
+func newSourceObserver[In, Out any](d *derivation[Out], onItem func(*In, func(*Out))) *sourceObserver[In, Out] {
+	return &sourceObserver[In, Out]{d: d, onItem: onItem}
+}
+
+func (o *sourceObserver[In, Out]) OnNewItem(item *In, index int) {
+	_ = index
+	handle(o.d, item, o.onItem)
+}
+
+func (o *sourceObserver[In, Out]) OnStateChanged(state types.StoreState, reason string) {
+	_ = reason
+	o.d.sourceChanged(state)
+}
@@ -0,0 +1,209 @@
+package facets
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/store"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTransfer struct {
+	Counterparty string
+	Amount       int
+}
+
+type testTotal struct {
+	Counterparty string
+	Count        int
+	Sum          int
+}
+
+type testReceipt struct {
+	Hash   string
+	Status int
+}
+
+type testTxWithReceipt struct {
+	Hash   string
+	Value  int
+	Status int
+}
+
+// newSliceStore builds a store that loads items the way the mock-backed collections do
+func newSliceStore[T any](name string, items []*T) *store.Store[T] {
+	var s *store.Store[T]
+	s = store.NewStore(name,
+		func(ctx *output.RenderCtx) error {
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				for _, item := range items {
+					s.AddItem(item, 0)
+				}
+			}()
+			return nil
+		},
+		func(item interface{}) *T { return nil },
+		nil)
+	return s
+}
+
+func transferTotals() GroupFuncs[testTransfer, testTotal] {
+	return GroupFuncs[testTransfer, testTotal]{
+		Key: func(item *testTransfer) string { return item.Counterparty },
+		NewGroup: func(key string, first *testTransfer) *testTotal {
+			return &testTotal{Counterparty: key}
+		},
+		Fold: func(group *testTotal, item *testTransfer) *testTotal {
+			folded := *group
+			folded.Count++
+			folded.Sum += item.Amount
+			return &folded
+		},
+	}
+}
+
+func totalsByKey(s *store.Store[testTotal]) map[string]testTotal {
+	ret := make(map[string]testTotal)
+	for _, item := range s.GetItems(false) {
+		ret[item.Counterparty] = *item
+	}
+	return ret
+}
+
+func TestGroupByStoreRecomputesFromLoadedSource(t *testing.T) {
+	src := newSliceStore("derived-group-src", []*testTransfer{
+		{Counterparty: "alice", Amount: 10},
+		{Counterparty: "bob", Amount: 5},
+		{Counterparty: "alice", Amount: 7},
+	})
+	assert.NoError(t, src.Fetch())
+
+	grouped := NewGroupByStore("derived-group", src, transferTotals())
+	assert.NoError(t, grouped.Fetch())
+
+	totals := totalsByKey(grouped)
+	assert.Len(t, totals, 2)
+	assert.Equal(t, testTotal{Counterparty: "alice", Count: 2, Sum: 17}, totals["alice"])
+	assert.Equal(t, testTotal{Counterparty: "bob", Count: 1, Sum: 5}, totals["bob"])
+	assert.Equal(t, types.StateLoaded, grouped.GetState())
+}
+
+func TestGroupByStoreUpdatesIncrementally(t *testing.T) {
+	src := newSliceStore("derived-incr-src", []*testTransfer{
+		{Counterparty: "alice", Amount: 1},
+	})
+	grouped := NewGroupByStore("derived-incr", src, transferTotals())
+
+	// Source streams after the derived store exists; rows arrive through the observer
+	assert.NoError(t, src.Fetch())
+	assert.Equal(t, types.StateLoaded, grouped.GetState())
+	assert.Equal(t, 1, totalsByKey(grouped)["alice"].Sum)
+
+	src.AddItem(&testTransfer{Counterparty: "alice", Amount: 4}, 0)
+	src.AddItem(&testTransfer{Counterparty: "carol", Amount: 9}, 0)
+
+	totals := totalsByKey(grouped)
+	assert.Len(t, totals, 2)
+	assert.Equal(t, 5, totals["alice"].Sum)
+	assert.Equal(t, 2, totals["alice"].Count)
+	assert.Equal(t, 9, totals["carol"].Sum)
+
+	// Re-fetching the derived store does not double count rows it has already seen
+	assert.NoError(t, grouped.Fetch())
+	assert.Equal(t, 5, totalsByKey(grouped)["alice"].Sum)
+}
+
+func TestGroupByStoreReadsWhileFolding(t *testing.T) {
+	src := newSliceStore("derived-race-src", []*testTransfer{
+		{Counterparty: "alice", Amount: 1},
+	})
+	grouped := NewGroupByStore("derived-race", src, transferTotals())
+	facet := NewFacet[testTotal]("totals", nil, nil, grouped, "test", nil, false)
+	assert.NoError(t, src.Fetch())
+
+	const n = 500
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < n; i++ {
+			src.AddItem(&testTransfer{Counterparty: "alice", Amount: 1}, 0)
+		}
+	}()
+	for reading := true; reading; {
+		select {
+		case <-done:
+			reading = false
+		default:
+		}
+		page, err := facet.GetPage(0, 10, nil, sdk.SortSpec{}, nil)
+		assert.NoError(t, err)
+		for _, row := range page.Items {
+			assert.Equal(t, row.Count, row.Sum)
+		}
+	}
+
+	page, err := facet.GetPage(0, 10, nil, sdk.SortSpec{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []testTotal{{Counterparty: "alice", Count: n + 1, Sum: n + 1}}, page.Items)
+	assert.Equal(t, 1, grouped.Count())
+}
+
+func TestJoinStoreMatchesBothSides(t *testing.T) {
+	txs := newSliceStore("derived-join-txs", []*testTransfer{
+		{Counterparty: "0x01", Amount: 100},
+		{Counterparty: "0x02", Amount: 200},
+	})
+	receipts := newSliceStore("derived-join-receipts", []*testReceipt{
+		{Hash: "0x02", Status: 1},
+	})
+
+	joined := NewJoinStore("derived-join", txs, receipts, JoinFuncs[testTransfer, testReceipt, testTxWithReceipt]{
+		LeftKey:  func(item *testTransfer) string { return item.Counterparty },
+		RightKey: func(item *testReceipt) string { return item.Hash },
+		Combine: func(l *testTransfer, r *testReceipt) *testTxWithReceipt {
+			return &testTxWithReceipt{Hash: l.Counterparty, Value: l.Amount, Status: r.Status}
+		},
+	})
+
+	assert.NoError(t, txs.Fetch())
+	assert.NoError(t, receipts.Fetch())
+	assert.Equal(t, 1, joined.Count())
+	assert.Equal(t, types.StateLoaded, joined.GetState())
+
+	receipts.AddItem(&testReceipt{Hash: "0x01", Status: 0}, 0)
+
+	rows := joined.GetItems(false)
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Hash < rows[j].Hash })
+	assert.Equal(t, []testTxWithReceipt{
+		{Hash: "0x01", Value: 100, Status: 0},
+		{Hash: "0x02", Value: 200, Status: 1},
+	}, []testTxWithReceipt{*rows[0], *rows[1]})
+}
+
+func TestProjectionStoreFiltersAndMaps(t *testing.T) {
+	src := newSliceStore("derived-proj-src", []*testTransfer{
+		{Counterparty: "alice", Amount: 10},
+		{Counterparty: "bob", Amount: -3},
+	})
+	assert.NoError(t, src.Fetch())
+
+	projected := NewProjectionStore("derived-proj", src, func(item *testTransfer) (*string, bool) {
+		if item.Amount < 0 {
+			return nil, false
+		}
+		label := fmt.Sprintf("%s:%d", item.Counterparty, item.Amount)
+		return &label, true
+	})
+	assert.NoError(t, projected.Fetch())
+
+	rows := projected.GetItems(false)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "alice:10", *rows[0])
+}
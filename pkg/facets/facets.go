@@ -3,6 +3,7 @@ package facets
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -152,14 +153,13 @@ func (r *Facet[T]) FetchFacet() error {
 		done := make(chan error, 1)
 
 		go func() {
-			err := r.store.Fetch()
-			done <- err
+			done <- <-store.GetScheduler().Schedule(r.store.GetContextKey(), r.store.Fetch)
 		}()
 
 		for {
 			select {
 			case err := <-done:
-				if err != nil && err.Error() != "context canceled" {
+				if err != nil && err.Error() != "context canceled" && !errors.Is(err, store.ErrFetchSuperseded) {
 					msgs.EmitError("Fetch failed", err)
 				}
 				return
@@ -184,6 +184,10 @@ func (r *Facet[T]) GetPage(
 	sortSpec sdk.SortSpec,
 	sortFunc func([]T, sdk.SortSpec) error,
 ) (*PageResult[T], error) {
+	if r.store != nil {
+		store.GetScheduler().SetForeground(r.store.GetContextKey())
+	}
+
 	r.mutex.RLock()
 	data := make([]T, len(r.view))
 	for i, ptr := range r.view {
@@ -340,23 +344,50 @@ func (r *Facet[T]) OnStateChanged(state types.StoreState, reason string) {
 		r.expectedCnt = r.store.GetExpectedTotal()
 		r.mutex.RUnlock()
 		r.progress.Tick(currentCount, currentCount)
-		if r.summaryProvider != nil {
-			collectionPayload := types.DataLoadedPayload{
-				CurrentCount:  currentCount,
-				ExpectedTotal: currentCount,
-				State:         types.StateLoaded,
-				Summary:       r.summaryProvider.GetSummary(nil),
-				Timestamp:     time.Now().Unix(),
-				EventPhase:    "complete",
-				Operation:     "load",
-			}
-			collectionPayload.Collection = r.collectionName
-			collectionPayload.DataFacet = r.dataFacet
-			msgs.EmitLoaded(collectionPayload)
-		}
+		r.emitLoaded(currentCount)
+	}
+}
+
+// OnItemReplaced swaps a row the store replaced in the facet's view and, once the facet is
+// loaded, tells the frontend so it refreshes the row
+func (r *Facet[T]) OnItemReplaced(old, item *T) {
+	keep := r.filterFunc == nil || r.filterFunc(item)
+	r.mutex.Lock()
+	index := slices.Index(r.view, old)
+	switch {
+	case index >= 0 && keep:
+		r.view[index] = item
+	case index >= 0:
+		r.view = slices.Delete(r.view, index, index+1)
+	case keep:
+		r.view = append(r.view, item)
+	}
+	currentCount := len(r.view)
+	r.mutex.Unlock()
+
+	if r.GetState() == types.StateLoaded {
+		r.emitLoaded(currentCount)
 	}
 }
 
+func (r *Facet[T]) emitLoaded(currentCount int) {
+	if r.summaryProvider == nil {
+		return
+	}
+	collectionPayload := types.DataLoadedPayload{
+		CurrentCount:  currentCount,
+		ExpectedTotal: currentCount,
+		State:         types.StateLoaded,
+		Summary:       r.summaryProvider.GetSummary(nil),
+		Timestamp:     time.Now().Unix(),
+		EventPhase:    "complete",
+		Operation:     "load",
+	}
+	collectionPayload.Collection = r.collectionName
+	collectionPayload.DataFacet = r.dataFacet
+	msgs.EmitLoaded(collectionPayload)
+}
+
 func (r *Facet[T]) ForEvery(actionFunc func(itemMatched *T) (error, bool), matchFunc func(item *T) bool) (int, error) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
@@ -379,13 +410,21 @@ func (r *Facet[T]) ForEvery(actionFunc func(itemMatched *T) (error, bool), match
 	return matchCount, nil
 }
 
-// ExportData exports all data in this facet to the specified file path and format
-func (r *Facet[T]) ExportData(payload *types.Payload, typeName string) (string, error) {
+// ExportData streams the rows in this facet's view to the payload's export file in chunks,
+// reporting progress as it goes. It returns the file and the number of rows written.
+func (r *Facet[T]) ExportData(payload *types.Payload, typeName string) (string, int, error) {
 	r.mutex.RLock()
-	data := make([]T, len(r.view))
-	for i, ptr := range r.view {
-		data[i] = *ptr
-	}
+	total := len(r.view)
 	r.mutex.RUnlock()
-	return types.ExportData(data, payload, typeName)
+
+	next := func(first, count int) []*T {
+		r.mutex.RLock()
+		defer r.mutex.RUnlock()
+		if first >= len(r.view) {
+			return nil
+		}
+		end := min(first+count, len(r.view))
+		return append([]*T(nil), r.view[first:end]...)
+	}
+	return types.StreamExport(payload, typeName, total, next, msgs.EmitExportProgress)
 }
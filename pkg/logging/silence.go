@@ -2,14 +2,33 @@ package logging
 
 import (
 	"io"
+	"sync"
 
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/logger"
 )
 
+var (
+	silenced     int
+	silencedFrom io.Writer
+	silenceMu    sync.Mutex
+)
+
+// Silence discards chifra's log output until the returned function is called. Calls may
+// overlap, as concurrent fetches do; the original writer comes back when the last one ends.
 func Silence() func() {
-	original := logger.GetLoggerWriter()
-	logger.SetLoggerWriter(io.Discard)
+	silenceMu.Lock()
+	defer silenceMu.Unlock()
+	if silenced == 0 {
+		silencedFrom = logger.GetLoggerWriter()
+		logger.SetLoggerWriter(io.Discard)
+	}
+	silenced++
 	return func() {
-		logger.SetLoggerWriter(original) // Restore original state
+		silenceMu.Lock()
+		defer silenceMu.Unlock()
+		silenced--
+		if silenced == 0 {
+			logger.SetLoggerWriter(silencedFrom) // Restore original state
+		}
 	}
 }
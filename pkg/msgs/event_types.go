@@ -20,6 +20,7 @@ const (
 	EventFacetChanged    EventType = "facet:changed"
 	EventProjectClosed   EventType = "project:closed"
 	EventProjectSwitched EventType = "project:switched"
+	EventExportProgress  EventType = "export:progress"
 )
 
 var AllMessages = []struct {
@@ -43,4 +44,5 @@ var AllMessages = []struct {
 	{EventFacetChanged, "FACET_CHANGED"},
 	{EventProjectClosed, "PROJECT_CLOSED"},
 	{EventProjectSwitched, "PROJECT_SWITCHED"},
+	{EventExportProgress, "EXPORT_PROGRESS"},
 }
@@ -189,6 +189,11 @@ func EmitProjectModal(msgText string, payload ...interface{}) {
 	emitMessage(EventProjectModal, msgText, payload...)
 }
 
+// EmitExportProgress reports how far a running export has got and, when Done is set, how it ended.
+func EmitExportProgress(payload types.ExportProgressPayload) {
+	emitMessage(EventExportProgress, payload.Collection, payload)
+}
+
 // EmitRowAction signals a row action with complete row data.
 func EmitRowAction(payload *types.RowActionPayload) {
 	emitMessage(EventRowAction, "row-action", *payload)
@@ -48,6 +48,7 @@ type AppPreferences struct {
 	LastTheme       string            `json:"lastTheme"`
 	LastSkin        string            `json:"lastSkin"`
 	LastFormat      string            `json:"lastFormat"`
+	LastCompress    bool              `json:"lastCompress,omitempty"`
 	LastLanguage    string            `json:"lastLanguage"`
 	LastProjects    []OpenProject     `json:"lastProjects"`
 	HelpCollapsed   bool              `json:"helpCollapsed"`
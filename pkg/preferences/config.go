@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"runtime/debug"
 	"strings"
 
 	"golang.org/x/text/cases"
@@ -106,6 +107,21 @@ func GetAppId() Id {
 	}
 }
 
+// AppVersion is set at build time with
+// -ldflags "-X github.com/TrueBlocks/trueblocks-approvals/pkg/preferences.AppVersion=v1.2.3"
+var AppVersion string
+
+// GetAppVersion returns the version the app was built as, falling back to the module version
+func GetAppVersion() string {
+	if AppVersion != "" {
+		return AppVersion
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "unknown"
+}
+
 const (
 	configOrgName    = "TrueBlocks"
 	configBaseApp    = "Approvals"
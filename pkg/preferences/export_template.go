@@ -0,0 +1,60 @@
+package preferences
+
+import "fmt"
+
+// ExportTemplate is a named column set and order for exports of one facet. Templates live in
+// a project or, to share them, in the organization preferences.
+type ExportTemplate struct {
+	Name       string   `json:"name"`
+	Collection string   `json:"collection"`
+	DataFacet  string   `json:"dataFacet"`
+	Columns    []string `json:"columns"`
+}
+
+// Validate reports a template that names no facet or no columns
+func (t *ExportTemplate) Validate() error {
+	if t.Name == "" || t.Collection == "" || t.DataFacet == "" {
+		return fmt.Errorf("export template needs a name, collection and facet")
+	}
+	if len(t.Columns) == 0 {
+		return fmt.Errorf("export template %s has no columns", t.Name)
+	}
+	return nil
+}
+
+// Matches returns true if the template is the named one for the facet
+func (t *ExportTemplate) Matches(name, collection, dataFacet string) bool {
+	return t.Name == name && t.Collection == collection && t.DataFacet == dataFacet
+}
+
+// FindExportTemplate returns the named template for a facet
+func FindExportTemplate(templates []ExportTemplate, name, collection, dataFacet string) (ExportTemplate, bool) {
+	for _, t := range templates {
+		if t.Matches(name, collection, dataFacet) {
+			return t, true
+		}
+	}
+	return ExportTemplate{}, false
+}
+
+// PutExportTemplate returns the templates with t added or replacing the one of the same name
+func PutExportTemplate(templates []ExportTemplate, t ExportTemplate) []ExportTemplate {
+	for i := range templates {
+		if templates[i].Matches(t.Name, t.Collection, t.DataFacet) {
+			templates[i] = t
+			return templates
+		}
+	}
+	return append(templates, t)
+}
+
+// DeleteExportTemplate returns the templates without the named one
+func DeleteExportTemplate(templates []ExportTemplate, name, collection, dataFacet string) []ExportTemplate {
+	ret := []ExportTemplate{}
+	for _, t := range templates {
+		if !t.Matches(name, collection, dataFacet) {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
@@ -9,14 +9,15 @@ import (
 )
 
 type OrgPreferences struct {
-	Version       string `json:"version,omitempty"`
-	Telemetry     bool   `json:"telemetry,omitempty"`
-	Theme         string `json:"theme,omitempty"`
-	Language      string `json:"language,omitempty"`
-	DeveloperName string `json:"developerName,omitempty"`
-	LogLevel      string `json:"logLevel,omitempty"`
-	Experimental  bool   `json:"experimental,omitempty"`
-	SupportURL    string `json:"supportUrl,omitempty"`
+	Version         string           `json:"version,omitempty"`
+	Telemetry       bool             `json:"telemetry,omitempty"`
+	Theme           string           `json:"theme,omitempty"`
+	Language        string           `json:"language,omitempty"`
+	DeveloperName   string           `json:"developerName,omitempty"`
+	LogLevel        string           `json:"logLevel,omitempty"`
+	Experimental    bool             `json:"experimental,omitempty"`
+	SupportURL      string           `json:"supportUrl,omitempty"`
+	ExportTemplates []ExportTemplate `json:"exportTemplates,omitempty"` // shared by all projects
 }
 
 func (o *OrgPreferences) String() string {
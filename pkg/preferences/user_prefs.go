@@ -9,10 +9,19 @@ import (
 )
 
 type UserPreferences struct {
-	Version string  `json:"version,omitempty"`
-	Name    string  `json:"name,omitempty"`
-	Email   string  `json:"email,omitempty"`
-	Chains  []Chain `json:"chains,omitempty"`
+	Version         string            `json:"version,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	Email           string            `json:"email,omitempty"`
+	Chains          []Chain           `json:"chains,omitempty"`
+	Timezone        string            `json:"timezone,omitempty"`
+	WeekStart       int               `json:"weekStart,omitempty"`
+	FiscalYearStart int               `json:"fiscalYearStart,omitempty"`
+	FiatCurrency    string            `json:"fiatCurrency,omitempty"`
+	PriceSource     string            `json:"priceSource,omitempty"` // "", "file", or "oracle"
+	PriceFile       string            `json:"priceFile,omitempty"`
+	PriceFeeds      map[string]string `json:"priceFeeds,omitempty"`    // token address to oracle feed address
+	ProviderKeys    map[string]string `json:"providerKeys,omitempty"`  // comparitoor source to API key
+	ProviderFiles   map[string]string `json:"providerFiles,omitempty"` // comparitoor source to a CSV file read in its place
 }
 
 func NewUserPreferences() *UserPreferences {
@@ -0,0 +1,194 @@
+package pricing
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// pricePoint is one row of a price history
+type pricePoint struct {
+	Timestamp int64
+	Price     float64
+}
+
+// FileSource serves prices from a local CSV or JSON price history. Each row names an
+// asset by address or symbol, a time as a unix timestamp or YYYY-MM-DD date, and a price.
+// A request is priced at the latest row at or before its timestamp.
+type FileSource struct {
+	name     string
+	currency string
+	history  map[string][]pricePoint
+}
+
+// fileRow is the JSON form of a price history row
+type fileRow struct {
+	Timestamp int64           `json:"timestamp"`
+	Date      string          `json:"date"`
+	Asset     string          `json:"asset"`
+	Symbol    string          `json:"symbol"`
+	Price     json.RawMessage `json:"price"`
+}
+
+// NewFileSource loads a price history. Files ending in .json are read as a JSON array of
+// rows; anything else is read as CSV with a header row.
+func NewFileSource(path, fiat string) (*FileSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	src := &FileSource{
+		name:     "file:" + filepath.Base(path),
+		currency: strings.ToUpper(fiat),
+		history:  make(map[string][]pricePoint),
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = src.readJSON(f)
+	} else {
+		err = src.readCSV(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading price file %s: %w", path, err)
+	}
+
+	for key := range src.history {
+		points := src.history[key]
+		sort.Slice(points, func(i, j int) bool { return points[i].Timestamp < points[j].Timestamp })
+	}
+	return src, nil
+}
+
+func (s *FileSource) Name() string {
+	return s.name
+}
+
+func (s *FileSource) Quote(req Request) (Quote, error) {
+	points := s.history[assetKey(req.Asset.Hex())]
+	if len(points) == 0 && req.Symbol != "" {
+		points = s.history[symbolKey(req.Symbol)]
+	}
+	i := sort.Search(len(points), func(i int) bool { return points[i].Timestamp > req.Timestamp })
+	if i == 0 {
+		return Quote{}, ErrNoPrice
+	}
+	p := points[i-1]
+	return Quote{Price: p.Price, Currency: s.currency, Source: s.name, Timestamp: p.Timestamp}, nil
+}
+
+func (s *FileSource) add(ts int64, asset, symbol string, price float64) {
+	if asset != "" {
+		key := assetKey(asset)
+		s.history[key] = append(s.history[key], pricePoint{Timestamp: ts, Price: price})
+	}
+	if symbol != "" {
+		key := symbolKey(symbol)
+		s.history[key] = append(s.history[key], pricePoint{Timestamp: ts, Price: price})
+	}
+}
+
+func (s *FileSource) readCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return err
+	}
+	cols := make(map[string]int)
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := cols["price"]; !ok {
+		return fmt.Errorf("missing price column")
+	}
+	_, hasAsset := cols["asset"]
+	_, hasSymbol := cols["symbol"]
+	if !hasAsset && !hasSymbol {
+		return fmt.Errorf("missing asset or symbol column")
+	}
+
+	get := func(rec []string, col string) string {
+		if i, ok := cols[col]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	for line := 2; ; line++ {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ts, err := parseTime(get(rec, "timestamp"), get(rec, "date"))
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		price, err := strconv.ParseFloat(get(rec, "price"), 64)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid price: %w", line, err)
+		}
+		s.add(ts, get(rec, "asset"), get(rec, "symbol"), price)
+	}
+}
+
+func (s *FileSource) readJSON(r io.Reader) error {
+	var rows []fileRow
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return err
+	}
+	for i, row := range rows {
+		ts, err := row.Timestamp, error(nil)
+		if ts == 0 {
+			ts, err = parseTime("", row.Date)
+		}
+		if err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+		price, err := strconv.ParseFloat(strings.Trim(string(row.Price), `"`), 64)
+		if err != nil {
+			return fmt.Errorf("row %d: invalid price: %w", i, err)
+		}
+		if row.Asset == "" && row.Symbol == "" {
+			return fmt.Errorf("row %d: missing asset or symbol", i)
+		}
+		s.add(ts, row.Asset, row.Symbol, price)
+	}
+	return nil
+}
+
+// parseTime reads a unix timestamp, falling back to a UTC date
+func parseTime(timestamp, date string) (int64, error) {
+	if timestamp != "" {
+		return strconv.ParseInt(timestamp, 10, 64)
+	}
+	if date == "" {
+		return 0, fmt.Errorf("missing timestamp or date")
+	}
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date %q: %w", date, err)
+	}
+	return t.Unix(), nil
+}
+
+func assetKey(addr string) string {
+	a := base.HexToAddress(addr)
+	return "a:" + a.Hex()
+}
+
+func symbolKey(symbol string) string {
+	return "s:" + strings.ToUpper(symbol)
+}
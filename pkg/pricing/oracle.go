@@ -0,0 +1,135 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	"golang.org/x/sync/singleflight"
+)
+
+// OracleSource reads prices from on-chain Chainlink-style aggregators, one feed per asset,
+// at the block of the request
+type OracleSource struct {
+	Feeds    map[base.Address]base.Address
+	Decimals int // decimals of the feed's answer; 8 for USD feeds
+	Currency string
+}
+
+// NewOracleSource builds an oracle source from asset to feed address pairs
+func NewOracleSource(feeds map[string]string, fiat string) *OracleSource {
+	src := &OracleSource{
+		Feeds:    make(map[base.Address]base.Address, len(feeds)),
+		Decimals: 8,
+		Currency: strings.ToUpper(fiat),
+	}
+	for asset, feed := range feeds {
+		src.Feeds[base.HexToAddress(asset)] = base.HexToAddress(feed)
+	}
+	return src
+}
+
+func (s *OracleSource) Name() string {
+	return "oracle"
+}
+
+func (s *OracleSource) Quote(req Request) (Quote, error) {
+	feed, ok := s.Feeds[req.Asset]
+	if !ok {
+		return Quote{}, ErrNoPrice
+	}
+	answer, err := callOracle(req.Chain, feed, req.BlockNumber)
+	if err != nil {
+		return Quote{}, err
+	}
+	if answer.Sign() <= 0 {
+		return Quote{}, ErrNoPrice
+	}
+	price := base.ToFloatWithDecimals((*base.Wei)(answer), s.Decimals).Float64()
+	return Quote{Price: price, Currency: s.Currency, Source: s.Name(), Timestamp: req.Timestamp}, nil
+}
+
+// callOracle returns a feed's latestAnswer() at a block
+var callOracle = func(chain string, feed base.Address, block base.Blknum) (*big.Int, error) {
+	opts := sdk.StateOptions{
+		Globals:  sdk.Globals{Cache: true, Chain: chain},
+		Addrs:    []string{feed.Hex()},
+		BlockIds: []string{fmt.Sprintf("%d", block)},
+		Calldata: "latestAnswer()",
+	}
+	results, _, err := opts.StateCall()
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, ErrNoPrice
+	}
+
+	raw := results[0].ReturnedBytes
+	if raw == "" {
+		for _, v := range results[0].Values {
+			raw = v
+			break
+		}
+	}
+	// Raw return data is hex; articulated values are decimal
+	answer, ok := new(big.Int).SetString(raw, 10)
+	if hex, isHex := strings.CutPrefix(raw, "0x"); isHex {
+		answer, ok = new(big.Int).SetString(hex, 16)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unreadable oracle answer %q", raw)
+	}
+	return answer, nil
+}
+
+// CachedSource remembers quotes, and assets with no price, for each asset and time window so
+// slow sources are asked at most once per window. Other errors are not remembered.
+type CachedSource struct {
+	Source
+	window int64
+	cache  map[string]cachedQuote
+	mutex  sync.Mutex
+	group  singleflight.Group
+}
+
+type cachedQuote struct {
+	quote Quote
+	err   error
+}
+
+// NewCachedSource wraps a source with a cache whose windows are the given number of seconds
+func NewCachedSource(src Source, window int64) *CachedSource {
+	if window <= 0 {
+		window = 86400
+	}
+	return &CachedSource{Source: src, window: window, cache: make(map[string]cachedQuote)}
+}
+
+func (s *CachedSource) Quote(req Request) (Quote, error) {
+	key := fmt.Sprintf("%s_%s_%d", req.Chain, req.Asset.Hex(), req.Timestamp/s.window)
+	s.mutex.Lock()
+	hit, ok := s.cache[key]
+	s.mutex.Unlock()
+	if ok {
+		return hit.quote, hit.err
+	}
+
+	// The lock is not held while the source is asked, so quotes for other keys are not
+	// held up; concurrent requests for this key share one fetch
+	v, _, _ := s.group.Do(key, func() (any, error) {
+		q, err := s.Source.Quote(req)
+		if err == nil || errors.Is(err, ErrNoPrice) {
+			s.mutex.Lock()
+			s.cache[key] = cachedQuote{quote: q, err: err}
+			s.mutex.Unlock()
+		}
+		return cachedQuote{quote: q, err: err}, nil
+	})
+	ret := v.(cachedQuote)
+	return ret.quote, ret.err
+}
@@ -0,0 +1,129 @@
+package pricing
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// ErrNoPrice is returned by a Source that has no price for the requested asset and time
+var ErrNoPrice = errors.New("no price available")
+
+// Request identifies the asset and moment to price. File sources use the timestamp and
+// on-chain sources use the block number.
+type Request struct {
+	Chain       string
+	Asset       base.Address
+	Symbol      string
+	BlockNumber base.Blknum
+	Timestamp   int64
+}
+
+// Quote is the price of one whole token in the source's fiat currency
+type Quote struct {
+	Price     float64 `json:"price"`
+	Currency  string  `json:"currency"`
+	Source    string  `json:"source"`
+	Timestamp int64   `json:"timestamp"`
+}
+
+// Source supplies historical prices
+type Source interface {
+	Name() string
+	Quote(req Request) (Quote, error)
+}
+
+// Valuation is the fiat value of a row's token amount
+type Valuation struct {
+	Currency string  `json:"currency,omitempty"`
+	Price    float64 `json:"price"`
+	Value    float64 `json:"value"`
+	Source   string  `json:"source,omitempty"`
+	Priced   bool    `json:"priced"`
+}
+
+const DefaultCurrency = "USD"
+
+var (
+	active     Source
+	currency   = DefaultCurrency
+	generation uint64
+	activeMu   sync.RWMutex
+)
+
+// Configure sets the process-wide price source. A nil source turns valuation off.
+func Configure(src Source, fiat string) {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	active = src
+	generation++
+	currency = strings.ToUpper(fiat)
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+}
+
+// Enabled reports whether a price source is configured
+func Enabled() bool {
+	activeMu.RLock()
+	defer activeMu.RUnlock()
+	return active != nil
+}
+
+// Generation changes each time the source is configured so that cached values can be dropped
+func Generation() uint64 {
+	activeMu.RLock()
+	defer activeMu.RUnlock()
+	return generation
+}
+
+// Currency returns the configured fiat currency
+func Currency() string {
+	activeMu.RLock()
+	defer activeMu.RUnlock()
+	return currency
+}
+
+// Lookup prices a request with the configured source, returning false if it cannot
+func Lookup(req Request) (Quote, bool) {
+	activeMu.RLock()
+	src, fiat := active, currency
+	activeMu.RUnlock()
+	if src == nil {
+		return Quote{}, false
+	}
+	q, err := src.Quote(req)
+	if err != nil {
+		return Quote{}, false
+	}
+	if q.Currency == "" {
+		q.Currency = fiat
+	}
+	return q, true
+}
+
+// Value converts a token amount in base units to fiat at the quoted price
+func Value(amount *base.Wei, decimals int, q Quote) float64 {
+	if amount == nil {
+		return 0
+	}
+	units := base.ToFloatWithDecimals(amount, decimals).Float64()
+	return units * q.Price
+}
+
+// Valuate prices an amount, returning an unpriced valuation if no quote is available
+func Valuate(req Request, amount *base.Wei, decimals int) Valuation {
+	q, ok := Lookup(req)
+	if !ok {
+		return Valuation{Currency: Currency()}
+	}
+	return Valuation{
+		Currency: q.Currency,
+		Price:    q.Price,
+		Value:    Value(amount, decimals, q),
+		Source:   q.Source,
+		Priced:   true,
+	}
+}
@@ -0,0 +1,196 @@
+package pricing
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+const dai = "0x6b175474e89094c44da98b954eedeac495271d0f"
+
+func writeFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func day(d int) int64 {
+	return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC).Unix()
+}
+
+func TestFileSourceCSV(t *testing.T) {
+	path := writeFile(t, "prices.csv", "date,asset,symbol,price\n"+
+		"2024-01-03,,ETH,2300\n"+
+		"2024-01-01,,ETH,2200.5\n"+
+		"2024-01-01,"+dai+",DAI,1.001\n")
+	src, err := NewFileSource(path, "usd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name  string
+		req   Request
+		price float64
+		ok    bool
+	}{
+		{"before history", Request{Symbol: "ETH", Timestamp: day(1) - 1}, 0, false},
+		{"on first day", Request{Symbol: "eth", Timestamp: day(1)}, 2200.5, true},
+		{"carries forward", Request{Symbol: "ETH", Timestamp: day(2) + 3600}, 2200.5, true},
+		{"later row", Request{Symbol: "ETH", Timestamp: day(5)}, 2300, true},
+		{"by address", Request{Asset: base.HexToAddress(dai), Timestamp: day(2)}, 1.001, true},
+		{"unknown asset", Request{Symbol: "XYZ", Timestamp: day(2)}, 0, false},
+	}
+	for _, tc := range testCases {
+		q, err := src.Quote(tc.req)
+		if (err == nil) != tc.ok || q.Price != tc.price {
+			t.Errorf("%s: got price %v err %v", tc.name, q.Price, err)
+		}
+		if err == nil && (q.Currency != "USD" || q.Source != "file:prices.csv") {
+			t.Errorf("%s: unexpected quote %+v", tc.name, q)
+		}
+	}
+}
+
+func TestFileSourceJSON(t *testing.T) {
+	path := writeFile(t, "prices.json", `[
+		{"timestamp": 1704067200, "symbol": "ETH", "price": 2200},
+		{"date": "2024-01-02", "asset": "`+dai+`", "price": "0.999"}
+	]`)
+	src, err := NewFileSource(path, "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q, err := src.Quote(Request{Symbol: "ETH", Timestamp: day(3)}); err != nil || q.Price != 2200 {
+		t.Errorf("unexpected ETH quote %+v %v", q, err)
+	}
+	if q, err := src.Quote(Request{Asset: base.HexToAddress(dai), Timestamp: day(2)}); err != nil || q.Price != 0.999 {
+		t.Errorf("unexpected DAI quote %+v %v", q, err)
+	}
+
+	if _, err := NewFileSource(writeFile(t, "bad.csv", "date,symbol\n2024-01-01,ETH\n"), "USD"); err == nil {
+		t.Error("expected an error for a file without a price column")
+	}
+}
+
+func TestOracleSourceIsCached(t *testing.T) {
+	saved := callOracle
+	defer func() { callOracle = saved }()
+
+	calls := 0
+	callOracle = func(chain string, feed base.Address, block base.Blknum) (*big.Int, error) {
+		calls++
+		return big.NewInt(230012345678), nil // 2300.12345678 with 8 decimals
+	}
+
+	src := NewCachedSource(NewOracleSource(map[string]string{dai: "0x5f4ec3df9cbd43714fe2740f5e3616155c5b8419"}, "USD"), 0)
+	req := Request{Chain: "mainnet", Asset: base.HexToAddress(dai), BlockNumber: 100, Timestamp: day(1) + 10}
+	for i := 0; i < 3; i++ {
+		q, err := src.Quote(req)
+		if err != nil || q.Price != 2300.12345678 {
+			t.Fatalf("unexpected quote %+v %v", q, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected one oracle call for the day, got %d", calls)
+	}
+
+	if _, err := src.Quote(Request{Asset: base.HexToAddress("0x01"), Timestamp: day(1)}); err != ErrNoPrice {
+		t.Errorf("expected ErrNoPrice for an asset without a feed, got %v", err)
+	}
+
+	calls = 0
+	callOracle = func(chain string, feed base.Address, block base.Blknum) (*big.Int, error) {
+		calls++
+		return nil, errors.New("connection refused")
+	}
+	req.Timestamp = day(2)
+	for i := 0; i < 2; i++ {
+		if _, err := src.Quote(req); err == nil {
+			t.Fatal("expected the oracle's error")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected failed calls to be retried, got %d calls", calls)
+	}
+}
+
+// blockingSource holds quotes for the blocked asset until release is closed
+type blockingSource struct {
+	blocked base.Address
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (s *blockingSource) Name() string { return "blocking" }
+
+func (s *blockingSource) Quote(req Request) (Quote, error) {
+	s.calls.Add(1)
+	if req.Asset == s.blocked {
+		<-s.release
+	}
+	return Quote{Price: 1}, nil
+}
+
+func TestCachedSourceDoesNotSerializeFetches(t *testing.T) {
+	slow := base.HexToAddress(dai)
+	src := &blockingSource{blocked: slow, release: make(chan struct{})}
+	cached := NewCachedSource(src, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = cached.Quote(Request{Asset: slow, Timestamp: day(1)})
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = cached.Quote(Request{Asset: base.HexToAddress("0x01"), Timestamp: day(1)})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("a quote for another asset waited on the slow fetch")
+	}
+
+	close(src.release)
+	wg.Wait()
+	if calls := src.calls.Load(); calls != 2 {
+		t.Errorf("expected concurrent quotes for one key to share fetches, got %d calls", calls)
+	}
+}
+
+func TestValuate(t *testing.T) {
+	defer Configure(nil, "")
+
+	amount := base.NewWei(0)
+	amount.SetString("1500000000000000000", 10)
+	if v := Valuate(Request{Symbol: "ETH"}, amount, 18); v.Priced {
+		t.Error("expected no valuation without a source")
+	}
+
+	path := writeFile(t, "prices.csv", "timestamp,symbol,price\n0,ETH,2000\n")
+	src, err := NewFileSource(path, "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	Configure(src, "usd")
+	v := Valuate(Request{Symbol: "ETH", Timestamp: day(1)}, amount, 18)
+	if !v.Priced || v.Value != 3000 || v.Currency != "USD" {
+		t.Errorf("unexpected valuation %+v", v)
+	}
+}
@@ -0,0 +1,62 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/preferences"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+)
+
+// ------------------------------------------------------------------------------------
+// GetExportTemplates returns the project's export templates
+func (p *Project) GetExportTemplates() []preferences.ExportTemplate {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]preferences.ExportTemplate{}, p.ExportTemplates...)
+}
+
+// ------------------------------------------------------------------------------------
+// SetExportTemplate adds an export template or replaces the one with the same name and facet
+func (p *Project) SetExportTemplate(template preferences.ExportTemplate) error {
+	if err := template.Validate(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ExportTemplates = preferences.PutExportTemplate(p.ExportTemplates, template)
+	return p.Save()
+}
+
+// ------------------------------------------------------------------------------------
+// RemoveExportTemplate removes the named export template of a facet
+func (p *Project) RemoveExportTemplate(name, collection string, facet types.DataFacet) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ExportTemplates = preferences.DeleteExportTemplate(p.ExportTemplates, name, collection, string(facet))
+	return p.Save()
+}
+
+// ------------------------------------------------------------------------------------
+// ExportColumns returns the columns an export of the payload's facet is limited to. A named
+// template is looked up in the project first and then in the shared templates. Without one
+// the payload's own columns are used, then the facet's visible columns. None means all.
+// Export profiles write their own columns, so a payload with a profile gets none.
+func (p *Project) ExportColumns(payload *types.Payload, shared []preferences.ExportTemplate) ([]string, error) {
+	if types.SelectsExportProfile(payload) {
+		return nil, nil
+	}
+	facet := string(payload.DataFacet)
+	if payload.Template != "" {
+		for _, templates := range [][]preferences.ExportTemplate{p.GetExportTemplates(), shared} {
+			if t, ok := preferences.FindExportTemplate(templates, payload.Template, payload.Collection, facet); ok {
+				return t.Columns, nil
+			}
+		}
+		return nil, fmt.Errorf("no export template %s for %s %s", payload.Template, payload.Collection, facet)
+	}
+	if len(payload.Columns) > 0 {
+		return payload.Columns, nil
+	}
+	state, _ := p.GetViewFacetState(ViewStateKey{ViewName: payload.Collection, FacetName: payload.DataFacet})
+	return state.Columns, nil
+}
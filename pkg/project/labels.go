@@ -0,0 +1,78 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// ------------------------------------------------------------------------------------
+// Label is a private name for an address that is kept in the project file. It overrides
+// the names database while the project is active and is never written to it.
+type Label struct {
+	Address base.Address `json:"address"`
+	Name    string       `json:"name"`
+	Tags    string       `json:"tags,omitempty"`
+}
+
+// ------------------------------------------------------------------------------------
+// GetLabels returns the project's labels
+func (p *Project) GetLabels() []Label {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]Label{}, p.Labels...)
+}
+
+// ------------------------------------------------------------------------------------
+// GetLabel returns the project's label for an address, if it has one
+func (p *Project) GetLabel(address base.Address) (Label, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if i := p.findLabel(address); i >= 0 {
+		return p.Labels[i], true
+	}
+	return Label{}, false
+}
+
+// ------------------------------------------------------------------------------------
+// PutLabel adds a label to the project or replaces the one for the same address
+func (p *Project) PutLabel(label Label) error {
+	label.Name = strings.TrimSpace(label.Name)
+	label.Tags = strings.TrimSpace(label.Tags)
+	if label.Address.IsZero() {
+		return fmt.Errorf("a label needs an address")
+	}
+	if label.Name == "" {
+		return fmt.Errorf("a label needs a name")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i := p.findLabel(label.Address); i >= 0 {
+		p.Labels[i] = label
+	} else {
+		p.Labels = append(p.Labels, label)
+	}
+	return p.Save()
+}
+
+// ------------------------------------------------------------------------------------
+// RemoveLabel removes the project's label for an address
+func (p *Project) RemoveLabel(address base.Address) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i := p.findLabel(address); i >= 0 {
+		p.Labels = append(p.Labels[:i], p.Labels[i+1:]...)
+		return p.Save()
+	}
+	return fmt.Errorf("no label for %s in project", address.Hex())
+}
+
+func (p *Project) findLabel(address base.Address) int {
+	for i, l := range p.Labels {
+		if l.Address == address {
+			return i
+		}
+	}
+	return -1
+}
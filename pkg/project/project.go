@@ -15,6 +15,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/file"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/filewriter"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/preferences"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
 )
 
@@ -31,13 +32,37 @@ type Project struct {
 	ActiveAddress   base.Address                    `json:"activeAddress"`
 	Chains          []string                        `json:"chains"`
 	ActiveChain     string                          `json:"activeChain"`
-	Contracts       []string                        `json:"contracts"`
+	Contracts       []Contract                      `json:"contracts"`
 	ActiveContract  string                          `json:"activeContract"`
 	ActivePeriod    types.Period                    `json:"activePeriod"`
+	PeriodConfig    *types.PeriodConfig             `json:"periodConfig,omitempty"`
 	ViewFacetStates map[ViewStateKey]ViewFacetState `json:"viewFacetStates"`
+	ExportTemplates []preferences.ExportTemplate    `json:"exportTemplates,omitempty"`
+	Labels          []Label                         `json:"labels,omitempty"`
 	Path            string                          `json:"-"`
 }
 
+// ------------------------------------------------------------------------------------
+// Contract is one of the project's own contracts on a chain
+type Contract struct {
+	Address       base.Address `json:"address"`
+	Chain         string       `json:"chain"`
+	Name          string       `json:"name,omitempty"`
+	DeployedBlock base.Blknum  `json:"deployedBlock,omitempty"`
+}
+
+// ------------------------------------------------------------------------------------
+// UnmarshalJSON also reads the bare addresses older project files stored
+func (c *Contract) UnmarshalJSON(data []byte) error {
+	var addr string
+	if err := json.Unmarshal(data, &addr); err == nil {
+		*c = Contract{Address: base.HexToAddress(addr)}
+		return nil
+	}
+	type Alias Contract
+	return json.Unmarshal(data, (*Alias)(c))
+}
+
 // ------------------------------------------------------------------------------------
 // NewProject creates a new project with default values and required active address
 func NewProject(name string, activeAddress base.Address, chains []string) *Project {
@@ -56,7 +81,7 @@ func NewProject(name string, activeAddress base.Address, chains []string) *Proje
 		ActiveChain:     chains[0],
 		Chains:          chains,
 		ActiveContract:  "",
-		Contracts:       []string{},
+		Contracts:       []Contract{},
 		ActivePeriod:    "blockly",
 		ViewFacetStates: make(map[ViewStateKey]ViewFacetState),
 	}
@@ -87,6 +112,12 @@ func (p *Project) UnmarshalJSON(data []byte) error {
 		p.ViewFacetStates = aux.OldFilterStates
 	}
 
+	for i := range p.Contracts {
+		if p.Contracts[i].Chain == "" {
+			p.Contracts[i].Chain = p.ActiveChain
+		}
+	}
+
 	if p.Version == "" || p.Version == "1.0" {
 		p.Version = "v6.5.1" // TODO: Why is this hard coded?
 	}
@@ -286,20 +317,28 @@ func (p *Project) SetActiveChain(chain string) error {
 }
 
 // ------------------------------------------------------------------------------------
-// GetContracts returns all contracts in the project
-func (p *Project) GetContracts() []string {
-	return p.Contracts
+// GetContracts returns the project's contracts on the given chain
+func (p *Project) GetContracts(chain string) []Contract {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	ret := []Contract{}
+	for _, c := range p.Contracts {
+		if c.Chain == chain {
+			ret = append(ret, c)
+		}
+	}
+	return ret
 }
 
 // ------------------------------------------------------------------------------------
 // GetActiveContract returns the currently selected contract
 func (p *Project) GetActiveContract() string {
-	// TODO: BOGUS Temporary hardcode - replace with dynamic selection
-	return "0x0c316b7042b419d07d343f2f4f5bd54ff731183d"
+	return p.ActiveContract
 }
 
 // ------------------------------------------------------------------------------------
-// SetActiveContract sets the currently selected contract (must be in project or empty)
+// SetActiveContract sets the currently selected contract, adding it to the project on
+// the active chain if it is not there yet
 func (p *Project) SetActiveContract(contract string) error {
 	if contract == "" {
 		if p.ActiveContract != contract {
@@ -309,17 +348,10 @@ func (p *Project) SetActiveContract(contract string) error {
 		return nil
 	}
 
-	found := false
-	for _, existingContract := range p.Contracts {
-		if existingContract == contract {
-			found = true
-			break
-		}
-	}
-
 	needsSave := false
-	if !found {
-		p.Contracts = append(p.Contracts, contract)
+	p.mu.Lock()
+	if p.findContract(p.ActiveChain, base.HexToAddress(contract)) < 0 {
+		p.Contracts = append(p.Contracts, Contract{Address: base.HexToAddress(contract), Chain: p.ActiveChain})
 		needsSave = true
 	}
 
@@ -327,6 +359,7 @@ func (p *Project) SetActiveContract(contract string) error {
 		p.ActiveContract = contract
 		needsSave = true
 	}
+	p.mu.Unlock()
 
 	if needsSave {
 		if err := p.Save(); err != nil {
@@ -344,20 +377,46 @@ func (p *Project) AddContract(contract string) error {
 }
 
 // ------------------------------------------------------------------------------------
-// RemoveContract removes a contract from the project
-func (p *Project) RemoveContract(contract string) error {
-	for i, existingContract := range p.Contracts {
-		if existingContract == contract {
-			p.Contracts = append(p.Contracts[:i], p.Contracts[i+1:]...)
-			if p.ActiveContract == contract {
-				p.ActiveContract = ""
-			}
-			return p.Save()
+// PutContract adds a contract to the project or replaces the one with the same chain and
+// address
+func (p *Project) PutContract(contract Contract) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if contract.Chain == "" {
+		contract.Chain = p.ActiveChain
+	}
+	if i := p.findContract(contract.Chain, contract.Address); i >= 0 {
+		p.Contracts[i] = contract
+	} else {
+		p.Contracts = append(p.Contracts, contract)
+	}
+	return p.Save()
+}
+
+// ------------------------------------------------------------------------------------
+// RemoveContract removes a contract on the given chain from the project
+func (p *Project) RemoveContract(chain, contract string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i := p.findContract(chain, base.HexToAddress(contract)); i >= 0 {
+		p.Contracts = append(p.Contracts[:i], p.Contracts[i+1:]...)
+		if strings.EqualFold(p.ActiveContract, contract) {
+			p.ActiveContract = ""
 		}
+		return p.Save()
 	}
 	return fmt.Errorf("contract %s not found in project", contract)
 }
 
+func (p *Project) findContract(chain string, address base.Address) int {
+	for i, c := range p.Contracts {
+		if c.Chain == chain && c.Address == address {
+			return i
+		}
+	}
+	return -1
+}
+
 // ------------------------------------------------------------------------------------
 // GetActivePeriod returns the currently selected period
 func (p *Project) GetActivePeriod() types.Period {
@@ -377,6 +436,24 @@ func (p *Project) SetActivePeriod(period types.Period) error {
 	return nil
 }
 
+// ------------------------------------------------------------------------------------
+// GetPeriodConfig returns the project's period settings, or nil if it uses the user's
+func (p *Project) GetPeriodConfig() *types.PeriodConfig {
+	return p.PeriodConfig
+}
+
+// ------------------------------------------------------------------------------------
+// SetPeriodConfig overrides the user's period settings for this project; nil clears the override
+func (p *Project) SetPeriodConfig(cfg *types.PeriodConfig) error {
+	if cfg != nil {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+	}
+	p.PeriodConfig = cfg
+	return p.Save()
+}
+
 // ------------------------------------------------------------------------------------
 // GetViewFacetState retrieves view facet state for a given key
 func (p *Project) GetViewFacetState(key ViewStateKey) (ViewFacetState, bool) {
@@ -1,11 +1,16 @@
 package project_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sync"
 	"testing"
 
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/preferences"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/project"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
 
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
 )
@@ -47,3 +52,132 @@ func TestProject(t *testing.T) {
 		t.Errorf("Expected project name '%s', got '%s'", "renamed-project", loadedProject.GetName())
 	}
 }
+
+func TestExportColumns(t *testing.T) {
+	p := project.NewProject("test-project", base.ZeroAddr, []string{"mainnet"})
+	payload := &types.Payload{Collection: "exports", DataFacet: "statements"}
+
+	// No template, payload columns or visible columns means every column
+	if columns, err := p.ExportColumns(payload, nil); err != nil || columns != nil {
+		t.Errorf("expected all columns, got %v %v", columns, err)
+	}
+
+	key := project.ViewStateKey{ViewName: "exports", FacetName: "statements"}
+	_ = p.SetViewFacetState(key, project.ViewFacetState{Columns: []string{"date", "asset"}})
+	if columns, _ := p.ExportColumns(payload, nil); !reflect.DeepEqual(columns, []string{"date", "asset"}) {
+		t.Errorf("expected the visible columns, got %v", columns)
+	}
+
+	shared := []preferences.ExportTemplate{
+		{Name: "quarterly", Collection: "exports", DataFacet: "statements", Columns: []string{"shared"}},
+		{Name: "monthly", Collection: "exports", DataFacet: "statements", Columns: []string{"monthly"}},
+	}
+	if err := p.SetExportTemplate(preferences.ExportTemplate{Name: "quarterly", Collection: "exports", DataFacet: "statements"}); err == nil {
+		t.Error("expected a template without columns to be rejected")
+	}
+	_ = p.SetExportTemplate(preferences.ExportTemplate{Name: "quarterly", Collection: "exports", DataFacet: "statements", Columns: []string{"project"}})
+
+	// The project's template shadows the shared one of the same name
+	payload.Template = "quarterly"
+	if columns, _ := p.ExportColumns(payload, shared); !reflect.DeepEqual(columns, []string{"project"}) {
+		t.Errorf("expected the project's template, got %v", columns)
+	}
+	payload.Template = "monthly"
+	if columns, _ := p.ExportColumns(payload, shared); !reflect.DeepEqual(columns, []string{"monthly"}) {
+		t.Errorf("expected the shared template, got %v", columns)
+	}
+	payload.DataFacet = "balances"
+	if _, err := p.ExportColumns(payload, shared); err == nil {
+		t.Error("expected an error for a template of another facet")
+	}
+
+	// A profile's columns are its own
+	payload.DataFacet, payload.Profile = "statements", "koinly"
+	if columns, err := p.ExportColumns(payload, shared); err != nil || columns != nil {
+		t.Errorf("expected no columns with a profile, got %v %v", columns, err)
+	}
+
+	_ = p.RemoveExportTemplate("quarterly", "exports", "statements")
+	if len(p.GetExportTemplates()) != 0 {
+		t.Errorf("expected the template to be removed, got %v", p.GetExportTemplates())
+	}
+}
+
+func TestProjectContracts(t *testing.T) {
+	data := []byte(`{"name":"old","activeChain":"gnosis","contracts":["0x0c316b7042b419d07d343f2f4f5bd54ff731183d"]}`)
+	var p project.Project
+	if err := p.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	addr := base.HexToAddress("0x0c316b7042b419d07d343f2f4f5bd54ff731183d")
+	if got := p.GetContracts("gnosis"); len(got) != 1 || got[0].Address != addr {
+		t.Fatalf("expected the bare address to load on the active chain, got %+v", got)
+	}
+
+	_ = p.PutContract(project.Contract{Address: addr, Chain: "gnosis", Name: "Registry", DeployedBlock: 100})
+	_ = p.PutContract(project.Contract{Address: addr, Chain: "mainnet"})
+	if got := p.GetContracts("gnosis"); len(got) != 1 || got[0].Name != "Registry" || got[0].DeployedBlock != 100 {
+		t.Errorf("expected the contract to be updated in place, got %+v", got)
+	}
+	if err := p.RemoveContract("mainnet", addr.Hex()); err != nil || len(p.GetContracts("mainnet")) != 0 || len(p.GetContracts("gnosis")) != 1 {
+		t.Errorf("expected only the mainnet contract to be removed: %v", err)
+	}
+}
+
+func TestSetActiveContractConcurrently(t *testing.T) {
+	p := project.NewProject("busy", base.ZeroAddr, []string{"mainnet"})
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_ = p.SetActiveContract(fmt.Sprintf("0x%040x", i))
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = p.GetContracts("mainnet")
+		}()
+	}
+	wg.Wait()
+	if got := p.GetContracts("mainnet"); len(got) != 20 {
+		t.Errorf("expected every contract to be added, got %d", len(got))
+	}
+}
+
+func TestProjectLabels(t *testing.T) {
+	p := project.NewProject("client", base.ZeroAddr, []string{"mainnet"})
+	path := filepath.Join(t.TempDir(), "client.json")
+	if err := p.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+
+	cold := base.HexToAddress("0x054993ab0f2b1acc0fdc65405ee203b4271bebe6")
+	if err := p.PutLabel(project.Label{Address: cold, Name: "  client cold wallet 3 ", Tags: "treasury"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.PutLabel(project.Label{Address: cold, Name: "client cold wallet 3"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.PutLabel(project.Label{Address: cold}); err == nil {
+		t.Error("expected a label without a name to be rejected")
+	}
+	if err := p.PutLabel(project.Label{Name: "nobody"}); err == nil {
+		t.Error("expected a label without an address to be rejected")
+	}
+
+	loaded, err := project.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := project.Label{Address: cold, Name: "client cold wallet 3"}
+	if got, ok := loaded.GetLabel(cold); !ok || got != want || len(loaded.GetLabels()) != 1 {
+		t.Errorf("expected the label to be replaced and saved in the project file, got %+v", loaded.GetLabels())
+	}
+
+	if err := loaded.RemoveLabel(cold); err != nil || len(loaded.GetLabels()) != 0 {
+		t.Errorf("expected the label to be removed: %v", err)
+	}
+	if err := loaded.RemoveLabel(cold); err == nil {
+		t.Error("expected removing a missing label to fail")
+	}
+}
@@ -5,4 +5,5 @@ type ViewFacetState struct {
 	Sorting   map[string]interface{} `json:"sorting,omitempty"`
 	Filtering map[string]interface{} `json:"filtering,omitempty"`
 	Other     map[string]interface{} `json:"other,omitempty"`
+	Columns   []string               `json:"columns,omitempty"` // visible columns in order, empty for all
 }
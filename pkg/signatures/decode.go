@@ -0,0 +1,270 @@
+package signatures
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/abi"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/articulate"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/types"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	ethAbi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// DecodeInput articulates call data by its selector. When more than one signature shares
+// the selector, one whose encoding reproduces the data exactly is preferred over one that
+// only decodes it. It returns nil if none decodes the data.
+func (db *DB) DecodeInput(input string) *sdk.Function {
+	if len(input) < 10 || (len(input)-10)%64 != 0 {
+		return nil
+	}
+	raw, err := hex.DecodeString(input[10:])
+	if err != nil {
+		return nil
+	}
+	var fallback *sdk.Function
+	for _, sig := range db.lookup(db.functions, input[:10]) {
+		fn, err := builtFunction(sig, "function", 0)
+		if err != nil {
+			continue
+		}
+		if err := articulate.ArticulateFunction(fn, input[10:], ""); err != nil {
+			continue
+		}
+		if method, err := fn.GetAbiMethod(); err == nil {
+			if values, err := method.Inputs.Unpack(raw); err == nil {
+				if packed, err := method.Inputs.Pack(values...); err == nil && bytes.Equal(packed, raw) {
+					return fn
+				}
+			}
+		}
+		if fallback == nil {
+			fallback = fn
+		}
+	}
+	return fallback
+}
+
+// DecodeLog articulates a log by its first topic. A text signature does not say which
+// parameters are indexed, so the leading parameters are taken to be, one per topic.
+func (db *DB) DecodeLog(log *sdk.Log) *sdk.Function {
+	if log == nil || len(log.Topics) == 0 {
+		return nil
+	}
+	data := strings.TrimPrefix(log.Data, "0x")
+	if len(data)%64 != 0 {
+		return nil
+	}
+	topic := strings.ToLower(log.Topics[0].Hex())
+	for _, sig := range db.lookup(db.events, topic) {
+		fn, err := builtFunction(sig, "event", len(log.Topics)-1)
+		if err != nil {
+			continue
+		}
+		cache := articulate.AbiCache{AbiMap: abi.SelectorSyncMap{}}
+		cache.AbiMap.SetValue(topic, fn)
+		decoded := *log
+		decoded.ArticulatedLog = nil
+		if err := cache.ArticulateLog(&decoded); err == nil && decoded.ArticulatedLog != nil {
+			return decoded.ArticulatedLog
+		}
+	}
+	return nil
+}
+
+// DecodeInput articulates call data with the process-wide database
+func DecodeInput(input string) *sdk.Function {
+	if db := Active(); db != nil {
+		return db.DecodeInput(input)
+	}
+	return nil
+}
+
+// DecodeLog articulates a log with the process-wide database
+func DecodeLog(log *sdk.Log) *sdk.Function {
+	if db := Active(); db != nil {
+		return db.DecodeLog(log)
+	}
+	return nil
+}
+
+// abiArg is a parameter as it appears in a JSON ABI
+type abiArg struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Indexed    bool     `json:"indexed,omitempty"`
+	Components []abiArg `json:"components,omitempty"`
+}
+
+var (
+	nameRe   = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	suffixRe = regexp.MustCompile(`^(\[[0-9]*\])*$`)
+)
+
+// parseSignature splits a signature such as swap((address,uint256)[],bool) into its name
+// and parameters
+func parseSignature(sig string) (string, []abiArg, error) {
+	sig = strings.TrimSpace(sig)
+	open := strings.Index(sig, "(")
+	if open < 1 || !strings.HasSuffix(sig, ")") || !nameRe.MatchString(sig[:open]) {
+		return "", nil, fmt.Errorf("%q is not a signature", sig)
+	}
+	args, err := parseParams(sig[open+1 : len(sig)-1])
+	return sig[:open], args, err
+}
+
+func parseParams(params string) ([]abiArg, error) {
+	ret := []abiArg{}
+	if strings.TrimSpace(params) == "" {
+		return ret, nil
+	}
+	depth, start := 0, 0
+	var parts []string
+	for i, r := range params {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in %q", params)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, params[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in %q", params)
+	}
+	parts = append(parts, params[start:])
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "(") {
+			close := strings.LastIndex(part, ")")
+			components, err := parseParams(part[1:close])
+			if err != nil {
+				return nil, err
+			}
+			suffix := strings.TrimSpace(part[close+1:])
+			if !suffixRe.MatchString(suffix) {
+				return nil, fmt.Errorf("%q is not a type", part)
+			}
+			ret = append(ret, abiArg{Type: "tuple" + suffix, Components: components})
+			continue
+		}
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("empty parameter in %q", params)
+		}
+		ret = append(ret, abiArg{Type: canonicalType(fields[0])})
+	}
+	return ret, nil
+}
+
+// canonicalType expands the aliases the selector hash does not accept
+func canonicalType(t string) string {
+	for alias, full := range map[string]string{"uint": "uint256", "int": "int256", "byte": "bytes1"} {
+		if t == alias || strings.HasPrefix(t, alias+"[") {
+			return full + t[len(alias):]
+		}
+	}
+	return t
+}
+
+// canonicalSignature is the signature as it is hashed, without spaces, parameter names
+// or type aliases
+func canonicalSignature(sig string) (string, error) {
+	name, args, err := parseSignature(sig)
+	if err != nil {
+		return "", err
+	}
+	return name + "(" + joinTypes(args) + ")", nil
+}
+
+func joinTypes(args []abiArg) string {
+	types := make([]string, 0, len(args))
+	for _, arg := range args {
+		if strings.HasPrefix(arg.Type, "tuple") {
+			types = append(types, "("+joinTypes(arg.Components)+")"+strings.TrimPrefix(arg.Type, "tuple"))
+		} else {
+			types = append(types, arg.Type)
+		}
+	}
+	return strings.Join(types, ",")
+}
+
+// built holds the function or event built for each signature, kind and indexed count so
+// that the ABI is parsed once rather than for every transaction and log decoded
+var built sync.Map
+
+type builtEntry struct {
+	fn  *sdk.Function
+	err error
+}
+
+// builtFunction returns a copy of the function or event for a signature, building it the
+// first time. Decoding fills in the copy's values, leaving the cached one untouched.
+func builtFunction(sig, kind string, nIndexed int) (*sdk.Function, error) {
+	key := fmt.Sprintf("%s %s %d", kind, sig, nIndexed)
+	entry, ok := built.Load(key)
+	if !ok {
+		fn, err := newFunction(sig, kind, nIndexed)
+		entry, _ = built.LoadOrStore(key, builtEntry{fn: fn, err: err})
+	}
+	b := entry.(builtEntry)
+	if b.err != nil {
+		return nil, b.err
+	}
+	fn := *b.fn
+	fn.Inputs = cloneParams(b.fn.Inputs)
+	fn.Outputs = cloneParams(b.fn.Outputs)
+	return &fn, nil
+}
+
+func cloneParams(params []sdk.Parameter) []sdk.Parameter {
+	if params == nil {
+		return nil
+	}
+	ret := make([]sdk.Parameter, len(params))
+	for i, p := range params {
+		p.Components = cloneParams(p.Components)
+		ret[i] = p
+	}
+	return ret
+}
+
+// newFunction builds the function or event for a signature. An event's first nIndexed
+// parameters are indexed.
+func newFunction(sig, kind string, nIndexed int) (*sdk.Function, error) {
+	name, args, err := parseSignature(sig)
+	if err != nil {
+		return nil, err
+	}
+	if nIndexed > len(args) {
+		return nil, fmt.Errorf("%s has fewer parameters than the log has topics", sig)
+	}
+	for i := 0; i < nIndexed; i++ {
+		args[i].Indexed = true
+	}
+	entry, _ := json.Marshal([]map[string]any{{"type": kind, "name": name, "inputs": args}})
+	parsed, err := ethAbi.JSON(strings.NewReader(string(entry)))
+	if err != nil {
+		return nil, err
+	}
+	for _, method := range parsed.Methods {
+		return types.FunctionFromAbiMethod(&method), nil
+	}
+	for _, event := range parsed.Events {
+		return types.FunctionFromAbiEvent(&event), nil
+	}
+	return nil, fmt.Errorf("%s did not parse", sig)
+}
@@ -0,0 +1,290 @@
+package signatures
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/logging"
+
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DB is a local database of function selectors and event topics with the signatures
+// that hash to them. It decodes calls and logs whose contract's ABI is not cached.
+type DB struct {
+	mu        sync.RWMutex
+	path      string
+	functions map[string][]string
+	events    map[string][]string
+	saveTimer *time.Timer
+}
+
+// saveDelay batches the many additions made while an ABI or a dump is read into one write
+const saveDelay = 2 * time.Second
+
+// NewDB returns an empty database that is not saved to disc
+func NewDB() *DB {
+	return &DB{
+		functions: make(map[string][]string),
+		events:    make(map[string][]string),
+	}
+}
+
+// Open loads the database kept in a text dump at path. A missing file is an empty
+// database. Additions are saved back to the file.
+func Open(path string) (*DB, error) {
+	db := NewDB()
+	if f, err := os.Open(path); err == nil {
+		defer f.Close()
+		if _, _, err := db.read(f); err != nil {
+			return nil, fmt.Errorf("reading signatures from %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	db.path = path
+	return db, nil
+}
+
+// Counts returns the number of function selectors and event topics in the database
+func (db *DB) Counts() (functions, events int) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return len(db.functions), len(db.events)
+}
+
+// Import adds the signatures in a text dump and returns how many were new and how many
+// lines were rejected because their signature does not hash to the given selector
+func (db *DB) Import(r io.Reader) (added, rejected int, err error) {
+	added, rejected, err = db.read(r)
+	if added > 0 {
+		db.scheduleSave()
+	}
+	return
+}
+
+var (
+	signatureRe = regexp.MustCompile(`[A-Za-z_$][A-Za-z0-9_$]*\(.*\)`)
+	prefixedRe  = regexp.MustCompile(`0x([0-9a-fA-F]{64}|[0-9a-fA-F]{8})\b`)
+	bareRe      = regexp.MustCompile(`\b([0-9a-fA-F]{64}|[0-9a-fA-F]{8})\b`)
+)
+
+// read parses a dump with a signature per line, such as transfer(address,uint256). A line
+// may also carry the selector or topic, in any order and with any separator, which is how
+// 4byte.directory and most other dumps are laid out. Without one, the signature is added as
+// both a function and an event. Lines without a signature, such as headers, are skipped.
+func (db *DB) read(r io.Reader) (added, rejected int, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		loc := signatureRe.FindStringIndex(line)
+		if loc == nil {
+			continue
+		}
+		canonical, err := canonicalSignature(line[loc[0]:loc[1]])
+		if err != nil {
+			rejected++
+			continue
+		}
+		hash := hashOf(canonical)
+
+		given := givenHash(line[:loc[0]] + " " + line[loc[1]:])
+		switch {
+		case given == "":
+			if db.add(db.functions, hash[:10], canonical) {
+				added++
+			}
+			if db.add(db.events, hash, canonical) {
+				added++
+			}
+		case given == hash[:10]:
+			if db.add(db.functions, given, canonical) {
+				added++
+			}
+		case given == hash:
+			if db.add(db.events, given, canonical) {
+				added++
+			}
+		default:
+			rejected++
+		}
+	}
+	return added, rejected, scanner.Err()
+}
+
+// givenHash finds the selector or topic on a line. Without a 0x prefix a number, such as
+// an id column, could pass for one, so bare hashes must have a letter.
+func givenHash(rest string) string {
+	if m := prefixedRe.FindStringSubmatch(rest); m != nil {
+		return "0x" + strings.ToLower(m[1])
+	}
+	for _, m := range bareRe.FindAllStringSubmatch(rest, -1) {
+		if strings.ContainsAny(strings.ToLower(m[1]), "abcdef") {
+			return "0x" + strings.ToLower(m[1])
+		}
+	}
+	return ""
+}
+
+// AddFunctions adds the signatures of the functions and events in an ABI and returns how
+// many were new
+func (db *DB) AddFunctions(fns ...sdk.Function) int {
+	added := 0
+	for _, fn := range fns {
+		if fn.Signature == "" {
+			continue
+		}
+		canonical, err := canonicalSignature(fn.Signature)
+		if err != nil {
+			continue
+		}
+		hash := hashOf(canonical)
+		switch fn.FunctionType {
+		case "function":
+			if db.add(db.functions, hash[:10], canonical) {
+				added++
+			}
+		case "event":
+			if db.add(db.events, hash, canonical) {
+				added++
+			}
+		}
+	}
+	if added > 0 {
+		db.scheduleSave()
+	}
+	return added
+}
+
+func (db *DB) add(table map[string][]string, key, signature string) bool {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, existing := range table[key] {
+		if existing == signature {
+			return false
+		}
+	}
+	table[key] = append(table[key], signature)
+	return true
+}
+
+// lookup returns the signatures of a selector or topic
+func (db *DB) lookup(table map[string][]string, key string) []string {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return append([]string{}, table[strings.ToLower(key)]...)
+}
+
+func (db *DB) scheduleSave() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.path == "" || db.saveTimer != nil {
+		return
+	}
+	db.saveTimer = time.AfterFunc(saveDelay, func() {
+		db.mu.Lock()
+		db.saveTimer = nil
+		db.mu.Unlock()
+		if err := db.Save(); err != nil {
+			logging.LogBEWarning(fmt.Sprintf("Signatures: %v", err))
+		}
+	})
+}
+
+// Save writes the database to its file as a dump of selector and signature lines
+func (db *DB) Save() error {
+	if db.path == "" {
+		return nil
+	}
+
+	db.mu.RLock()
+	lines := make([]string, 0, len(db.functions)+len(db.events))
+	for _, table := range []map[string][]string{db.functions, db.events} {
+		for key, sigs := range table {
+			for _, sig := range sigs {
+				lines = append(lines, key+" "+sig)
+			}
+		}
+	}
+	db.mu.RUnlock()
+	sort.Strings(lines)
+
+	if err := os.MkdirAll(filepath.Dir(db.path), 0755); err != nil {
+		return err
+	}
+	tmp := db.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, db.path)
+}
+
+func hashOf(canonical string) string {
+	return fmt.Sprintf("0x%x", crypto.Keccak256([]byte(canonical)))
+}
+
+var (
+	active   *DB
+	activeMu sync.RWMutex
+)
+
+// Configure sets the process-wide database. A nil database turns the fallback decoder off.
+func Configure(db *DB) {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	active = db
+}
+
+// Active returns the process-wide database, if there is one
+func Active() *DB {
+	activeMu.RLock()
+	defer activeMu.RUnlock()
+	return active
+}
+
+// Report is the outcome of importing a dump into the process-wide database
+type Report struct {
+	Added     int `json:"added"`
+	Rejected  int `json:"rejected"`
+	Functions int `json:"functions"`
+	Events    int `json:"events"`
+}
+
+// ImportFile imports the text dump at path into the process-wide database
+func ImportFile(path string) (Report, error) {
+	db := Active()
+	if db == nil {
+		return Report{}, fmt.Errorf("the signature database is not open")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return Report{}, err
+	}
+	defer f.Close()
+
+	var ret Report
+	ret.Added, ret.Rejected, err = db.Import(f)
+	ret.Functions, ret.Events = db.Counts()
+	return ret, err
+}
+
+// AddFunctions adds an ABI's signatures to the process-wide database
+func AddFunctions(fns ...sdk.Function) int {
+	if db := Active(); db != nil {
+		return db.AddFunctions(fns...)
+	}
+	return 0
+}
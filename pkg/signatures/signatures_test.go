@@ -0,0 +1,186 @@
+package signatures
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	holder        = "000000000000000000000000f503017d7baf7fbc0fff7492b751025c6a78179b"
+	spender       = "0000000000000000000000001111111254eeb25477b68fb85ed929f73a960582"
+	amount        = "00000000000000000000000000000000000000000000000000000000000003e8"
+)
+
+func TestCanonicalSignature(t *testing.T) {
+	for in, want := range map[string]string{
+		"transfer(address,uint256)":                 "transfer(address,uint256)",
+		"transfer(address to, uint amount)":         "transfer(address,uint256)",
+		"swap((address,uint)[],bytes32, int[2])":    "swap((address,uint256)[],bytes32,int256[2])",
+		"Approval(address indexed,address,uint256)": "Approval(address,address,uint256)",
+		"noArgs()": "noArgs()",
+	} {
+		got, err := canonicalSignature(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, bad := range []string{"transfer", "(address)", "f(address", "f((address)", "f(address,)", "f((uint256)x)"} {
+		_, err := canonicalSignature(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestImportAndSave(t *testing.T) {
+	dump := strings.Join([]string{
+		"# a comment",
+		"id,text_signature,hex_signature",
+		"12345678,approve(address,uint256),0x095ea7b3",
+		"a9059cbb\ttransfer(address,uint256)",
+		"Transfer(address,address,uint256) " + transferTopic,
+		"0xdeadbeef approve(address,uint256)",
+		"allowance(address,address)",
+	}, "\n")
+
+	path := filepath.Join(t.TempDir(), "signatures.txt")
+	db, err := Open(path)
+	require.NoError(t, err)
+	added, rejected, err := db.Import(strings.NewReader(dump))
+	require.NoError(t, err)
+	assert.Equal(t, 5, added, "allowance is added as both a function and an event")
+	assert.Equal(t, 1, rejected, "0xdeadbeef is not approve's selector")
+
+	assert.Equal(t, []string{"approve(address,uint256)"}, db.lookup(db.functions, "0x095ea7b3"))
+	assert.Equal(t, []string{"transfer(address,uint256)"}, db.lookup(db.functions, "0xA9059CBB"))
+	assert.Equal(t, []string{"Transfer(address,address,uint256)"}, db.lookup(db.events, transferTopic))
+	assert.Equal(t, []string{"allowance(address,address)"}, db.lookup(db.functions, "0xdd62ed3e"))
+	nFunctions, nEvents := db.Counts()
+	assert.Equal(t, 3, nFunctions)
+	assert.Equal(t, 2, nEvents)
+
+	require.NoError(t, db.Save())
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	f, e := reopened.Counts()
+	assert.Equal(t, nFunctions, f)
+	assert.Equal(t, nEvents, e)
+
+	require.NoError(t, os.WriteFile(path, []byte("not a dump\n"), 0644))
+	empty, err := Open(path)
+	require.NoError(t, err)
+	f, e = empty.Counts()
+	assert.Zero(t, f+e)
+}
+
+func TestAddFunctions(t *testing.T) {
+	db := NewDB()
+	added := db.AddFunctions(
+		sdk.Function{FunctionType: "function", Name: "approve", Signature: "approve(address,uint256)"},
+		sdk.Function{FunctionType: "event", Name: "Transfer", Signature: "Transfer(address,address,uint256)"},
+		sdk.Function{FunctionType: "constructor", Signature: "constructor(address)"},
+		sdk.Function{FunctionType: "function", Name: "unsigned"},
+	)
+	assert.Equal(t, 2, added)
+	assert.Equal(t, 0, db.AddFunctions(sdk.Function{FunctionType: "function", Signature: "approve(address,uint256)"}))
+}
+
+func TestDecode(t *testing.T) {
+	db := NewDB()
+	_, _, err := db.Import(strings.NewReader(strings.Join([]string{
+		"0x23b872dd gasprice_bit_ether(int128)",
+		"0x23b872dd transferFrom(address,address,uint256)",
+		"0x095ea7b3 approve(address,uint256)",
+		transferTopic + " Transfer(address,address,uint256)",
+		"Deposited(address,uint256)",
+	}, "\n")))
+	require.NoError(t, err)
+
+	t.Run("Input", func(t *testing.T) {
+		fn := db.DecodeInput("0x095ea7b3" + spender + amount)
+		require.NotNil(t, fn)
+		assert.Equal(t, "approve", fn.Name)
+		require.Len(t, fn.Inputs, 2)
+		assert.Equal(t, "0x1111111254eeb25477b68fb85ed929f73a960582", strings.ToLower(fmt.Sprint(fn.Inputs[0].Value)))
+		assert.Equal(t, "1000", fmt.Sprint(fn.Inputs[1].Value))
+
+		assert.Nil(t, db.DecodeInput("0x095ea7b3"+spender[:10]), "data is not whole words")
+		assert.Nil(t, db.DecodeInput("0xffffffff"+amount), "unknown selector")
+		assert.Nil(t, db.DecodeInput("0x"), "no selector")
+	})
+
+	t.Run("CollidingSelectorPrefersAnExactEncoding", func(t *testing.T) {
+		fn := db.DecodeInput("0x23b872dd" + holder + spender + amount)
+		require.NotNil(t, fn)
+		assert.Equal(t, "transferFrom", fn.Name)
+	})
+
+	t.Run("Log", func(t *testing.T) {
+		log := &sdk.Log{
+			Topics: []base.Hash{base.HexToHash(transferTopic), base.HexToHash("0x" + holder), base.HexToHash("0x" + spender)},
+			Data:   "0x" + amount,
+		}
+		fn := db.DecodeLog(log)
+		require.NotNil(t, fn)
+		assert.Equal(t, "Transfer", fn.Name)
+		require.Len(t, fn.Inputs, 3)
+		assert.Equal(t, "1000", fmt.Sprint(fn.Inputs[2].Value))
+		assert.Nil(t, log.ArticulatedLog, "the log itself is not changed")
+
+		deposit := &sdk.Log{
+			Topics: []base.Hash{base.HexToHash(hashOf("Deposited(address,uint256)")), base.HexToHash("0x" + holder)},
+			Data:   "0x" + amount,
+		}
+		fn = db.DecodeLog(deposit)
+		require.NotNil(t, fn)
+		assert.Equal(t, "Deposited", fn.Name)
+		require.Len(t, fn.Inputs, 2)
+		assert.Equal(t, "0xf503017d7baf7fbc0fff7492b751025c6a78179b", strings.ToLower(fmt.Sprint(fn.Inputs[0].Value)))
+		assert.Equal(t, "1000", fmt.Sprint(fn.Inputs[1].Value))
+
+		unknown := &sdk.Log{Topics: []base.Hash{base.HexToHash("0x" + amount)}}
+		assert.Nil(t, db.DecodeLog(unknown))
+		tooMany := &sdk.Log{Topics: []base.Hash{base.HexToHash(transferTopic), {}, {}, {}, {}}}
+		assert.Nil(t, db.DecodeLog(tooMany))
+	})
+
+	t.Run("BuiltOncePerSignature", func(t *testing.T) {
+		first := db.DecodeInput("0x095ea7b3" + spender + amount)
+		second := db.DecodeInput("0x095ea7b3" + holder + "0000000000000000000000000000000000000000000000000000000000000007")
+		require.NotNil(t, first)
+		require.NotNil(t, second)
+		assert.Equal(t, "1000", fmt.Sprint(first.Inputs[1].Value), "a later decode does not change an earlier result")
+		assert.Equal(t, "7", fmt.Sprint(second.Inputs[1].Value))
+
+		a, err := builtFunction("approve(address,uint256)", "function", 0)
+		require.NoError(t, err)
+		b, _ := builtFunction("approve(address,uint256)", "function", 0)
+		assert.NotSame(t, a, b)
+		cached, ok := built.Load("function approve(address,uint256) 0")
+		require.True(t, ok)
+		assert.Nil(t, cached.(builtEntry).fn.Inputs[1].Value, "the cached function holds no decoded values")
+	})
+
+	t.Run("ProcessWide", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "dump.txt")
+		require.NoError(t, os.WriteFile(path, []byte("0xa9059cbb transfer(address,uint256)\n"), 0644))
+
+		Configure(nil)
+		assert.Nil(t, DecodeInput("0x095ea7b3"+spender+amount))
+		_, err := ImportFile(path)
+		assert.Error(t, err, "no database is open")
+
+		Configure(db)
+		defer Configure(nil)
+		assert.NotNil(t, DecodeInput("0x095ea7b3"+spender+amount))
+		report, err := ImportFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, Report{Added: 1, Functions: 4, Events: 2}, report)
+	})
+}
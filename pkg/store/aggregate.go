@@ -0,0 +1,200 @@
+package store
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	coreTypes "github.com/TrueBlocks/trueblocks-chifra/v6/pkg/types"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// AggregateInput is a single item's contribution to a period and asset aggregate
+type AggregateInput struct {
+	Timestamp int64
+	Asset     string
+	Symbol    string
+	Decimals  uint64
+	In        *base.Wei
+	Out       *base.Wei
+	Gas       *base.Wei
+	Balance   *base.Wei // Balance after the item; nil if the item does not carry one
+}
+
+// AggregateSummary holds the numeric totals for one asset over one period
+type AggregateSummary struct {
+	Period     types.Period `json:"period"`
+	Timestamp  int64        `json:"timestamp"`
+	Asset      string       `json:"asset"`
+	Symbol     string       `json:"symbol"`
+	Decimals   uint64       `json:"decimals"`
+	Count      int64        `json:"count"`
+	SumIn      base.Wei     `json:"sumIn"`
+	SumOut     base.Wei     `json:"sumOut"`
+	NetFlow    base.Wei     `json:"netFlow"`
+	MinBalance base.Wei     `json:"minBalance"`
+	MaxBalance base.Wei     `json:"maxBalance"`
+	GasSpent   base.Wei     `json:"gasSpent"`
+	hasBalance bool
+}
+
+// Model implements the sdk.Modeler interface for AggregateSummary
+func (a *AggregateSummary) Model(chain, format string, verbose bool, extraOptions map[string]any) coreTypes.Model {
+	return coreTypes.Model{
+		Data: map[string]any{
+			"period":     a.Period,
+			"timestamp":  a.Timestamp,
+			"asset":      a.Asset,
+			"symbol":     a.Symbol,
+			"decimals":   a.Decimals,
+			"count":      a.Count,
+			"sumIn":      a.SumIn.String(),
+			"sumOut":     a.SumOut.String(),
+			"netFlow":    a.NetFlow.String(),
+			"minBalance": a.MinBalance.String(),
+			"maxBalance": a.MaxBalance.String(),
+			"gasSpent":   a.GasSpent.String(),
+		},
+		Order: []string{
+			"period", "timestamp", "asset", "symbol", "decimals", "count",
+			"sumIn", "sumOut", "netFlow", "minBalance", "maxBalance", "gasSpent",
+		},
+	}
+}
+
+func (a *AggregateSummary) add(in AggregateInput) {
+	a.Count++
+	if in.In != nil {
+		addWei(&a.SumIn, in.In)
+		addWei(&a.NetFlow, in.In)
+	}
+	if in.Out != nil {
+		addWei(&a.SumOut, in.Out)
+		subWei(&a.NetFlow, in.Out)
+	}
+	if in.Gas != nil {
+		addWei(&a.GasSpent, in.Gas)
+	}
+	if in.Balance != nil {
+		if !a.hasBalance || in.Balance.Cmp(&a.MinBalance) < 0 {
+			a.MinBalance = cloneWei(in.Balance)
+		}
+		if !a.hasBalance || in.Balance.Cmp(&a.MaxBalance) > 0 {
+			a.MaxBalance = cloneWei(in.Balance)
+		}
+		a.hasBalance = true
+	}
+	if a.Symbol == "" {
+		a.Symbol = in.Symbol
+		a.Decimals = in.Decimals
+	}
+}
+
+// clone returns a copy that does not share big.Int storage with the running totals
+func (a *AggregateSummary) clone() AggregateSummary {
+	ret := *a
+	ret.SumIn = cloneWei(&a.SumIn)
+	ret.SumOut = cloneWei(&a.SumOut)
+	ret.NetFlow = cloneWei(&a.NetFlow)
+	ret.MinBalance = cloneWei(&a.MinBalance)
+	ret.MaxBalance = cloneWei(&a.MaxBalance)
+	ret.GasSpent = cloneWei(&a.GasSpent)
+	return ret
+}
+
+// base.Wei arithmetic returns new values, so the running totals are updated through big.Int
+func addWei(dst, v *base.Wei) {
+	(*big.Int)(dst).Add((*big.Int)(dst), (*big.Int)(v))
+}
+
+func subWei(dst, v *base.Wei) {
+	(*big.Int)(dst).Sub((*big.Int)(dst), (*big.Int)(v))
+}
+
+func cloneWei(w *base.Wei) base.Wei {
+	return base.Wei(*new(big.Int).Set((*big.Int)(w)))
+}
+
+// Aggregate folds an item into the totals for its period and asset
+func (sm *SummaryManager[T]) Aggregate(in AggregateInput, period types.Period) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	key := SummaryKey{Timestamp: NormalizeToPeriod(in.Timestamp, period), Period: period, AssetAddr: in.Asset}
+	agg, exists := sm.aggregates[key]
+	if !exists {
+		agg = &AggregateSummary{Period: period, Timestamp: key.Timestamp, Asset: in.Asset}
+		sm.aggregates[key] = agg
+	}
+	agg.add(in)
+}
+
+// GetAggregates returns a copy of the totals for a given period, ordered by time then asset
+func (sm *SummaryManager[T]) GetAggregates(period types.Period) []AggregateSummary {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	results := make([]AggregateSummary, 0, len(sm.aggregates))
+	for key, agg := range sm.aggregates {
+		if key.Period == period {
+			results = append(results, agg.clone())
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Timestamp != results[j].Timestamp {
+			return results[i].Timestamp < results[j].Timestamp
+		}
+		return results[i].Asset < results[j].Asset
+	})
+	return results
+}
+
+// SortAggregates sorts summary rows in place by the first field of the sort spec
+func SortAggregates(items []AggregateSummary, spec sdk.SortSpec) error {
+	if len(spec.Fields) == 0 || spec.Fields[0] == "" {
+		return nil
+	}
+	field := spec.Fields[0]
+	desc := len(spec.Order) > 0 && spec.Order[0] == sdk.Dec
+
+	var less func(a, b *AggregateSummary) bool
+	switch field {
+	case "period":
+		less = func(a, b *AggregateSummary) bool { return a.Period < b.Period }
+	case "timestamp":
+		less = func(a, b *AggregateSummary) bool { return a.Timestamp < b.Timestamp }
+	case "asset":
+		less = func(a, b *AggregateSummary) bool { return a.Asset < b.Asset }
+	case "symbol":
+		less = func(a, b *AggregateSummary) bool { return a.Symbol < b.Symbol }
+	case "decimals":
+		less = func(a, b *AggregateSummary) bool { return a.Decimals < b.Decimals }
+	case "count":
+		less = func(a, b *AggregateSummary) bool { return a.Count < b.Count }
+	case "sumIn":
+		less = func(a, b *AggregateSummary) bool { return a.SumIn.Cmp(&b.SumIn) < 0 }
+	case "sumOut":
+		less = func(a, b *AggregateSummary) bool { return a.SumOut.Cmp(&b.SumOut) < 0 }
+	case "netFlow":
+		less = func(a, b *AggregateSummary) bool { return a.NetFlow.Cmp(&b.NetFlow) < 0 }
+	case "minBalance":
+		less = func(a, b *AggregateSummary) bool { return a.MinBalance.Cmp(&b.MinBalance) < 0 }
+	case "maxBalance":
+		less = func(a, b *AggregateSummary) bool { return a.MaxBalance.Cmp(&b.MaxBalance) < 0 }
+	case "gasSpent":
+		less = func(a, b *AggregateSummary) bool { return a.GasSpent.Cmp(&b.GasSpent) < 0 }
+	default:
+		return fmt.Errorf("unsupported sort field for summaries: %s", field)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if desc {
+			return less(&items[j], &items[i])
+		}
+		return less(&items[i], &items[j])
+	})
+	return nil
+}
@@ -73,11 +73,12 @@ func CancelFetch(contextKey string) {
 }
 
 func CancelFetches() int {
+	cancelledCount := GetScheduler().CancelQueued()
+
 	cm := GetContextManager()
 	cm.renderCtxsMutex.Lock()
 	defer cm.renderCtxsMutex.Unlock()
 
-	cancelledCount := 0
 	for key, ctx := range cm.renderCtxs {
 		if ctx != nil {
 			ctx.Cancel()
@@ -0,0 +1,114 @@
+package store
+
+import (
+	"reflect"
+)
+
+// footprintSamples caps how many items are walked when estimating a store's memory use.
+// The average deep size of the sample is extrapolated over the whole store.
+const footprintSamples = 256
+
+// estimateFootprint returns an estimate of the bytes held by items, following strings,
+// slices, maps and pointers. At most footprintSamples items, spread evenly, are walked.
+func estimateFootprint[T any](items []*T) int64 {
+	n := len(items)
+	if n == 0 {
+		return 0
+	}
+
+	stride := 1
+	if n > footprintSamples {
+		stride = n / footprintSamples
+	}
+
+	var sampled, total int64
+	seen := make(map[uintptr]bool)
+	for i := 0; i < n; i += stride {
+		total += deepSize(reflect.ValueOf(items[i]), seen)
+		sampled++
+	}
+	return int64(n)*int64(reflect.TypeFor[*T]().Size()) + total*int64(n)/sampled
+}
+
+// deepSize returns the bytes referenced by v beyond its own inline size. Pointers, maps
+// and slices already counted (tracked in seen) contribute nothing the second time.
+func deepSize(v reflect.Value, seen map[uintptr]bool) int64 {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		elem := v.Elem()
+		return int64(elem.Type().Size()) + deepSize(elem, seen)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		return int64(elem.Type().Size()) + deepSize(elem, seen)
+
+	case reflect.String:
+		return int64(v.Len())
+
+	case reflect.Slice:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		size := int64(v.Cap()) * int64(v.Type().Elem().Size())
+		if hasReferences(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				size += deepSize(v.Index(i), seen)
+			}
+		}
+		return size
+
+	case reflect.Array:
+		var size int64
+		if hasReferences(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				size += deepSize(v.Index(i), seen)
+			}
+		}
+		return size
+
+	case reflect.Map:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		size := int64(v.Len()) * int64(v.Type().Key().Size()+v.Type().Elem().Size())
+		iter := v.MapRange()
+		for iter.Next() {
+			size += deepSize(iter.Key(), seen) + deepSize(iter.Value(), seen)
+		}
+		return size
+
+	case reflect.Struct:
+		var size int64
+		for i := 0; i < v.NumField(); i++ {
+			size += deepSize(v.Field(i), seen)
+		}
+		return size
+	}
+	return 0
+}
+
+// hasReferences reports whether values of t can point to memory outside their inline size
+func hasReferences(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.String, reflect.Slice, reflect.Map:
+		return true
+	case reflect.Array:
+		return hasReferences(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasReferences(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
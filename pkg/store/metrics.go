@@ -0,0 +1,192 @@
+package store
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	coreTypes "github.com/TrueBlocks/trueblocks-chifra/v6/pkg/types"
+)
+
+// FetchMetrics is a point-in-time snapshot of a store's fetch performance
+type FetchMetrics struct {
+	StoreName       string           `json:"storeName"`
+	State           types.StoreState `json:"state"`
+	Fetches         int64            `json:"fetches"`
+	Items           int64            `json:"items"`
+	LastDurationMs  int64            `json:"lastDurationMs"`
+	TimeToFirstMs   int64            `json:"timeToFirstMs"`
+	ItemsPerSecond  float64          `json:"itemsPerSecond"`
+	Errors          int64            `json:"errors"`
+	Retries         int64            `json:"retries"`
+	Cancellations   int64            `json:"cancellations"`
+	MemoryBytes     int64            `json:"memoryBytes"` // sampled deep estimate, including strings, slices and pointed-to values
+	LastFetchedAt   int64            `json:"lastFetchedAt"`
+	LastError       string           `json:"lastError"`
+	fetchInProgress bool
+}
+
+// Model implements the sdk.Modeler interface for FetchMetrics
+func (m *FetchMetrics) Model(chain, format string, verbose bool, extraOptions map[string]any) coreTypes.Model {
+	return coreTypes.Model{
+		Data: map[string]any{
+			"storeName":      m.StoreName,
+			"state":          m.State,
+			"fetches":        m.Fetches,
+			"items":          m.Items,
+			"lastDurationMs": m.LastDurationMs,
+			"timeToFirstMs":  m.TimeToFirstMs,
+			"itemsPerSecond": m.ItemsPerSecond,
+			"errors":         m.Errors,
+			"retries":        m.Retries,
+			"cancellations":  m.Cancellations,
+			"memoryBytes":    m.MemoryBytes,
+			"lastFetchedAt":  m.LastFetchedAt,
+			"lastError":      m.LastError,
+		},
+		Order: []string{
+			"storeName", "state", "fetches", "items", "lastDurationMs", "timeToFirstMs",
+			"itemsPerSecond", "errors", "retries", "cancellations", "memoryBytes",
+			"lastFetchedAt", "lastError",
+		},
+	}
+}
+
+// metricsRecorder accumulates fetch metrics for a single store
+type metricsRecorder struct {
+	metrics    FetchMetrics
+	started    time.Time
+	firstItem  time.Time
+	footprint  func() int64
+	stateOf    func() types.StoreState
+	mutex      sync.Mutex
+	registered bool
+}
+
+var (
+	metricsRegistry   = make(map[string]*metricsRecorder)
+	metricsRegistryMu sync.RWMutex
+)
+
+func newMetricsRecorder[T any](s *Store[T]) *metricsRecorder {
+	return &metricsRecorder{
+		metrics: FetchMetrics{StoreName: s.contextKey},
+		footprint: func() int64 {
+			s.mutex.RLock()
+			defer s.mutex.RUnlock()
+			size := estimateFootprint(s.data)
+			if s.dataMap != nil {
+				size += int64(len(*s.dataMap)) * int64(reflect.TypeFor[*T]().Size()*3)
+			}
+			return size
+		},
+		stateOf: s.GetState,
+	}
+}
+
+func (r *metricsRecorder) begin() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.started = time.Now()
+	r.firstItem = time.Time{}
+	r.metrics.Fetches++
+	r.metrics.Items = 0
+	r.metrics.TimeToFirstMs = 0
+	r.metrics.LastError = ""
+	r.metrics.fetchInProgress = true
+	r.register()
+}
+
+func (r *metricsRecorder) item() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.firstItem.IsZero() {
+		r.firstItem = time.Now()
+		r.metrics.TimeToFirstMs = r.firstItem.Sub(r.started).Milliseconds()
+	}
+	r.metrics.Items++
+}
+
+func (r *metricsRecorder) retry(err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.metrics.Retries++
+	r.metrics.Errors++
+	r.metrics.LastError = err.Error()
+}
+
+func (r *metricsRecorder) end(err error, cancelled bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	elapsed := time.Since(r.started)
+	r.metrics.LastDurationMs = elapsed.Milliseconds()
+	r.metrics.LastFetchedAt = r.started.Unix()
+	if secs := elapsed.Seconds(); secs > 0 {
+		r.metrics.ItemsPerSecond = float64(r.metrics.Items) / secs
+	}
+	switch {
+	case cancelled:
+		r.metrics.Cancellations++
+	case err != nil:
+		r.metrics.Errors++
+		r.metrics.LastError = err.Error()
+	}
+	r.metrics.fetchInProgress = false
+}
+
+// register adds the recorder to the global registry the first time its store fetches
+func (r *metricsRecorder) register() {
+	if r.registered {
+		return
+	}
+	metricsRegistryMu.Lock()
+	metricsRegistry[r.metrics.StoreName] = r
+	metricsRegistryMu.Unlock()
+	r.registered = true
+}
+
+func (r *metricsRecorder) snapshot() FetchMetrics {
+	footprint := r.footprint()
+	state := r.stateOf()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	ret := r.metrics
+	ret.State = state
+	ret.MemoryBytes = footprint
+	if ret.fetchInProgress {
+		elapsed := time.Since(r.started)
+		ret.LastDurationMs = elapsed.Milliseconds()
+		if secs := elapsed.Seconds(); secs > 0 {
+			ret.ItemsPerSecond = float64(ret.Items) / secs
+		}
+	}
+	return ret
+}
+
+// GetMetrics returns a snapshot of this store's fetch metrics
+func (s *Store[T]) GetMetrics() FetchMetrics {
+	return s.metrics.snapshot()
+}
+
+// GetAllFetchMetrics returns a snapshot of every store that has fetched at least once, sorted by name
+func GetAllFetchMetrics() []FetchMetrics {
+	metricsRegistryMu.RLock()
+	recorders := make([]*metricsRecorder, 0, len(metricsRegistry))
+	for _, r := range metricsRegistry {
+		recorders = append(recorders, r)
+	}
+	metricsRegistryMu.RUnlock()
+
+	ret := make([]FetchMetrics, 0, len(recorders))
+	for _, r := range recorders {
+		ret = append(ret, r.snapshot())
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].StoreName < ret[j].StoreName
+	})
+	return ret
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreMetricsRecordFetch(t *testing.T) {
+	items := []*TestData{{ID: 1}, {ID: 2}, {ID: 3}}
+	store := createStoreWithTestData(t, items, nil)
+
+	assert.NoError(t, store.Fetch())
+
+	m := store.GetMetrics()
+	assert.Equal(t, "test-data-store", m.StoreName)
+	assert.Equal(t, types.StateLoaded, m.State)
+	assert.Equal(t, int64(1), m.Fetches)
+	assert.Equal(t, int64(3), m.Items)
+	assert.Equal(t, int64(0), m.Errors)
+	assert.Greater(t, m.MemoryBytes, int64(0))
+
+	found := false
+	for _, each := range GetAllFetchMetrics() {
+		if each.StoreName == "test-data-store" {
+			found = true
+		}
+	}
+	assert.True(t, found, "store should appear in the metrics registry after fetching")
+}
+
+func TestStoreMetricsRecordErrors(t *testing.T) {
+	store := NewStore("metrics-error-test",
+		func(ctx *output.RenderCtx) error {
+			return errors.New("boom")
+		},
+		func(item interface{}) *TestData { return item.(*TestData) },
+		nil)
+
+	assert.Error(t, store.Fetch())
+
+	m := store.GetMetrics()
+	assert.Equal(t, int64(1), m.Fetches)
+	assert.Equal(t, int64(1), m.Errors)
+	assert.Equal(t, "boom", m.LastError)
+}
+
+func TestEstimateFootprintFollowsReferences(t *testing.T) {
+	short := []*TestData{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+	long := []*TestData{{ID: 1, Name: strings.Repeat("a", 1000)}, {ID: 2, Name: strings.Repeat("b", 1000)}}
+
+	assert.Equal(t, int64(0), estimateFootprint([]*TestData{}))
+	assert.GreaterOrEqual(t, estimateFootprint(long)-estimateFootprint(short), int64(1998))
+}
@@ -0,0 +1,57 @@
+package store
+
+import (
+	"time"
+)
+
+// RetryPolicy controls how Store.Fetch recovers from stream and query errors
+type RetryPolicy struct {
+	MaxAttempts    int           // Total attempts including the first; 1 disables retries
+	InitialBackoff time.Duration // Wait before the first retry
+	MaxBackoff     time.Duration // Upper bound on any single wait
+	Multiplier     float64       // Growth factor applied after each retry
+}
+
+// DefaultRetryPolicy is applied to every new store
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     8 * time.Second,
+	Multiplier:     2.0,
+}
+
+// NoRetryPolicy gives up after the first failure
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+// Backoff returns the wait before the given retry (1-based)
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	if retry < 1 || p.InitialBackoff <= 0 {
+		return 0
+	}
+	multiplier := max(p.Multiplier, 1.0)
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		delay *= multiplier
+		if p.MaxBackoff > 0 && delay >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && time.Duration(delay) > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return time.Duration(delay)
+}
+
+// SetRetryPolicy replaces the retry policy used by subsequent fetches
+func (s *Store[T]) SetRetryPolicy(policy RetryPolicy) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.retryPolicy = policy
+}
+
+// GetRetryPolicy returns the store's current retry policy
+func (s *Store[T]) GetRetryPolicy() RetryPolicy {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.retryPolicy
+}
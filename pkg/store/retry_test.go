@@ -0,0 +1,104 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2.0,
+	}
+
+	assert.Equal(t, time.Duration(0), policy.Backoff(0))
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 300*time.Millisecond, policy.Backoff(3))
+	assert.Equal(t, 300*time.Millisecond, policy.Backoff(10))
+}
+
+func TestStoreFetchResumesAfterTransientError(t *testing.T) {
+	items := []*TestData{
+		{ID: 1, Name: "one"},
+		{ID: 2, Name: "two"},
+		{ID: 3, Name: "three"},
+		{ID: 4, Name: "four"},
+	}
+
+	attempts := 0
+	store := NewStore("retry-resume-test",
+		func(ctx *output.RenderCtx) error {
+			attempts++
+			failing := attempts == 1
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				for i, item := range items {
+					if failing && i == 2 {
+						ctx.ErrorChan <- types.NewNetworkError("test", "facet", "fetch", errors.New("connection reset by peer"))
+						return
+					}
+					ctx.ModelChan <- item
+				}
+			}()
+			return nil
+		},
+		func(item interface{}) *TestData { return item.(*TestData) },
+		func(item *TestData) (string, bool) { return fmt.Sprintf("%d", item.ID), true })
+	store.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2.0})
+
+	err := store.Fetch()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, types.StateLoaded, store.GetState())
+
+	got := store.GetItems(false)
+	assert.Len(t, got, 4)
+	for i, item := range got {
+		assert.Equal(t, items[i].ID, item.ID)
+	}
+}
+
+func TestStoreFetchGivesUpAfterMaxAttempts(t *testing.T) {
+	transient := errors.New("503 service temporarily unavailable")
+
+	attempts := 0
+	store := NewStore("retry-exhausted-test",
+		func(ctx *output.RenderCtx) error {
+			attempts++
+			return types.NewSDKError("test", "facet", "fetch", transient)
+		},
+		func(item interface{}) *TestData { return item.(*TestData) },
+		nil)
+	store.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	err := store.Fetch()
+	assert.ErrorIs(t, err, transient)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, types.StateStale, store.GetState())
+}
+
+func TestStoreFetchDoesNotRetryValidationErrors(t *testing.T) {
+	attempts := 0
+	store := NewStore("retry-validation-test",
+		func(ctx *output.RenderCtx) error {
+			attempts++
+			return types.NewValidationError("test", "facet", "fetch", errors.New("timeout must be positive"))
+		},
+		func(item interface{}) *TestData { return item.(*TestData) },
+		nil)
+	store.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	err := store.Fetch()
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
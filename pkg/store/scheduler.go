@@ -0,0 +1,233 @@
+package store
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// Priority orders queued fetches. Higher values run first.
+type Priority int
+
+const (
+	PriorityBackground Priority = iota
+	PriorityForeground
+)
+
+// DefaultMaxConcurrentFetches caps the number of SDK streams running at once
+const DefaultMaxConcurrentFetches = 3
+
+// ErrFetchSuperseded is delivered to callers whose queued fetch was replaced or dropped
+var ErrFetchSuperseded = errors.New("fetch superseded")
+
+type fetchJob struct {
+	contextKey string
+	priority   Priority
+	seq        uint64
+	fetchFunc  func() error
+	waiters    []chan error
+	superseded bool
+}
+
+// FetchScheduler sits between the facets and Store.Fetch. It limits the number of
+// concurrent fetches, runs the on-screen facet ahead of background facets, and
+// coalesces repeated requests for the same contextKey.
+type FetchScheduler struct {
+	maxConcurrent int
+	foregroundKey string
+	queue         []*fetchJob
+	running       map[string]*fetchJob
+	nextSeq       uint64
+	mutex         sync.Mutex
+}
+
+var (
+	globalScheduler *FetchScheduler
+	schedulerOnce   sync.Once
+)
+
+// GetScheduler returns the singleton fetch scheduler
+func GetScheduler() *FetchScheduler {
+	schedulerOnce.Do(func() {
+		globalScheduler = NewFetchScheduler(DefaultMaxConcurrentFetches)
+	})
+	return globalScheduler
+}
+
+// NewFetchScheduler creates a scheduler that runs at most maxConcurrent background fetches
+func NewFetchScheduler(maxConcurrent int) *FetchScheduler {
+	return &FetchScheduler{
+		maxConcurrent: max(1, maxConcurrent),
+		queue:         make([]*fetchJob, 0),
+		running:       make(map[string]*fetchJob),
+	}
+}
+
+// SetMaxConcurrent changes the concurrency cap. Queued jobs start immediately if the cap grows.
+func (s *FetchScheduler) SetMaxConcurrent(n int) {
+	s.mutex.Lock()
+	s.maxConcurrent = max(1, n)
+	s.mutex.Unlock()
+	s.dispatch()
+}
+
+// SetForeground marks the store with the given contextKey as the one currently on screen.
+// Any queued fetch for that key moves to the front of the queue. The previous foreground
+// store's fetches, queued or running, drop to background so they neither run ahead of
+// other queued fetches nor hold the foreground's extra slot.
+func (s *FetchScheduler) SetForeground(contextKey string) {
+	s.mutex.Lock()
+	previous := s.foregroundKey
+	if previous == contextKey {
+		s.mutex.Unlock()
+		return
+	}
+	s.foregroundKey = contextKey
+	for _, job := range s.queue {
+		switch job.contextKey {
+		case contextKey:
+			job.priority = PriorityForeground
+		case previous:
+			job.priority = PriorityBackground
+		}
+	}
+	if job, isRunning := s.running[previous]; isRunning {
+		job.priority = PriorityBackground
+	}
+	s.mutex.Unlock()
+	s.dispatch()
+}
+
+// Schedule queues fetchFunc for the given contextKey and returns a channel that receives
+// the fetch's result. A request for a key that is already queued joins the queued job.
+// A request for a key that is already running joins the running fetch unless that fetch
+// has been invalidated, in which case a new fetch is queued behind it.
+func (s *FetchScheduler) Schedule(contextKey string, fetchFunc func() error) <-chan error {
+	result := make(chan error, 1)
+
+	s.mutex.Lock()
+	priority := PriorityBackground
+	if contextKey == s.foregroundKey {
+		priority = PriorityForeground
+	}
+
+	for _, job := range s.queue {
+		if job.contextKey == contextKey {
+			job.waiters = append(job.waiters, result)
+			job.fetchFunc = fetchFunc
+			s.mutex.Unlock()
+			return result
+		}
+	}
+
+	if job, isRunning := s.running[contextKey]; isRunning && !job.superseded {
+		job.waiters = append(job.waiters, result)
+		s.mutex.Unlock()
+		return result
+	}
+
+	s.nextSeq++
+	s.queue = append(s.queue, &fetchJob{
+		contextKey: contextKey,
+		priority:   priority,
+		seq:        s.nextSeq,
+		fetchFunc:  fetchFunc,
+		waiters:    []chan error{result},
+	})
+	s.mutex.Unlock()
+
+	s.dispatch()
+	return result
+}
+
+// Invalidate marks the running fetch for contextKey as out of date so that the next
+// request for that key starts a fresh fetch rather than joining it
+func (s *FetchScheduler) Invalidate(contextKey string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if job, isRunning := s.running[contextKey]; isRunning {
+		job.superseded = true
+	}
+}
+
+// CancelQueued drops every fetch that has not yet started and returns how many were dropped
+func (s *FetchScheduler) CancelQueued() int {
+	s.mutex.Lock()
+	dropped := s.queue
+	s.queue = make([]*fetchJob, 0)
+	s.mutex.Unlock()
+
+	for _, job := range dropped {
+		job.finish(ErrFetchSuperseded)
+	}
+	return len(dropped)
+}
+
+// QueuedCount returns the number of fetches waiting for a free slot
+func (s *FetchScheduler) QueuedCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.queue)
+}
+
+// RunningCount returns the number of fetches currently streaming
+func (s *FetchScheduler) RunningCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.running)
+}
+
+// dispatch starts as many queued jobs as the concurrency cap allows. The foreground
+// job is always allowed to start, even if background fetches have filled every slot.
+func (s *FetchScheduler) dispatch() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sort.SliceStable(s.queue, func(i, j int) bool {
+		if s.queue[i].priority != s.queue[j].priority {
+			return s.queue[i].priority > s.queue[j].priority
+		}
+		return s.queue[i].seq < s.queue[j].seq
+	})
+
+	remaining := make([]*fetchJob, 0, len(s.queue))
+	for _, job := range s.queue {
+		_, keyBusy := s.running[job.contextKey]
+		hasSlot := len(s.running) < s.maxConcurrent || (job.priority == PriorityForeground && !s.foregroundRunning())
+		if keyBusy || !hasSlot {
+			remaining = append(remaining, job)
+			continue
+		}
+		s.running[job.contextKey] = job
+		go s.run(job)
+	}
+	s.queue = remaining
+}
+
+func (s *FetchScheduler) foregroundRunning() bool {
+	for _, job := range s.running {
+		if job.priority == PriorityForeground {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *FetchScheduler) run(job *fetchJob) {
+	err := job.fetchFunc()
+
+	s.mutex.Lock()
+	if s.running[job.contextKey] == job {
+		delete(s.running, job.contextKey)
+	}
+	s.mutex.Unlock()
+
+	job.finish(err)
+	s.dispatch()
+}
+
+func (j *fetchJob) finish(err error) {
+	for _, waiter := range j.waiters {
+		waiter <- err
+	}
+}
@@ -0,0 +1,184 @@
+package store
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerCapsConcurrency(t *testing.T) {
+	s := NewFetchScheduler(2)
+	release := make(chan struct{})
+	var active, peak atomic.Int32
+
+	fetch := func() error {
+		n := active.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		active.Add(-1)
+		return nil
+	}
+
+	results := make([]<-chan error, 0, 5)
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		results = append(results, s.Schedule(key, fetch))
+	}
+
+	assert.Eventually(t, func() bool { return active.Load() == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 3, s.QueuedCount())
+
+	close(release)
+	for _, r := range results {
+		assert.NoError(t, <-r)
+	}
+	assert.Equal(t, int32(2), peak.Load())
+	assert.Equal(t, 0, s.RunningCount())
+	assert.Equal(t, 0, s.QueuedCount())
+}
+
+func TestSchedulerForegroundRunsFirst(t *testing.T) {
+	s := NewFetchScheduler(1)
+	block := make(chan struct{})
+	var order []string
+	var mu sync.Mutex
+
+	record := func(key string) func() error {
+		return func() error {
+			mu.Lock()
+			order = append(order, key)
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	first := s.Schedule("busy", func() error { <-block; return nil })
+	assert.Eventually(t, func() bool { return s.RunningCount() == 1 }, time.Second, 5*time.Millisecond)
+
+	bg := s.Schedule("background", record("background"))
+	s.SetForeground("onscreen")
+	fg := s.Schedule("onscreen", record("onscreen"))
+
+	// The foreground fetch does not wait behind the busy background slot
+	assert.NoError(t, <-fg)
+	close(block)
+	assert.NoError(t, <-first)
+	assert.NoError(t, <-bg)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"onscreen", "background"}, order)
+}
+
+func TestSchedulerDemotesPreviousForeground(t *testing.T) {
+	s := NewFetchScheduler(1)
+	blockBusy, blockOld := make(chan struct{}), make(chan struct{})
+	var order []string
+	var mu sync.Mutex
+
+	record := func(key string) func() error {
+		return func() error {
+			mu.Lock()
+			order = append(order, key)
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	busy := s.Schedule("busy", func() error { <-blockBusy; return nil })
+	assert.Eventually(t, func() bool { return s.RunningCount() == 1 }, time.Second, 5*time.Millisecond)
+	s.SetForeground("old")
+	oldRunning := s.Schedule("old", func() error { <-blockOld; return nil })
+	assert.Eventually(t, func() bool { return s.RunningCount() == 2 }, time.Second, 5*time.Millisecond)
+
+	bg := s.Schedule("background", record("background"))
+	s.Invalidate("old")
+	oldQueued := s.Schedule("old", record("old"))
+	assert.Equal(t, 2, s.QueuedCount())
+
+	// The new foreground fetch gets the extra slot the old foreground fetch was holding
+	s.SetForeground("new")
+	select {
+	case err := <-s.Schedule("new", record("new")):
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("foreground fetch waited behind the previous foreground fetch")
+	}
+
+	// The old foreground's queued fetch no longer runs ahead of earlier background fetches
+	close(blockOld)
+	assert.NoError(t, <-oldRunning)
+	close(blockBusy)
+	assert.NoError(t, <-busy)
+	assert.NoError(t, <-bg)
+	assert.NoError(t, <-oldQueued)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"new", "background", "old"}, order)
+}
+
+func TestSchedulerCoalescesQueuedKey(t *testing.T) {
+	s := NewFetchScheduler(1)
+	block := make(chan struct{})
+	var calls atomic.Int32
+
+	busy := s.Schedule("busy", func() error { <-block; return nil })
+	assert.Eventually(t, func() bool { return s.RunningCount() == 1 }, time.Second, 5*time.Millisecond)
+
+	fetch := func() error { calls.Add(1); return nil }
+	r1 := s.Schedule("same", fetch)
+	r2 := s.Schedule("same", fetch)
+	assert.Equal(t, 1, s.QueuedCount())
+
+	close(block)
+	assert.NoError(t, <-busy)
+	assert.NoError(t, <-r1)
+	assert.NoError(t, <-r2)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestSchedulerCancelQueued(t *testing.T) {
+	s := NewFetchScheduler(1)
+	block := make(chan struct{})
+
+	busy := s.Schedule("busy", func() error { <-block; return nil })
+	assert.Eventually(t, func() bool { return s.RunningCount() == 1 }, time.Second, 5*time.Millisecond)
+
+	queued := s.Schedule("queued", func() error { return nil })
+	assert.Equal(t, 1, s.CancelQueued())
+	assert.ErrorIs(t, <-queued, ErrFetchSuperseded)
+
+	close(block)
+	assert.NoError(t, <-busy)
+}
+
+func TestSchedulerJoinsRunningUntilInvalidated(t *testing.T) {
+	s := NewFetchScheduler(2)
+	block := make(chan struct{})
+	var calls atomic.Int32
+
+	fetch := func() error { calls.Add(1); <-block; return nil }
+	first := s.Schedule("key", fetch)
+	assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	joined := s.Schedule("key", fetch)
+	assert.Equal(t, 0, s.QueuedCount())
+
+	s.Invalidate("key")
+	fresh := s.Schedule("key", fetch)
+	assert.Equal(t, 1, s.QueuedCount())
+
+	close(block)
+	assert.NoError(t, <-first)
+	assert.NoError(t, <-joined)
+	assert.NoError(t, <-fresh)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -32,6 +32,7 @@ func (t *TestData) Model(chain, format string, verbose bool, extraOptions map[st
 // Mock observer for testing
 type MockObserver struct {
 	newItems     []*TestData
+	replaced     [][2]*TestData
 	stateChanges []struct {
 		state  types.StoreState
 		reason string
@@ -45,6 +46,12 @@ func (m *MockObserver) OnNewItem(item *TestData, index int) {
 	m.newItems = append(m.newItems, item)
 }
 
+func (m *MockObserver) OnItemReplaced(old, item *TestData) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.replaced = append(m.replaced, [2]*TestData{old, item})
+}
+
 func (m *MockObserver) OnStateChanged(state types.StoreState, reason string) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -1,9 +1,11 @@
 package store
 
 import (
+	"fmt"
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/logging"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
@@ -17,6 +19,12 @@ type FacetObserver[T any] interface {
 	OnStateChanged(state types.StoreState, reason string)
 }
 
+// ReplaceObserver is implemented by observers that hold a store's rows and must follow
+// ReplaceItem when it swaps one for another
+type ReplaceObserver[T any] interface {
+	OnItemReplaced(old, item *T)
+}
+
 type MappingFunc[T any] func(item *T) (key string, includeInMap bool)
 
 // Store handle the low-level data fetching and streaming from external systems
@@ -34,6 +42,8 @@ type Store[T any] struct {
 	summaryManager     *SummaryManager[T] // Manages aggregated summary data
 	mutex              sync.RWMutex
 	mapSortFunc        func(a, b *T) bool
+	retryPolicy        RetryPolicy
+	metrics            *metricsRecorder
 }
 
 // NewStore creates a new SDK-based store
@@ -52,11 +62,13 @@ func NewStore[T any](
 		contextKey:     contextKey,
 		state:          types.StateStale,
 		summaryManager: NewSummaryManager[T](),
+		retryPolicy:    DefaultRetryPolicy,
 	}
 	if mappingFunc != nil {
 		tempMap := make(map[interface{}]*T)
 		s.dataMap = &tempMap
 	}
+	s.metrics = newMetricsRecorder(s)
 	s.expectedTotalItems.Store(0)
 	return s
 }
@@ -201,6 +213,7 @@ func (s *Store[T]) Fetch() error {
 	s.expectedTotalItems.Store(0)
 	s.state = types.StateFetching
 	s.stateReason = "User reload - fetching data"
+	policy := s.retryPolicy
 
 	// Notify observers while holding the lock
 	currentObservers := make([]FacetObserver[T], len(s.observers))
@@ -209,9 +222,68 @@ func (s *Store[T]) Fetch() error {
 	for _, observer := range currentObservers {
 		observer.OnStateChanged(types.StateFetching, "User reload - fetching data")
 	}
+	s.metrics.begin()
+
+	received := 0
+	for attempt := 1; ; attempt++ {
+		renderCtx := RegisterContext(s.contextKey)
+		if attempt > 1 {
+			select {
+			case <-time.After(policy.Backoff(attempt - 1)):
+			case <-renderCtx.Ctx.Done():
+				msgs.EmitStatus("loading canceled")
+				s.metrics.end(renderCtx.Ctx.Err(), true)
+				s.ChangeState(types.StateLoaded, "User cancelled operation")
+				return renderCtx.Ctx.Err()
+			}
+		}
+
+		var fromStream bool
+		var err error
+		received, fromStream, err = s.fetchAttempt(renderCtx, received)
+		if err == nil {
+			s.metrics.end(nil, false)
+			s.ChangeState(types.StateLoaded, "Data loaded successfully")
+			return nil
+		}
+		if renderCtx.Ctx.Err() != nil {
+			msgs.EmitStatus("loading canceled")
+			s.metrics.end(renderCtx.Ctx.Err(), true)
+			s.ChangeState(types.StateLoaded, "User cancelled operation")
+			return renderCtx.Ctx.Err()
+		}
+
+		if attempt < policy.MaxAttempts && types.IsRetryableError(err) {
+			s.metrics.retry(err)
+			msgs.EmitStatus(fmt.Sprintf("retrying %s after error (attempt %d of %d): %v", s.contextKey, attempt+1, policy.MaxAttempts, err))
+			continue
+		}
+
+		source := "Query function failed"
+		partial := "Partial data loaded despite query error"
+		empty := "No data due to query error, ready to retry"
+		if fromStream {
+			source = "Stream error during fetch"
+			partial = "Partial data loaded despite stream error"
+			empty = "No data due to stream error, ready to retry"
+		}
+		msgs.EmitError(source, err)
+		s.metrics.end(err, false)
+		if s.Count() > 0 {
+			s.ChangeState(types.StateLoaded, partial)
+		} else {
+			s.ChangeState(types.StateStale, empty)
+		}
+		return err
+	}
+}
 
-	renderCtx := RegisterContext(s.contextKey)
+// fetchAttempt streams one run of the query function into the store. The first resumeAfter
+// raw items are skipped because an earlier attempt already delivered them. It returns the
+// total number of raw items seen so far and whether a failure came from the stream or the query.
+func (s *Store[T]) fetchAttempt(renderCtx *output.RenderCtx, resumeAfter int) (int, bool, error) {
 	errChan := make(chan error, 1)
+	received := 0
 
 	// Execute the query function which will fill the channels with streamed data
 	if s.queryFunc != nil {
@@ -232,9 +304,11 @@ func (s *Store[T]) Fetch() error {
 		case item, ok := <-renderCtx.ModelChan:
 			if !ok {
 				modelChanClosed = true
-				if errorChanClosed {
-					s.ChangeState(types.StateLoaded, "Data loaded successfully")
-				}
+				continue
+			}
+
+			received++
+			if received <= resumeAfter {
 				continue
 			}
 
@@ -259,6 +333,7 @@ func (s *Store[T]) Fetch() error {
 			s.data = append(s.data, itemPtr)
 			s.expectedTotalItems.Store(int64(len(s.data)))
 			index := len(s.data) - 1
+			s.metrics.item()
 
 			// TODO: BOGUS
 			// Note: Summary aggregation is now handled by the GetSummaryPage method in the backend
@@ -283,42 +358,25 @@ func (s *Store[T]) Fetch() error {
 		case streamErr, ok := <-renderCtx.ErrorChan:
 			if !ok {
 				errorChanClosed = true
-				if modelChanClosed {
-					s.ChangeState(types.StateLoaded, "Data loaded successfully")
-				}
 				continue
 			}
-			msgs.EmitError("Stream error during fetch", streamErr)
-			if s.Count() > 0 {
-				s.ChangeState(types.StateLoaded, "Partial data loaded despite stream error")
-			} else {
-				s.ChangeState(types.StateStale, "No data due to stream error, ready to retry")
-			}
-			return streamErr
+			return max(received, resumeAfter), true, streamErr
 
 		case <-renderCtx.Ctx.Done():
-			msgs.EmitStatus("loading canceled")
-			s.ChangeState(types.StateLoaded, "User cancelled operation")
-			return renderCtx.Ctx.Err()
+			return max(received, resumeAfter), false, renderCtx.Ctx.Err()
 
 		case queryErr := <-errChan:
-			msgs.EmitError("Query function failed", queryErr)
-			if s.Count() > 0 {
-				s.ChangeState(types.StateLoaded, "Partial data loaded despite query error")
-			} else {
-				s.ChangeState(types.StateStale, "No data due to query error, ready to retry")
-			}
-			return queryErr
+			return max(received, resumeAfter), false, queryErr
 		}
 	}
 
-	s.ChangeState(types.StateLoaded, "Data loaded successfully")
-	return nil
+	return max(received, resumeAfter), false, nil
 }
 
 func (s *Store[T]) AddItem(item *T, index int) {
 	s.mutex.Lock()
 	s.data = append(s.data, item)
+	s.expectedTotalItems.Store(int64(len(s.data)))
 	newIndex := len(s.data) - 1
 	itemPtr := s.data[newIndex]
 
@@ -346,11 +404,48 @@ func (s *Store[T]) AddItem(item *T, index int) {
 	}
 }
 
+// ReplaceItem swaps a row of the store for a new one and tells the observers. Rows are
+// replaced rather than changed in place so that readers holding the old row are not raced.
+func (s *Store[T]) ReplaceItem(old, item *T) {
+	s.mutex.Lock()
+	found := false
+	for i := len(s.data) - 1; i >= 0; i-- {
+		if s.data[i] == old {
+			s.data[i] = item
+			found = true
+			break
+		}
+	}
+	if !found {
+		s.mutex.Unlock()
+		return
+	}
+	if s.dataMap != nil && s.mappingFunc != nil {
+		if key, include := s.mappingFunc(old); include && (*s.dataMap)[key] == old {
+			delete(*s.dataMap, key)
+		}
+		if key, include := s.mappingFunc(item); include {
+			(*s.dataMap)[key] = item
+		}
+	}
+	observers := make([]FacetObserver[T], len(s.observers))
+	copy(observers, s.observers)
+	s.mutex.Unlock()
+
+	for _, observer := range observers {
+		if replacer, ok := observer.(ReplaceObserver[T]); ok {
+			replacer.OnItemReplaced(old, item)
+		}
+	}
+}
+
 func (s *Store[T]) GetExpectedTotal() int {
 	return int(s.expectedTotalItems.Load())
 }
 
 func (s *Store[T]) Reset() {
+	GetScheduler().Invalidate(s.contextKey)
+
 	s.mutex.Lock()
 	UnregisterContext(s.contextKey)
 
@@ -409,6 +504,7 @@ func (s *Store[T]) GetSummaries(period types.Period) []*T {
 func (s *Store[T]) AddBalance(item *T, index int) {
 	s.mutex.Lock()
 	s.data = append(s.data, item)
+	s.expectedTotalItems.Store(int64(len(s.data)))
 	newIndex := len(s.data) - 1
 	itemPtr := s.data[newIndex]
 
@@ -95,6 +95,33 @@ func TestStoreDataMapping(t *testing.T) {
 	assert.False(t, found)
 }
 
+func TestStoreReplaceItem(t *testing.T) {
+	store := NewStore("test-replace",
+		func(ctx *output.RenderCtx) error { return nil },
+		func(item interface{}) *TestData { return item.(*TestData) },
+		func(item *TestData) (string, bool) { return fmt.Sprintf("%d", item.ID), true })
+	observer := &MockObserver{}
+	store.RegisterObserver(observer)
+
+	old := &TestData{ID: 1, Name: "Old", Value: 1}
+	store.AddItem(old, 0)
+	store.AddItem(&TestData{ID: 2, Name: "Other", Value: 2}, 1)
+
+	item := &TestData{ID: 1, Name: "New", Value: 10}
+	store.ReplaceItem(old, item)
+	assert.Equal(t, item, store.GetItem(0))
+	assert.Equal(t, 2, store.Count())
+	retrieved, found := store.GetItemFromMap("1")
+	assert.True(t, found)
+	assert.Equal(t, item, retrieved)
+	assert.Equal(t, [][2]*TestData{{old, item}}, observer.replaced)
+
+	// Replacing a row the store does not hold changes nothing
+	store.ReplaceItem(old, &TestData{ID: 3})
+	assert.Equal(t, 2, store.Count())
+	assert.Len(t, observer.replaced, 1)
+}
+
 func TestStoreDataMappingWithExclusion(t *testing.T) {
 	mappingFunc := func(item *TestData) (string, bool) {
 		return fmt.Sprintf("%d", item.ID), item.ID%2 == 0
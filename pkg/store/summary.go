@@ -17,14 +17,16 @@ type SummaryKey struct {
 
 // SummaryManager manages aggregated summary data for different time periods
 type SummaryManager[T any] struct {
-	summaries map[SummaryKey][]*T
-	mutex     sync.RWMutex
+	summaries  map[SummaryKey][]*T
+	aggregates map[SummaryKey]*AggregateSummary
+	mutex      sync.RWMutex
 }
 
 // NewSummaryManager creates a new summary manager
 func NewSummaryManager[T any]() *SummaryManager[T] {
 	return &SummaryManager[T]{
-		summaries: make(map[SummaryKey][]*T),
+		summaries:  make(map[SummaryKey][]*T),
+		aggregates: make(map[SummaryKey]*AggregateSummary),
 	}
 }
 
@@ -77,28 +79,68 @@ func (sm *SummaryManager[T]) Reset() {
 	sm.mutex.Lock()
 	defer sm.mutex.Unlock()
 	sm.summaries = make(map[SummaryKey][]*T)
+	sm.aggregates = make(map[SummaryKey]*AggregateSummary)
 }
 
-// NormalizeToPeriod normalizes a timestamp to the start of the given period
+var (
+	periodConfig   types.PeriodConfig
+	periodLocation = time.UTC
+	periodConfigMu sync.RWMutex
+)
+
+// SetPeriodConfig sets the timezone, week start and fiscal year used by NormalizeToPeriod
+func SetPeriodConfig(cfg types.PeriodConfig) {
+	periodConfigMu.Lock()
+	defer periodConfigMu.Unlock()
+	periodConfig = cfg
+	periodLocation = cfg.Location()
+}
+
+// GetPeriodConfig returns the configuration used by NormalizeToPeriod
+func GetPeriodConfig() types.PeriodConfig {
+	periodConfigMu.RLock()
+	defer periodConfigMu.RUnlock()
+	return periodConfig
+}
+
+// NormalizeToPeriod normalizes a timestamp to the start of the given period using the current period config
 func NormalizeToPeriod(timestamp int64, period types.Period) int64 {
-	t := time.Unix(timestamp, 0).UTC()
+	periodConfigMu.RLock()
+	cfg, loc := periodConfig, periodLocation
+	periodConfigMu.RUnlock()
+	return normalizeToPeriod(timestamp, period, cfg, loc)
+}
+
+// NormalizeToPeriodWith normalizes a timestamp to the start of the given period using an explicit config
+func NormalizeToPeriodWith(timestamp int64, period types.Period, cfg types.PeriodConfig) int64 {
+	return normalizeToPeriod(timestamp, period, cfg, cfg.Location())
+}
+
+func normalizeToPeriod(timestamp int64, period types.Period, cfg types.PeriodConfig, loc *time.Location) int64 {
+	t := time.Unix(timestamp, 0).In(loc)
 
 	switch period {
 	case types.PeriodHourly:
-		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, time.UTC).Unix()
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc).Unix()
 	case types.PeriodDaily:
-		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix()
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Unix()
 	case types.PeriodWeekly:
-		// Start of week (Sunday)
-		days := int(t.Weekday())
-		return time.Date(t.Year(), t.Month(), t.Day()-days, 0, 0, 0, 0, time.UTC).Unix()
+		days := (int(t.Weekday()) - int(cfg.WeekStart) + 7) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-days, 0, 0, 0, 0, loc).Unix()
 	case types.PeriodMonthly:
-		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC).Unix()
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc).Unix()
 	case types.PeriodQuarterly:
 		quarter := ((int(t.Month())-1)/3)*3 + 1
-		return time.Date(t.Year(), time.Month(quarter), 1, 0, 0, 0, 0, time.UTC).Unix()
+		return time.Date(t.Year(), time.Month(quarter), 1, 0, 0, 0, 0, loc).Unix()
 	case types.PeriodAnnual:
-		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, loc).Unix()
+	case types.PeriodFiscalQuarterly:
+		// Months since the fiscal year began; time.Date normalizes a negative month into the prior year
+		sinceStart := (int(t.Month()) - int(cfg.FiscalStartMonth()) + 12) % 12
+		return time.Date(t.Year(), t.Month()-time.Month(sinceStart%3), 1, 0, 0, 0, 0, loc).Unix()
+	case types.PeriodFiscalAnnual:
+		sinceStart := (int(t.Month()) - int(cfg.FiscalStartMonth()) + 12) % 12
+		return time.Date(t.Year(), t.Month()-time.Month(sinceStart), 1, 0, 0, 0, 0, loc).Unix()
 	default: // PeriodBlockly
 		return timestamp // No normalization for block-level data
 	}
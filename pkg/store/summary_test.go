@@ -5,6 +5,9 @@ import (
 	"time"
 
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
 
 type TestItem struct {
@@ -88,3 +91,106 @@ func TestStoreWithSummary(t *testing.T) {
 		t.Errorf("Expected 2 items in daily summaries, got %d", len(summaries))
 	}
 }
+
+func TestNormalizeToPeriodWithConfig(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	accounting := types.PeriodConfig{
+		Timezone:        "America/New_York",
+		WeekStart:       time.Monday,
+		FiscalYearStart: time.April,
+	}
+
+	// 2024-01-03 02:30 UTC is still the evening of Tuesday 2024-01-02 in New York
+	ts := time.Date(2024, 1, 3, 2, 30, 0, 0, time.UTC).Unix()
+
+	tests := []struct {
+		name   string
+		period types.Period
+		cfg    types.PeriodConfig
+		want   time.Time
+	}{
+		{"utc daily", types.PeriodDaily, types.PeriodConfig{}, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"local daily", types.PeriodDaily, accounting, time.Date(2024, 1, 2, 0, 0, 0, 0, ny)},
+		{"utc sunday week", types.PeriodWeekly, types.PeriodConfig{}, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"local monday week", types.PeriodWeekly, accounting, time.Date(2024, 1, 1, 0, 0, 0, 0, ny)},
+		{"calendar annual", types.PeriodAnnual, accounting, time.Date(2024, 1, 1, 0, 0, 0, 0, ny)},
+		{"fiscal annual", types.PeriodFiscalAnnual, accounting, time.Date(2023, 4, 1, 0, 0, 0, 0, ny)},
+		{"fiscal quarter", types.PeriodFiscalQuarterly, accounting, time.Date(2024, 1, 1, 0, 0, 0, 0, ny)},
+		{"fiscal quarter spans year", types.PeriodFiscalQuarterly, types.PeriodConfig{FiscalYearStart: time.February}, time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"default fiscal quarter", types.PeriodFiscalQuarterly, types.PeriodConfig{}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"blockly", types.PeriodBlockly, accounting, time.Unix(ts, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeToPeriodWith(ts, tt.period, tt.cfg)
+			if got != tt.want.Unix() {
+				t.Errorf("got %v, want %v", time.Unix(got, 0).UTC(), tt.want.UTC())
+			}
+		})
+	}
+}
+
+func TestSetPeriodConfig(t *testing.T) {
+	defer SetPeriodConfig(types.PeriodConfig{})
+
+	ts := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC).Unix() // a Saturday
+	SetPeriodConfig(types.PeriodConfig{WeekStart: time.Saturday})
+	if got := NormalizeToPeriod(ts, types.PeriodWeekly); got != time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC).Unix() {
+		t.Errorf("expected week to start on the Saturday, got %v", time.Unix(got, 0).UTC())
+	}
+	if GetPeriodConfig().WeekStart != time.Saturday {
+		t.Errorf("expected config to be retained")
+	}
+}
+
+func TestSummaryAggregates(t *testing.T) {
+	sm := NewSummaryManager[TestItem]()
+	day1 := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC).Unix()
+	day2 := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC).Unix()
+
+	sm.Aggregate(AggregateInput{Timestamp: day1, Asset: "0xeth", Symbol: "ETH", In: base.NewWei(100), Balance: base.NewWei(100)}, types.PeriodMonthly)
+	sm.Aggregate(AggregateInput{Timestamp: day1, Asset: "0xeth", Out: base.NewWei(30), Gas: base.NewWei(2), Balance: base.NewWei(68)}, types.PeriodMonthly)
+	sm.Aggregate(AggregateInput{Timestamp: day2, Asset: "0xeth", In: base.NewWei(5), Balance: base.NewWei(73)}, types.PeriodMonthly)
+	sm.Aggregate(AggregateInput{Timestamp: day2, Asset: "0xdai", Symbol: "DAI", In: base.NewWei(7)}, types.PeriodMonthly)
+	sm.Aggregate(AggregateInput{Timestamp: day2, Asset: "0xdai", In: base.NewWei(7)}, types.PeriodDaily)
+
+	rows := sm.GetAggregates(types.PeriodMonthly)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 monthly rows, got %d", len(rows))
+	}
+	dai, eth := rows[0], rows[1]
+	if dai.Asset != "0xdai" || dai.Symbol != "DAI" || dai.Count != 1 || dai.SumIn.String() != "7" {
+		t.Errorf("unexpected dai row: %+v", dai)
+	}
+	if eth.Count != 3 || eth.Symbol != "ETH" {
+		t.Errorf("unexpected eth row: %+v", eth)
+	}
+	for name, got := range map[string]*base.Wei{
+		"sumIn": &eth.SumIn, "sumOut": &eth.SumOut, "netFlow": &eth.NetFlow,
+		"minBalance": &eth.MinBalance, "maxBalance": &eth.MaxBalance, "gasSpent": &eth.GasSpent,
+	} {
+		want := map[string]string{"sumIn": "105", "sumOut": "30", "netFlow": "75", "minBalance": "68", "maxBalance": "100", "gasSpent": "2"}[name]
+		if got.String() != want {
+			t.Errorf("%s: got %s, want %s", name, got.String(), want)
+		}
+	}
+
+	if err := SortAggregates(rows, sdk.SortSpec{Fields: []string{"sumIn"}, Order: []sdk.SortOrder{sdk.Dec}}); err != nil {
+		t.Fatal(err)
+	}
+	if rows[0].Asset != "0xeth" {
+		t.Errorf("expected eth first when sorted by sumIn descending")
+	}
+	if err := SortAggregates(rows, sdk.SortSpec{Fields: []string{"bogus"}}); err == nil {
+		t.Errorf("expected error for unknown sort field")
+	}
+
+	sm.Reset()
+	if len(sm.GetAggregates(types.PeriodMonthly)) != 0 {
+		t.Errorf("expected aggregates to be cleared by Reset")
+	}
+}
@@ -137,10 +137,12 @@ func isDupFunction() func(existing []*Function, newItem *Function) bool {
 		}
 		lastExistingLen = len(existing)
 
-		if seen[newItem.Encoding] {
+		// Different signatures with the same selector are kept so that collisions show
+		key := newItem.Encoding + functionSignature(newItem)
+		if seen[key] {
 			return true
 		}
-		seen[newItem.Encoding] = true
+		seen[key] = true
 		return false
 	}
 	// EXISTING_CODE
@@ -302,7 +304,7 @@ func (c *AbisCollection) ResetSummary() {
 	}
 }
 
-func (c *AbisCollection) ExportData(payload *types.Payload) (string, error) {
+func (c *AbisCollection) ExportData(payload *types.Payload) (string, int, error) {
 	switch payload.DataFacet {
 	case AbisDownloaded:
 		return c.downloadedFacet.ExportData(payload, string(AbisDownloaded))
@@ -313,7 +315,7 @@ func (c *AbisCollection) ExportData(payload *types.Payload) (string, error) {
 	case AbisEvents:
 		return c.eventsFacet.ExportData(payload, string(AbisEvents))
 	default:
-		return "", fmt.Errorf("[ExportData] unsupported abis facet: %s", payload.DataFacet)
+		return "", 0, fmt.Errorf("[ExportData] unsupported abis facet: %s", payload.DataFacet)
 	}
 }
 
@@ -1,14 +1,19 @@
 package abis
 
 import (
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
 
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/crud"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func assertAbisPage(t *testing.T, page types.Page) *AbisPage {
@@ -23,6 +28,123 @@ func assertAbisPage(t *testing.T, page types.Page) *AbisPage {
 	return abisPage
 }
 
+const testAbiJSON = `[
+	{"type":"constructor","inputs":[{"name":"owner","type":"address"}]},
+	{"type":"function","name":"approve","stateMutability":"nonpayable",
+		"inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}],
+		"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"allowance","stateMutability":"view",
+		"inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],
+		"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"event","name":"Approval","anonymous":false,
+		"inputs":[{"name":"owner","type":"address","indexed":true},{"name":"spender","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"fallback","stateMutability":"payable"}
+]`
+
+func TestAbiImport(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+		return path
+	}
+
+	saved := abisCachePath
+	defer func() { abisCachePath = saved }()
+	abisCachePath = func(chain, fileName string) string {
+		return filepath.Join(dir, "cache", chain, fileName+".json")
+	}
+
+	t.Run("ReadsPlainAbisAndArtifacts", func(t *testing.T) {
+		for name, contents := range map[string]string{
+			"plain.json":    testAbiJSON,
+			"hardhat.json":  `{"contractName":"Token","abi":` + testAbiJSON + `,"bytecode":"0x"}`,
+			"stringed.json": `{"abi":` + strconv.Quote(testAbiJSON) + `}`,
+		} {
+			data, err := readAbiFile(write(name, contents))
+			require.NoError(t, err, name)
+			assert.Equal(t, byte('['), data[0], name)
+		}
+	})
+
+	t.Run("RejectsFilesWithoutAnAbi", func(t *testing.T) {
+		for name, contents := range map[string]string{
+			"noabi.json":   `{"bytecode":"0x"}`,
+			"broken.json":  `[{"type":"function","name":`,
+			"notabi.json":  `[{"type":"function","inputs":[{"type":"notatype"}]}]`,
+			"garbage.json": `{not json`,
+		} {
+			_, err := readAbiFile(write(name, contents))
+			assert.Error(t, err, name)
+		}
+		_, err := readAbiFile(filepath.Join(dir, "missing.json"))
+		assert.Error(t, err)
+	})
+
+	t.Run("WritesTheAbiToTheCache", func(t *testing.T) {
+		address := base.HexToAddress("0x1234567890123456789012345678901234567890")
+		abi, err := importAbi("mainnet", AbiImport{Address: address, Path: write("artifact.json", `{"abi":`+testAbiJSON+`}`)})
+		require.NoError(t, err)
+		assert.Equal(t, address, abi.Address)
+		assert.Equal(t, int64(2), abi.NFunctions)
+		assert.Equal(t, int64(1), abi.NEvents)
+		assert.True(t, abi.HasConstructor)
+		assert.True(t, abi.HasFallback)
+		assert.False(t, abi.IsEmpty)
+
+		cached, err := os.ReadFile(abisCachePath("mainnet", address.Hex()))
+		require.NoError(t, err)
+		assert.Equal(t, testAbiJSON, string(cached))
+		assert.Equal(t, int64(len(cached)), abi.FileSize)
+
+		_, err = importAbi("mainnet", AbiImport{Path: write("other.json", testAbiJSON)})
+		assert.Error(t, err, "an address is required")
+	})
+
+	t.Run("CrudCreateListsTheImport", func(t *testing.T) {
+		payload := types.Payload{ActiveChain: "mainnet"}
+		collection := NewAbisCollection(&payload)
+		address := base.HexToAddress("0xabcdefabcdefabcdefabcdefabcdefabcdefabcd")
+		var item any = map[string]any{"address": address.Hex(), "path": write("crud.json", testAbiJSON)}
+		require.NoError(t, collection.Crud(&payload, crud.Create, &item))
+
+		found := false
+		for _, abi := range collection.downloadedFacet.GetStore().GetItems(false) {
+			found = found || (abi.Address == address && abi.NFunctions == 2)
+		}
+		assert.True(t, found)
+
+		var bad any = map[string]any{"address": address.Hex(), "path": write("bad.json", `{"bytecode":"0x"}`)}
+		assert.Error(t, collection.Crud(&payload, crud.Create, &bad))
+	})
+}
+
+func TestSelectorCollisions(t *testing.T) {
+	transferFrom := &Function{Name: "transferFrom", FunctionType: "function", Encoding: "0x23b872dd", Signature: "transferFrom(address,address,uint256)"}
+	gasprice := &Function{Name: "gasprice_bit_ether", FunctionType: "function", Encoding: "0x23B872DD", Signature: "gasprice_bit_ether(int128)"}
+	approve := &Function{Name: "approve", FunctionType: "function", Encoding: "0x095ea7b3", Signature: "approve(address,uint256)"}
+	approveAgain := *approve
+	event := &Function{Name: "Transfer", FunctionType: "event", Encoding: "0x23b872dd", Signature: "Transfer(address,address,uint256)"}
+
+	collisions := selectorCollisions([]*Function{transferFrom, approve, gasprice, &approveAgain, event, nil})
+	assert.Equal(t, map[string][]string{
+		"0x23b872dd": {"gasprice_bit_ether(int128)", "transferFrom(address,address,uint256)"},
+	}, collisions)
+
+	assert.Equal(t, []string{"gasprice_bit_ether(int128)"}, collidesWith(collisions, transferFrom))
+	assert.Equal(t, []string{"transferFrom(address,address,uint256)"}, collidesWith(collisions, gasprice))
+	assert.Empty(t, collidesWith(collisions, approve))
+
+	isDup := isDupFunction()
+	existing := []*Function{}
+	for _, fn := range []*Function{transferFrom, gasprice, approve, &approveAgain} {
+		if !isDup(existing, fn) {
+			existing = append(existing, fn)
+		}
+	}
+	assert.Len(t, existing, 3, "colliding functions are both kept, repeats are not")
+}
+
 // Domain-specific filtering and ABI logic tests for Abis collection
 
 func TestAbisMatchesFilter(t *testing.T) {
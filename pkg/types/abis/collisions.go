@@ -0,0 +1,56 @@
+package abis
+
+import (
+	"sort"
+	"strings"
+)
+
+// selectorCollisions maps each four-byte selector that more than one function signature
+// hashes to across the known ABIs to those signatures. A call to such a selector may be
+// decoded as the wrong function.
+func selectorCollisions(functions []*Function) map[string][]string {
+	bySelector := make(map[string]map[string]bool)
+	for _, fn := range functions {
+		if fn == nil || fn.FunctionType == "event" || fn.Encoding == "" {
+			continue
+		}
+		selector := strings.ToLower(fn.Encoding)
+		if bySelector[selector] == nil {
+			bySelector[selector] = make(map[string]bool)
+		}
+		bySelector[selector][functionSignature(fn)] = true
+	}
+
+	ret := make(map[string][]string)
+	for selector, signatures := range bySelector {
+		if len(signatures) < 2 {
+			continue
+		}
+		list := make([]string, 0, len(signatures))
+		for sig := range signatures {
+			list = append(list, sig)
+		}
+		sort.Strings(list)
+		ret[selector] = list
+	}
+	return ret
+}
+
+// collidesWith lists the other signatures that share the function's selector
+func collidesWith(collisions map[string][]string, fn *Function) []string {
+	ret := []string{}
+	own := functionSignature(fn)
+	for _, sig := range collisions[strings.ToLower(fn.Encoding)] {
+		if sig != own {
+			ret = append(ret, sig)
+		}
+	}
+	return ret
+}
+
+func functionSignature(fn *Function) string {
+	if fn.Signature != "" {
+		return fn.Signature
+	}
+	return fn.Name
+}
@@ -1,9 +1,12 @@
 package abis
 
 import (
+	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/signatures"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/names"
 
@@ -18,12 +21,43 @@ func (c *AbisCollection) Crud(
 	item interface{},
 ) error {
 	var abi = &Abi{Address: base.HexToAddress(payload.TargetAddress)}
-	if cast, ok := item.(*Abi); ok && cast != nil {
-		abi = cast
+	var imp = AbiImport{Address: abi.Address}
+	switch cast := item.(type) {
+	case *Abi:
+		if cast != nil {
+			abi = cast
+			imp.Address = cast.Address
+		}
+	case *AbiImport:
+		if cast != nil {
+			imp = *cast
+		}
+	case *any:
+		// From the frontend the item arrives as a decoded JSON object, which for an import
+		// carries the path of the file
+		if cast != nil && *cast != nil {
+			data, _ := json.Marshal(*cast)
+			if err := json.Unmarshal(data, &imp); err != nil && (op == crud.Create || op == crud.Update) {
+				return fmt.Errorf("item is not an ABI import: %w", err)
+			}
+			if imp.Address.IsZero() {
+				imp.Address = abi.Address
+			}
+			abi.Address = imp.Address
+		}
 	}
 
 	var err error
 	switch op {
+	case crud.Create, crud.Update:
+		if abi, err = importAbi(payload.ActiveChain, imp); err != nil {
+			msgs.EmitError("Abis.Crud.Import", err)
+			return err
+		}
+		// Create adds the listing if it is new and the imported functions change what collides
+		op = crud.Create
+		c.functionsFacet.Reset()
+		c.eventsFacet.Reset()
 	case crud.Autoname:
 		if err = names.AutonameAddress(abi.Address.Hex()); err != nil {
 			msgs.EmitError("Abis.Crud.Autoname", err)
@@ -53,7 +87,10 @@ func (c *AbisCollection) Crud(
 		return result
 	})
 	c.downloadedFacet.SyncWithStore()
+	abiChanged(abi.Address)
 	switch op {
+	case crud.Create:
+		msgs.EmitStatus(fmt.Sprintf("imported ABI for address: %s", abi.Address))
 	case crud.Remove:
 		if removedCount > 0 {
 			msgs.EmitStatus(fmt.Sprintf("deleted ABI for address: %s", abi.Address))
@@ -65,6 +102,28 @@ func (c *AbisCollection) Crud(
 	return nil
 }
 
+var (
+	abiChangedFuncs []func(address base.Address)
+	abiChangedMu    sync.RWMutex
+)
+
+// OnAbiChanged registers fn to be called after an address's ABI is imported or removed so
+// that packages holding a resolved copy can drop it
+func OnAbiChanged(fn func(address base.Address)) {
+	abiChangedMu.Lock()
+	defer abiChangedMu.Unlock()
+	abiChangedFuncs = append(abiChangedFuncs, fn)
+}
+
+func abiChanged(address base.Address) {
+	abiChangedMu.RLock()
+	funcs := append([]func(base.Address){}, abiChangedFuncs...)
+	abiChangedMu.RUnlock()
+	for _, fn := range funcs {
+		fn(address)
+	}
+}
+
 func (c *AbisCollection) updateAbiInData(data []*Abi, abi *Abi, op crud.Operation) ([]*Abi, int) {
 	count := 0
 	switch op {
@@ -101,4 +160,52 @@ func (c *AbisCollection) updateAbiInData(data []*Abi, abi *Abi, op crud.Operatio
 	}
 }
 
+// ResolveAbi returns the address's ABI with its functions and events, downloading it if the
+// cache does not have it yet. A newly downloaded ABI is added to the downloaded facet.
+func (c *AbisCollection) ResolveAbi(payload *types.Payload, address base.Address) (*Abi, error) {
+	opts := sdk.AbisOptions{
+		Addrs:   []string{address.Hex()},
+		Globals: sdk.Globals{Cache: true, Chain: payload.ActiveChain},
+	}
+	functions, _, err := opts.Abis()
+	if err != nil {
+		return nil, err
+	}
+
+	abi := &Abi{Address: address, Name: names.NameAddress(address), IsEmpty: len(functions) == 0}
+	for _, fn := range functions {
+		countFunction(abi, fn.FunctionType)
+	}
+	signatures.AddFunctions(functions...)
+
+	store := c.downloadedFacet.GetStore()
+	store.UpdateData(func(data []*Abi) []*Abi {
+		for _, existing := range data {
+			if existing.Address == address {
+				abi.Path, abi.FileSize, abi.LastModDate, abi.IsKnown = existing.Path, existing.FileSize, existing.LastModDate, existing.IsKnown
+				return data
+			}
+		}
+		listed := *abi
+		return append(data, &listed)
+	})
+	c.downloadedFacet.SyncWithStore()
+
+	abi.Functions = functions
+	return abi, nil
+}
+
+// SeedSignatures adds the functions and events of every cached ABI on the chain to the
+// signature database and returns how many were new
+func SeedSignatures(chain string) (int, error) {
+	opts := sdk.AbisOptions{
+		Globals: sdk.Globals{Cache: true, Chain: chain},
+	}
+	functions, _, err := opts.AbisDetails()
+	if err != nil {
+		return 0, err
+	}
+	return signatures.AddFunctions(functions...), nil
+}
+
 // TODO: Consider adding batch operations for Abis, similar to MonitorsCollection.Clean (e.g., batch remove).
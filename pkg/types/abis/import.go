@@ -0,0 +1,108 @@
+package abis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/names"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/abi"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	ethAbi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// AbiImport asks Crud to associate the ABI in a file with an address
+type AbiImport struct {
+	Address base.Address `json:"address"`
+	Path    string       `json:"path"`
+}
+
+// abisCachePath is where chifra looks for an address's ABI before downloading it
+var abisCachePath = abi.PathToAbisCache
+
+// readAbiFile returns the ABI in a file, which may be a plain ABI or a compiler artifact
+// that carries it under "abi", such as Hardhat's and Foundry's build output
+func readAbiFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var artifact struct {
+			Abi json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return nil, fmt.Errorf("%s is not valid JSON: %w", filepath.Base(path), err)
+		}
+		if len(artifact.Abi) == 0 {
+			return nil, fmt.Errorf("%s has no abi field", filepath.Base(path))
+		}
+		data = bytes.TrimSpace(artifact.Abi)
+		// Some tools store the ABI as a string of JSON
+		var str string
+		if json.Unmarshal(data, &str) == nil {
+			data = []byte(str)
+		}
+	}
+	if _, err := ethAbi.JSON(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("%s is not an ABI: %w", filepath.Base(path), err)
+	}
+	return data, nil
+}
+
+// importAbi writes the file's ABI to the cache as the address's ABI and returns its listing
+func importAbi(chain string, imp AbiImport) (*Abi, error) {
+	if imp.Address.IsZero() {
+		return nil, fmt.Errorf("an address is required to import an ABI")
+	}
+	if imp.Path == "" {
+		return nil, fmt.Errorf("a file is required to import an ABI")
+	}
+	data, err := readAbiFile(imp.Path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []struct {
+		Type string `json:"type"`
+	}
+	_ = json.Unmarshal(data, &entries)
+
+	fullPath := abisCachePath(chain, imp.Address.Hex())
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(fullPath, data, 0666); err != nil {
+		return nil, err
+	}
+
+	ret := &Abi{
+		Address:     imp.Address,
+		Name:        names.NameAddress(imp.Address),
+		Path:        fullPath,
+		FileSize:    int64(len(data)),
+		LastModDate: time.Now().Format("2006-01-02 15:04:05"),
+	}
+	for _, entry := range entries {
+		countFunction(ret, entry.Type)
+	}
+	ret.IsEmpty = len(entries) == 0
+	return ret, nil
+}
+
+func countFunction(abi *Abi, functionType string) {
+	switch functionType {
+	case "function":
+		abi.NFunctions++
+	case "event":
+		abi.NEvents++
+	case "constructor":
+		abi.HasConstructor = true
+	case "fallback", "receive":
+		abi.HasFallback = true
+	}
+}
@@ -27,6 +27,10 @@ type AbisPage struct {
 	ExpectedTotal int              `json:"expectedTotal"`
 	State         types.StoreState `json:"state"`
 	// EXISTING_CODE
+	// CollidesWith lines up with Functions and lists the other signatures that share each
+	// function's selector. NCollisions is the number of selectors that collide.
+	CollidesWith [][]string `json:"collidesWith"`
+	NCollisions  int        `json:"nCollisions"`
 	// EXISTING_CODE
 }
 
@@ -146,6 +150,16 @@ func (c *AbisCollection) GetPage(
 			fmt.Errorf("[GetPage] unsupported dataFacet: %v", payload.DataFacet))
 	}
 
+	// EXISTING_CODE
+	if dataFacet == AbisFunctions {
+		collisions := selectorCollisions(c.functionsFacet.GetStore().GetItems(false))
+		page.NCollisions = len(collisions)
+		page.CollidesWith = make([][]string, len(page.Functions))
+		for i := range page.Functions {
+			page.CollidesWith[i] = collidesWith(collisions, &page.Functions[i])
+		}
+	}
+	// EXISTING_CODE
 	return page, nil
 }
 
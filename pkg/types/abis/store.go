@@ -14,6 +14,7 @@ import (
 	"sync"
 
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/signatures"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/store"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/names"
@@ -117,6 +118,7 @@ func (c *AbisCollection) getFunctionsStore(payload *types.Payload, facet types.D
 		processFunc := func(item interface{}) *Function {
 			if it, ok := item.(*Function); ok {
 				// EXISTING_CODE
+				signatures.AddFunctions(*it)
 				// EXISTING_CODE
 				return it
 			}
@@ -158,6 +160,10 @@ func (c *AbisCollection) getStoreName(payload *types.Payload, facet types.DataFa
 		return ""
 	}
 	name = fmt.Sprintf("%s-%s-%s", name, payload.ActiveChain, payload.ActiveAddress)
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
 	return name
 }
 
@@ -293,7 +293,7 @@ func (c *ChunksCollection) ResetSummary() {
 	}
 }
 
-func (c *ChunksCollection) ExportData(payload *types.Payload) (string, error) {
+func (c *ChunksCollection) ExportData(payload *types.Payload) (string, int, error) {
 	switch payload.DataFacet {
 	case ChunksStats:
 		return c.statsFacet.ExportData(payload, string(ChunksStats))
@@ -304,7 +304,7 @@ func (c *ChunksCollection) ExportData(payload *types.Payload) (string, error) {
 	case ChunksManifest:
 		return c.manifestFacet.ExportData(payload, string(ChunksManifest))
 	default:
-		return "", fmt.Errorf("[ExportData] unsupported chunks facet: %s", payload.DataFacet)
+		return "", 0, fmt.Errorf("[ExportData] unsupported chunks facet: %s", payload.DataFacet)
 	}
 }
 
@@ -148,6 +148,8 @@ func (c *ChunksCollection) GetPage(
 			fmt.Errorf("[GetPage] unsupported dataFacet: %v", payload.DataFacet))
 	}
 
+	// EXISTING_CODE
+	// EXISTING_CODE
 	return page, nil
 }
 
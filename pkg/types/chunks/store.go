@@ -284,6 +284,10 @@ func (c *ChunksCollection) getStoreName(payload *types.Payload, facet types.Data
 		return ""
 	}
 	name = fmt.Sprintf("%s-%s-%s", name, payload.ActiveChain, payload.ActiveAddress)
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
 	return name
 }
 
@@ -17,7 +17,7 @@ type Collection interface {
 	Reset(payload *Payload)
 	NeedsUpdate(payload *Payload) bool
 	GetSummary(payload *Payload) Summary
-	ExportData(payload *Payload) (string, error)
+	ExportData(payload *Payload) (string, int, error)
 	ChangeVisibility(payload *Payload) error
 	GetConfig() (*ViewConfig, error)
 	SummaryAccumulator
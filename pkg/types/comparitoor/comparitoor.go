@@ -107,31 +107,31 @@ func (c *ComparitoorCollection) initializeFacets(payload *types.Payload) {
 
 func isComparitoor(item *Transaction) bool {
 	// EXISTING_CODE
-	return true
+	return tagOf(item).comparison != nil
 	// EXISTING_CODE
 }
 
 func isChifra(item *Transaction) bool {
 	// EXISTING_CODE
-	return true
+	return tagOf(item).source == string(ComparitoorChifra)
 	// EXISTING_CODE
 }
 
 func isEtherscan(item *Transaction) bool {
 	// EXISTING_CODE
-	return true
+	return tagOf(item).source == string(ComparitoorEtherscan)
 	// EXISTING_CODE
 }
 
 func isCovalent(item *Transaction) bool {
 	// EXISTING_CODE
-	return true
+	return tagOf(item).source == string(ComparitoorCovalent)
 	// EXISTING_CODE
 }
 
 func isAlchemy(item *Transaction) bool {
 	// EXISTING_CODE
-	return true
+	return tagOf(item).source == string(ComparitoorAlchemy)
 	// EXISTING_CODE
 }
 
@@ -145,7 +145,12 @@ func isDupTransaction() func(existing []*Transaction, newItem *Transaction) bool
 			if it == nil {
 				continue
 			}
-			if it.BlockNumber == newItem.BlockNumber && it.TransactionIndex == newItem.TransactionIndex {
+			// Transfers from some sources carry a hash but no transaction index
+			if !it.Hash.IsZero() && !newItem.Hash.IsZero() {
+				if it.Hash == newItem.Hash {
+					return true
+				}
+			} else if it.BlockNumber == newItem.BlockNumber && it.TransactionIndex == newItem.TransactionIndex {
 				return true
 			}
 		}
@@ -232,8 +237,7 @@ func (c *ComparitoorCollection) AccumulateItem(item interface{}, summary *types.
 		summary.FacetCounts = make(map[types.DataFacet]int)
 	}
 	summary.TotalCount++
-	// We cannot easily know which facet invoked this from here without extra context; leave hook.
-	// Optionally could classify by inspecting item fields later.
+	// Reconciliation counts are set on the summary when the sources are compared
 	// EXISTING_CODE
 }
 
@@ -269,7 +273,7 @@ func (c *ComparitoorCollection) ResetSummary() {
 	}
 }
 
-func (c *ComparitoorCollection) ExportData(payload *types.Payload) (string, error) {
+func (c *ComparitoorCollection) ExportData(payload *types.Payload) (string, int, error) {
 	switch payload.DataFacet {
 	case ComparitoorComparitoor:
 		return c.comparitoorFacet.ExportData(payload, string(ComparitoorComparitoor))
@@ -282,7 +286,7 @@ func (c *ComparitoorCollection) ExportData(payload *types.Payload) (string, erro
 	case ComparitoorAlchemy:
 		return c.alchemyFacet.ExportData(payload, string(ComparitoorAlchemy))
 	default:
-		return "", fmt.Errorf("[ExportData] unsupported comparitoor facet: %s", payload.DataFacet)
+		return "", 0, fmt.Errorf("[ExportData] unsupported comparitoor facet: %s", payload.DataFacet)
 	}
 }
 
@@ -293,4 +297,39 @@ func (c *ComparitoorCollection) ChangeVisibility(payload *types.Payload) error {
 }
 
 // EXISTING_CODE
+
+// setComparisonSummary puts the report's per-class counts, the providers that failed and
+// the providers' scorecard in the summary's custom data
+func (c *ComparitoorCollection) setComparisonSummary(report *Report, scorecard *Scorecard) {
+	c.summaryMutex.Lock()
+	defer c.summaryMutex.Unlock()
+	if c.summary.CustomData == nil {
+		c.summary.CustomData = make(map[string]interface{})
+	}
+	classes := make(map[string]int)
+	for class, count := range report.Counts() {
+		classes[string(class)] = count
+	}
+	c.summary.CustomData["sources"] = report.Sources
+	c.summary.CustomData["failed"] = report.Failed
+	c.summary.CustomData["classes"] = classes
+	c.summary.CustomData["comparisons"] = len(report.Comparisons)
+	c.summary.CustomData["scorecard"] = scorecard
+	c.summary.LastUpdated = time.Now().Unix()
+}
+
+// setScorecardBuckets charts every provider's scores on the comparitoor facet and each
+// provider's own on its facet
+func (c *ComparitoorCollection) setScorecardBuckets(scorecard *Scorecard) {
+	c.comparitoorFacet.SetBuckets(scorecard.Buckets())
+	for name, facet := range map[types.DataFacet]*facets.Facet[Transaction]{
+		ComparitoorChifra:    c.chifraFacet,
+		ComparitoorEtherscan: c.etherscanFacet,
+		ComparitoorCovalent:  c.covalentFacet,
+		ComparitoorAlchemy:   c.alchemyFacet,
+	} {
+		facet.SetBuckets(scorecard.ProviderBuckets(string(name)))
+	}
+}
+
 // EXISTING_CODE
@@ -0,0 +1,319 @@
+package comparitoor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// Class is how a transaction reconciles across the sources
+type Class string
+
+const (
+	PresentInAll  Class = "present-in-all"
+	MissingFrom   Class = "missing-from"
+	ExtraIn       Class = "extra-in"
+	FieldMismatch Class = "field-mismatch"
+)
+
+// Source is the list of transactions one provider reported, or the error it failed with
+type Source struct {
+	Name         string
+	Transactions []*Transaction
+	Err          error
+}
+
+// FieldDiff is a field the sources disagree on with each source's value
+type FieldDiff struct {
+	Field  string            `json:"field"`
+	Values map[string]string `json:"values"`
+}
+
+// Comparison is one transaction matched across the sources
+type Comparison struct {
+	Key              string      `json:"key"`
+	BlockNumber      base.Blknum `json:"blockNumber"`
+	TransactionIndex base.Txnum  `json:"transactionIndex"`
+	Hash             base.Hash   `json:"hash"`
+	Class            Class       `json:"class"`
+	Label            string      `json:"label"`
+	Category         Category    `json:"category"`
+	Present          []string    `json:"present"`
+	Missing          []string    `json:"missing"`
+	Diffs            []FieldDiff `json:"diffs,omitempty"`
+	found            map[string]*Transaction
+	representative   *Transaction
+}
+
+// Report is the result of reconciling the sources
+type Report struct {
+	Sources     []string          `json:"sources"`
+	Failed      map[string]string `json:"failed,omitempty"`
+	Comparisons []Comparison      `json:"comparisons"`
+	index       map[string]int
+	aliases     map[string]string
+	tagged      []*Transaction
+}
+
+// comparedFields are checked between every pair of sources that report a transaction's details
+var comparedFields = []struct {
+	name  string
+	value func(tx *Transaction) string
+}{
+	{"value", func(tx *Transaction) string { return tx.Value.String() }},
+	{"gasUsed", func(tx *Transaction) string { return fmt.Sprint(tx.GasUsed) }},
+	{"gasPrice", func(tx *Transaction) string { return fmt.Sprint(tx.GasPrice) }},
+	{"isError", func(tx *Transaction) string { return fmt.Sprint(tx.IsError) }},
+}
+
+// transactionKey matches by hash. Rows without a hash, such as internal traces, match by
+// block and transaction index.
+func transactionKey(tx *Transaction) string {
+	if !tx.Hash.IsZero() {
+		return strings.ToLower(tx.Hash.Hex())
+	}
+	return blockIndexKey(tx)
+}
+
+func blockIndexKey(tx *Transaction) string {
+	return fmt.Sprintf("%d.%d", tx.BlockNumber, tx.TransactionIndex)
+}
+
+// hasDetails is false for sources that list a transaction without its receipt (Alchemy's
+// transfers, for example). Their zeroed fields are not compared.
+func hasDetails(tx *Transaction) bool {
+	return tx.Timestamp != 0 || tx.GasUsed != 0
+}
+
+// Compare reconciles the sources' transactions. A source that returned nothing at all is
+// left out rather than reported missing on every row. One that failed is also listed in
+// Failed with its error.
+func Compare(sources []Source) *Report {
+	report := &Report{
+		Sources:     []string{},
+		Failed:      make(map[string]string),
+		Comparisons: []Comparison{},
+		index:       make(map[string]int),
+		aliases:     make(map[string]string),
+	}
+	for _, src := range sources {
+		if src.Err != nil {
+			report.Failed[src.Name] = src.Err.Error()
+		}
+		if len(src.Transactions) > 0 {
+			report.Sources = append(report.Sources, src.Name)
+		}
+	}
+
+	// Hashed rows claim their block and index so hashless rows for the same transaction join
+	// them. Rows without details may not know their index.
+	aliases := report.aliases
+	for _, src := range sources {
+		for _, tx := range src.Transactions {
+			if tx != nil && !tx.Hash.IsZero() && hasDetails(tx) {
+				aliases[blockIndexKey(tx)] = transactionKey(tx)
+			}
+		}
+	}
+
+	for _, src := range sources {
+		for _, tx := range src.Transactions {
+			if tx == nil {
+				continue
+			}
+			key := transactionKey(tx)
+			if alias, ok := aliases[key]; ok {
+				key = alias
+			}
+			i, ok := report.index[key]
+			if !ok {
+				i = len(report.Comparisons)
+				report.index[key] = i
+				report.Comparisons = append(report.Comparisons, Comparison{
+					Key:              key,
+					BlockNumber:      tx.BlockNumber,
+					TransactionIndex: tx.TransactionIndex,
+					Hash:             tx.Hash,
+					found:            make(map[string]*Transaction),
+				})
+			}
+			cmp := &report.Comparisons[i]
+			if prev, seen := cmp.found[src.Name]; !seen || (!hasDetails(prev) && hasDetails(tx)) {
+				cmp.found[src.Name] = tx
+			}
+			if cmp.Hash.IsZero() {
+				cmp.Hash = tx.Hash
+			}
+		}
+	}
+
+	for i := range report.Comparisons {
+		report.Comparisons[i].classify(report.Sources)
+	}
+	sort.SliceStable(report.Comparisons, func(i, j int) bool {
+		a, b := report.Comparisons[i], report.Comparisons[j]
+		if a.BlockNumber != b.BlockNumber {
+			return a.BlockNumber < b.BlockNumber
+		}
+		return a.TransactionIndex < b.TransactionIndex
+	})
+	for i := range report.Comparisons {
+		report.index[report.Comparisons[i].Key] = i
+	}
+	return report
+}
+
+func (c *Comparison) classify(sources []string) {
+	c.Present, c.Missing = []string{}, []string{}
+	for _, name := range sources {
+		tx, ok := c.found[name]
+		if !ok {
+			c.Missing = append(c.Missing, name)
+			continue
+		}
+		c.Present = append(c.Present, name)
+		if c.representative == nil || (!hasDetails(c.representative) && hasDetails(tx)) {
+			c.representative = tx
+		}
+	}
+	if c.representative != nil {
+		c.BlockNumber, c.TransactionIndex = c.representative.BlockNumber, c.representative.TransactionIndex
+	}
+	c.Category = c.category()
+
+	for _, field := range comparedFields {
+		values := make(map[string]string)
+		distinct := make(map[string]bool)
+		for _, name := range c.Present {
+			if tx := c.found[name]; hasDetails(tx) {
+				v := field.value(tx)
+				values[name] = v
+				distinct[v] = true
+			}
+		}
+		if len(distinct) > 1 {
+			c.Diffs = append(c.Diffs, FieldDiff{Field: field.name, Values: values})
+		}
+	}
+
+	switch {
+	case len(c.Missing) == 0 && len(c.Diffs) > 0:
+		c.Class, c.Label = FieldMismatch, string(FieldMismatch)
+	case len(c.Missing) == 0:
+		c.Class, c.Label = PresentInAll, string(PresentInAll)
+	case len(c.Present) == 1 && len(sources) > 2:
+		c.Class, c.Label = ExtraIn, string(ExtraIn)+"-"+c.Present[0]
+	default:
+		c.Class, c.Label = MissingFrom, string(MissingFrom)+"-"+strings.Join(c.Missing, "-")
+	}
+}
+
+// category is failed if any source says so, then internal for rows without a hash and
+// token if any source saw a token move
+func (c *Comparison) category() Category {
+	token := false
+	for _, tx := range c.found {
+		if tx.IsError {
+			return Failed
+		}
+		token = token || tx.HasToken
+	}
+	switch {
+	case c.Hash.IsZero():
+		return Internal
+	case token:
+		return TokenTransfer
+	default:
+		return External
+	}
+}
+
+// Lookup finds the comparison a transaction from any of the sources belongs to
+func (r *Report) Lookup(tx *Transaction) (*Comparison, bool) {
+	if r == nil || tx == nil {
+		return nil, false
+	}
+	key := transactionKey(tx)
+	if alias, ok := r.aliases[key]; ok {
+		key = alias
+	}
+	if i, ok := r.index[key]; ok {
+		return &r.Comparisons[i], true
+	}
+	return nil, false
+}
+
+// Counts returns the number of comparisons in each class
+func (r *Report) Counts() map[Class]int {
+	ret := make(map[Class]int)
+	if r == nil {
+		return ret
+	}
+	for _, cmp := range r.Comparisons {
+		ret[cmp.Class]++
+	}
+	return ret
+}
+
+// Representatives returns one transaction per comparison, preferring a source that
+// reported its details
+func (r *Report) Representatives() []*Transaction {
+	ret := make([]*Transaction, 0, len(r.Comparisons))
+	for _, cmp := range r.Comparisons {
+		ret = append(ret, cmp.representative)
+	}
+	return ret
+}
+
+// sourceTag records which source a stored transaction came from and, for the
+// comparitoor facet, the comparison it represents
+type sourceTag struct {
+	source     string
+	comparison *Comparison
+}
+
+var (
+	reports   = make(map[string]*Report)
+	tags      = make(map[*Transaction]sourceTag)
+	reportsMu sync.RWMutex
+)
+
+// setReport replaces the report for a store and re-tags its transactions
+func setReport(storeKey string, sources []Source, report *Report) {
+	reportsMu.Lock()
+	defer reportsMu.Unlock()
+	if prev := reports[storeKey]; prev != nil {
+		for _, tx := range prev.tagged {
+			delete(tags, tx)
+		}
+	}
+	reports[storeKey] = report
+	for _, src := range sources {
+		for _, tx := range src.Transactions {
+			tags[tx] = sourceTag{source: src.Name}
+			report.tagged = append(report.tagged, tx)
+		}
+	}
+	for i := range report.Comparisons {
+		cmp := &report.Comparisons[i]
+		if t, ok := tags[cmp.representative]; ok {
+			t.comparison = cmp
+			tags[cmp.representative] = t
+		}
+	}
+}
+
+func getReport(storeKey string) *Report {
+	reportsMu.RLock()
+	defer reportsMu.RUnlock()
+	return reports[storeKey]
+}
+
+func tagOf(tx *Transaction) sourceTag {
+	reportsMu.RLock()
+	defer reportsMu.RUnlock()
+	return tags[tx]
+}
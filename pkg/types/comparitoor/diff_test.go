@@ -0,0 +1,123 @@
+package comparitoor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// standInProviders reads every source from its stand-in in testdata
+func standInProviders() []Provider {
+	var ret []Provider
+	for _, facet := range []types.DataFacet{ComparitoorChifra, ComparitoorEtherscan, ComparitoorCovalent, ComparitoorAlchemy} {
+		ret = append(ret, NewFileProvider(string(facet), filepath.Join("testdata", string(facet)+".csv")))
+	}
+	return ret
+}
+
+// standIns reads every source from its stand-in in testdata
+func standIns(t *testing.T) []Source {
+	t.Helper()
+	var sources []Source
+	for _, p := range standInProviders() {
+		txs, err := p.Transactions(context.Background(), "mainnet", base.Address{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sources = append(sources, Source{Name: p.Name(), Transactions: txs})
+	}
+	return sources
+}
+
+func TestParseCSVByHeader(t *testing.T) {
+	sources := standIns(t)
+	chifra, etherscan, covalent, alchemy := sources[0].Transactions, sources[1].Transactions, sources[2].Transactions, sources[3].Transactions
+	if len(chifra) != 4264 || len(etherscan) != 706 || len(covalent) != 0 || len(alchemy) != 3239 {
+		t.Fatalf("unexpected row counts %d %d %d %d", len(chifra), len(etherscan), len(covalent), len(alchemy))
+	}
+	// The sources order their columns differently
+	c, e := chifra[0], etherscan[0]
+	if c.Hash != e.Hash || c.Value.String() != "5000000000000000000" || e.Value.String() != c.Value.String() || c.GasUsed != 21000 || e.GasPrice != c.GasPrice {
+		t.Errorf("columns were not read by name: %+v %+v", c, e)
+	}
+}
+
+func TestCompareTestdata(t *testing.T) {
+	report := Compare(standIns(t))
+	if len(report.Sources) != 3 {
+		t.Fatalf("expected the empty covalent source to be left out, got %v", report.Sources)
+	}
+
+	counts := report.Counts()
+	if len(report.Comparisons) != 4264 {
+		t.Errorf("expected one comparison per chifra transaction, got %d", len(report.Comparisons))
+	}
+	if counts[PresentInAll]+counts[FieldMismatch] != 361 {
+		t.Errorf("expected 361 transactions in every source, got %v", counts)
+	}
+	if counts[ExtraIn] != 946 || counts[ExtraIn]+counts[MissingFrom] != 4264-361 {
+		t.Errorf("unexpected class counts %v", counts)
+	}
+
+	var mismatches int
+	for _, cmp := range report.Comparisons {
+		for _, diff := range cmp.Diffs {
+			if diff.Field != "isError" || diff.Values["chifra"] != "true" || diff.Values["etherscan"] != "false" {
+				t.Errorf("unexpected diff %+v on %s", diff, cmp.Key)
+			}
+			// Alchemy lists transactions without details, so it takes no side
+			if _, ok := diff.Values["alchemy"]; ok {
+				t.Errorf("alchemy's zeroed fields were compared on %s", cmp.Key)
+			}
+			mismatches++
+		}
+	}
+	if mismatches != 12 {
+		t.Errorf("expected 12 isError mismatches between chifra and etherscan, got %d", mismatches)
+	}
+}
+
+func TestCompareClasses(t *testing.T) {
+	tx := func(hash string, blk, idx, gas uint64) *Transaction {
+		ret := &Transaction{BlockNumber: base.Blknum(blk), TransactionIndex: base.Txnum(idx), GasUsed: base.Gas(gas), Timestamp: 1}
+		if hash != "" {
+			ret.Hash = base.HexToHash(hash)
+		}
+		return ret
+	}
+	report := Compare([]Source{
+		{Name: "a", Transactions: []*Transaction{tx("0x01", 1, 0, 21000), tx("0x02", 2, 0, 21000), tx("0x03", 3, 0, 21000)}},
+		{Name: "b", Transactions: []*Transaction{tx("0x01", 1, 0, 21000), tx("0x02", 2, 0, 30000), tx("", 3, 0, 21000)}},
+		{Name: "c", Transactions: []*Transaction{tx("0x01", 1, 0, 21000), tx("0x02", 2, 0, 21000), tx("0x04", 4, 1, 21000)}},
+		{Name: "d"},
+	})
+
+	want := []struct {
+		class Class
+		label string
+	}{
+		{PresentInAll, "present-in-all"},
+		{FieldMismatch, "field-mismatch"},
+		{MissingFrom, "missing-from-c"}, // b's trace matched by block and index
+		{ExtraIn, "extra-in-c"},
+	}
+	if len(report.Comparisons) != len(want) {
+		t.Fatalf("expected %d comparisons, got %+v", len(want), report.Comparisons)
+	}
+	for i, w := range want {
+		if cmp := report.Comparisons[i]; cmp.Class != w.class || cmp.Label != w.label {
+			t.Errorf("comparison %d: got %s %s, want %s %s", i, cmp.Class, cmp.Label, w.class, w.label)
+		}
+	}
+	if diffs := report.Comparisons[1].Diffs; len(diffs) != 1 || diffs[0].Field != "gasUsed" || diffs[0].Values["b"] != "30000" {
+		t.Errorf("unexpected diffs %+v", diffs)
+	}
+
+	trace := tx("", 3, 0, 0)
+	if cmp, ok := report.Lookup(trace); !ok || cmp.Hash != base.HexToHash("0x03") {
+		t.Errorf("expected a hashless lookup to find the hashed comparison")
+	}
+}
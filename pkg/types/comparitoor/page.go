@@ -27,21 +27,18 @@ type ComparitoorPage struct {
 	ExpectedTotal int              `json:"expectedTotal"`
 	State         types.StoreState `json:"state"`
 	// EXISTING_CODE
-	// Per-source arrays and counts
-	Chifra         []*Transaction `json:"chifra"`
-	ChifraCount    int            `json:"chifraCount"`
-	Etherscan      []*Transaction `json:"etherscan"`
-	EtherscanCount int            `json:"etherscanCount"`
-	Covalent       []*Transaction `json:"covalent"`
-	CovalentCount  int            `json:"covalentCount"`
-	Alchemy        []*Transaction `json:"alchemy"`
-	AlchemyCount   int            `json:"alchemyCount"`
+	// Comparisons line up with the comparitoor facet's rows
+	Comparisons []Comparison  `json:"comparisons,omitempty"`
+	ClassCounts map[Class]int `json:"classCounts,omitempty"`
+	// Per-source counts
+	ChifraCount    int `json:"chifraCount"`
+	EtherscanCount int `json:"etherscanCount"`
+	CovalentCount  int `json:"covalentCount"`
+	AlchemyCount   int `json:"alchemyCount"`
 	// Overlap/union/intersection statistics
 	OverlapCount      int `json:"overlapCount"`
 	UnionCount        int `json:"unionCount"`
 	IntersectionCount int `json:"intersectionCount"`
-	// Optionally, a map of overlap details (e.g., how many sources each tx appears in)
-	OverlapDetails map[string]int `json:"overlapDetails,omitempty"`
 	// EXISTING_CODE
 }
 
@@ -180,6 +177,17 @@ func (c *ComparitoorCollection) GetPage(
 			fmt.Errorf("[GetPage] unsupported dataFacet: %v", payload.DataFacet))
 	}
 
+	// EXISTING_CODE
+	if dataFacet == ComparitoorComparitoor {
+		report := getReport(getStoreKey(payload))
+		page.Comparisons = make([]Comparison, len(page.Transaction))
+		for i := range page.Transaction {
+			if cmp, ok := report.Lookup(&page.Transaction[i]); ok {
+				page.Comparisons[i] = *cmp
+			}
+		}
+	}
+	// EXISTING_CODE
 	return page, nil
 }
 
@@ -253,111 +261,32 @@ func preprocessPage(
 	_ = pageSize
 	_ = sortSpec
 	// EXISTING_CODE
-	// Always populate per-source arrays for frontend, with missing/unique flags
-	// First, gather all keys and counts
-	// Helper: convert []T to []*T
-	// slicePtrs := func(items []Transaction) []*Transaction {
-	// 	out := make([]*Transaction, len(items))
-	// 	for i := range items {
-	// 		out[i] = &items[i]
-	// 	}
-	// 	return out
-	// }
-	// // Key as string: "blockNum:txIdx"
-	// key := func(tx *Transaction) string {
-	// 	if tx == nil {
-	// 		return ""
-	// 	}
-	// 	return fmt.Sprintf("%v:%v", tx.BlockNumber, tx.TransactionIndex)
-	// }
-	// // Get per-source results
-	// var chifraResult, esResult, cvResult, alResult *facets.PageResult[Transaction]
-	// if c.chifraFacet != nil {
-	// 	chifraResult, _ = c.chifraFacet.GetPage(first, pageSize, nil, sortSpec, nil)
-	// }
-	// if c.etherscanFacet != nil {
-	// 	esResult, _ = c.etherscanFacet.GetPage(first, pageSize, nil, sortSpec, nil)
-	// }
-	// if c.covalentFacet != nil {
-	// 	cvResult, _ = c.covalentFacet.GetPage(first, pageSize, nil, sortSpec, nil)
-	// }
-	// if c.alchemyFacet != nil {
-	// 	alResult, _ = c.alchemyFacet.GetPage(first, pageSize, nil, sortSpec, nil)
-	// }
-	// sets := [][]*Transaction{
-	// 	slicePtrs(chifraResult.Items),
-	// 	slicePtrs(esResult.Items),
-	// 	slicePtrs(cvResult.Items),
-	// 	slicePtrs(alResult.Items),
-	// }
-	// keyCount := make(map[string]int)
-	// for _, arr := range sets {
-	// 	for _, tx := range arr {
-	// 		k := key(tx)
-	// 		if k != "" {
-	// 			keyCount[k]++
-	// 		}
-	// 	}
-	// }
-	// // Helper to build annotated array for a source
-	// buildAnnotated := func(arr []Transaction) []*Transaction {
-	// 	present := make(map[string]*Transaction)
-	// 	for i := range arr {
-	// 		tx := &arr[i]
-	// 		present[key(tx)] = tx
-	// 	}
-	// 	out := make([]*Transaction, 0, len(keyCount))
-	// 	for k := range keyCount {
-	// 		found, ok := present[k]
-	// 		missing := !ok
-	// 		unique := false
-	// 		if !missing && keyCount[k] == 1 {
-	// 			unique = true
-	// 		}
-	// 		if found != nil {
-	// 			found.Missing = false
-	// 			found.Unique = unique
-	// 			// Use existing entry, with missing=false and unique flag set
-	// 			out = append(out, found)
-	// 		} else {
-	// 			// Synthesize a missing entry (types must match SDK)
-	// 			// Parse block/tx from key
-	// 			var blk, txidx uint64
-	// 			_, _ = fmt.Sscanf(k, "%d:%d", &blk, &txidx)
-	// 			tx := sdk.Transaction{
-	// 				BlockNumber:      base.Blknum(blk),
-	// 				TransactionIndex: base.Txnum(txidx),
-	// 			}
-	// 			out = append(out, &tx)
-	// 		}
-	// 	}
-	// 	return out
-	// }
-	// page.Chifra = buildAnnotated(chifraResult.Items)
-	// page.Etherscan = buildAnnotated(esResult.Items)
-	// page.Covalent = buildAnnotated(cvResult.Items)
-	// page.Alchemy = buildAnnotated(alResult.Items)
-	// page.ChifraCount = len(chifraResult.Items)
-	// page.EtherscanCount = len(esResult.Items)
-	// page.CovalentCount = len(cvResult.Items)
-	// page.AlchemyCount = len(alResult.Items)
-	// // Compute overlap/union/intersection statistics
-	// union := 0
-	// intersection := 0
-	// overlap := 0
-	// for _, count := range keyCount {
-	// 	if count > 1 {
-	// 		overlap++
-	// 	}
-	// 	if count == len(sets) {
-	// 		intersection++
-	// 	}
-	// 	union++
-	// }
-	// page.OverlapCount = overlap
-	// page.UnionCount = union
-	// page.IntersectionCount = intersection
-	// page.OverlapDetails = keyCount
+	report := getReport(getStoreKey(payload))
+	if report == nil {
+		return nil
+	}
+	page.ClassCounts = report.Counts()
+	for _, cmp := range report.Comparisons {
+		for _, name := range cmp.Present {
+			switch types.DataFacet(name) {
+			case ComparitoorChifra:
+				page.ChifraCount++
+			case ComparitoorEtherscan:
+				page.EtherscanCount++
+			case ComparitoorCovalent:
+				page.CovalentCount++
+			case ComparitoorAlchemy:
+				page.AlchemyCount++
+			}
+		}
+		if len(cmp.Present) > 1 {
+			page.OverlapCount++
+		}
+		if len(cmp.Missing) == 0 {
+			page.IntersectionCount++
+		}
+	}
+	page.UnionCount = len(report.Comparisons)
 	// EXISTING_CODE
 	return nil
 }
@@ -368,6 +297,10 @@ func (c *ComparitoorCollection) matchesComparitoorFilter(item *Transaction, filt
 		return true
 	}
 	filter = strings.TrimSpace(filter)
+	// Reconciliation class: e.g., "field-mismatch", "missing-from-etherscan"
+	if cmp := tagOf(item).comparison; cmp != nil && strings.Contains(cmp.Label, filter) {
+		return true
+	}
 	// Block range: e.g., "1000-2000"
 	if strings.Contains(filter, "-") {
 		parts := strings.SplitN(filter, "-", 2)
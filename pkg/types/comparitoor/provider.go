@@ -0,0 +1,115 @@
+package comparitoor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/preferences"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// Provider fetches an address's transaction list from one source. Its name is the
+// facet that shows its transactions.
+type Provider interface {
+	Name() string
+	Transactions(ctx context.Context, chain string, address base.Address) ([]*Transaction, error)
+}
+
+var (
+	providers   = DefaultProviders()
+	providersMu sync.RWMutex
+)
+
+// ConfigureProviders sets the providers that are compared, in column order
+func ConfigureProviders(list []Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers = list
+}
+
+// GetProviders returns the configured providers
+func GetProviders() []Provider {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	return append([]Provider{}, providers...)
+}
+
+// DefaultProviders queries chifra alone. The hosted sources need an API key.
+func DefaultProviders() []Provider {
+	return []Provider{NewChifraProvider()}
+}
+
+// ProvidersFromPreferences returns the sources in column order. A source named in the
+// user's ProviderFiles is read from that CSV file. Otherwise chifra is queried through
+// the SDK and a hosted source gets an HTTP adapter if its API key is set. Hosted sources
+// with neither are left out.
+func ProvidersFromPreferences(user *preferences.UserPreferences) []Provider {
+	chainIDs := map[string]uint64{"mainnet": 1}
+	for _, chain := range user.Chains {
+		if chain.ChainId != 0 {
+			chainIDs[chain.Chain] = chain.ChainId
+		}
+	}
+
+	ret := []Provider{}
+	for _, facet := range []types.DataFacet{ComparitoorChifra, ComparitoorEtherscan, ComparitoorCovalent, ComparitoorAlchemy} {
+		name := string(facet)
+		if path := user.ProviderFiles[name]; path != "" {
+			ret = append(ret, NewFileProvider(name, path))
+			continue
+		}
+		key := user.ProviderKeys[name]
+		switch {
+		case facet == ComparitoorChifra:
+			ret = append(ret, NewChifraProvider())
+		case key == "":
+			continue
+		case facet == ComparitoorEtherscan:
+			ret = append(ret, NewEtherscanProvider(key, chainIDs))
+		case facet == ComparitoorCovalent:
+			ret = append(ret, NewCovalentProvider(key))
+		case facet == ComparitoorAlchemy:
+			ret = append(ret, NewAlchemyProvider(key))
+		}
+	}
+	return ret
+}
+
+// fetchSources queries every provider at once. A provider that fails comes back with its
+// error and no transactions so that the others are still compared.
+func fetchSources(ctx context.Context, chain string, address base.Address) []Source {
+	list := GetProviders()
+	sources := make([]Source, len(list))
+	var wg sync.WaitGroup
+	for i, p := range list {
+		sources[i].Name = p.Name()
+		wg.Add(1)
+		go func(i int, p Provider) {
+			defer wg.Done()
+			txs, err := p.Transactions(ctx, chain, address)
+			if err != nil {
+				logging.LogError("comparitoor: "+p.Name(), err, context.Canceled)
+				sources[i].Err = err
+				return
+			}
+			sources[i].Transactions = txs
+		}(i, p)
+	}
+	wg.Wait()
+	return sources
+}
+
+// sourcesError joins the errors of the providers that failed, or is nil if none did
+func sourcesError(sources []Source) error {
+	var errs []error
+	for _, src := range sources {
+		if src.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", src.Name, src.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
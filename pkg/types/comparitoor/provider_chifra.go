@@ -0,0 +1,40 @@
+package comparitoor
+
+import (
+	"context"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// ChifraProvider reads an address's transactions from the local index through the SDK
+type ChifraProvider struct{}
+
+// NewChifraProvider queries chifra export for the active chain and address
+func NewChifraProvider() *ChifraProvider {
+	return &ChifraProvider{}
+}
+
+func (p *ChifraProvider) Name() string {
+	return string(ComparitoorChifra)
+}
+
+func (p *ChifraProvider) Transactions(ctx context.Context, chain string, address base.Address) ([]*Transaction, error) {
+	queryCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	opts := sdk.ExportOptions{
+		Globals:   sdk.Globals{Cache: true, Chain: chain},
+		RenderCtx: &output.RenderCtx{Ctx: queryCtx, Cancel: cancel},
+		Addrs:     []string{address.Hex()},
+	}
+	txs, _, err := opts.Export()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Transaction, len(txs))
+	for i := range txs {
+		out[i] = &txs[i]
+	}
+	return out, nil
+}
@@ -0,0 +1,105 @@
+package comparitoor
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// FileProvider reads a source's transactions from a CSV export in the format of the files
+// in testdata. The file holds a single address's list, so the address is not checked.
+type FileProvider struct {
+	name string
+	path string
+}
+
+// NewFileProvider reads the named source from a CSV file on disk
+func NewFileProvider(name, path string) *FileProvider {
+	return &FileProvider{name: name, path: path}
+}
+
+func (p *FileProvider) Name() string {
+	return p.name
+}
+
+func (p *FileProvider) Transactions(ctx context.Context, chain string, address base.Address) ([]*Transaction, error) {
+	_, _ = chain, address
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", p.path, err)
+	}
+	defer f.Close()
+	return parseTransactionsCSV(f)
+}
+
+// parseTransactionsCSV reads the columns it knows by name since each source's export
+// orders them differently. Rows without a block number and index are skipped.
+func parseTransactionsCSV(r io.Reader) ([]*Transaction, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return []*Transaction{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[name] = i
+	}
+	if _, ok := cols["blockNumber"]; !ok {
+		return nil, fmt.Errorf("missing blockNumber column")
+	}
+
+	out := []*Transaction{}
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			return out, nil
+		} else if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := cols[name]; ok && i < len(rec) {
+				return rec[i]
+			}
+			return ""
+		}
+		number := func(name string) uint64 {
+			v, _ := strconv.ParseUint(field(name), 10, 64)
+			return v
+		}
+
+		blk, err1 := strconv.ParseUint(field("blockNumber"), 10, 64)
+		idx, err2 := strconv.ParseUint(field("transactionIndex"), 10, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		tx := &Transaction{
+			BlockNumber:      base.Blknum(blk),
+			TransactionIndex: base.Txnum(idx),
+			Timestamp:        base.Timestamp(number("timestamp")),
+			From:             base.HexToAddress(field("from")),
+			To:               base.HexToAddress(field("to")),
+			GasPrice:         base.Gas(number("gasPrice")),
+			GasUsed:          base.Gas(number("gasUsed")),
+			IsError:          field("isError") == "true",
+			HasToken:         field("hasToken") == "true",
+		}
+		if hash := field("hash"); hash != "" {
+			tx.Hash = base.HexToHash(hash)
+		}
+		if value := field("value"); value != "" {
+			tx.Value = *base.NewWeiStr(value)
+		}
+		out = append(out, tx)
+	}
+}
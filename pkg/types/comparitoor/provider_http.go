@@ -0,0 +1,377 @@
+package comparitoor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+var defaultClient = &http.Client{Timeout: 30 * time.Second}
+
+// doJSON sends the request and decodes a JSON response into out
+func doJSON(client *http.Client, req *http.Request, out any) error {
+	if client == nil {
+		client = defaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s %s", req.URL.Host, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func parseUint(s string) uint64 {
+	if strings.HasPrefix(s, "0x") {
+		v, _ := strconv.ParseUint(s[2:], 16, 64)
+		return v
+	}
+	v, _ := strconv.ParseUint(s, 10, 64)
+	return v
+}
+
+// EtherscanProvider reads an account's normal and internal transactions and its token
+// transfers from an Etherscan-compatible API. Blockscout and the other Etherscan clones
+// answer the same queries. Internal and token rows carry no receipt, so only the block,
+// hash and addresses are filled in.
+type EtherscanProvider struct {
+	BaseURL  string
+	APIKey   string
+	ChainIDs map[string]uint64
+	PageSize int
+	Client   *http.Client
+}
+
+func NewEtherscanProvider(apiKey string, chainIDs map[string]uint64) *EtherscanProvider {
+	return &EtherscanProvider{
+		BaseURL:  "https://api.etherscan.io/v2/api",
+		APIKey:   apiKey,
+		ChainIDs: chainIDs,
+		PageSize: 1000,
+	}
+}
+
+func (p *EtherscanProvider) Name() string {
+	return string(ComparitoorEtherscan)
+}
+
+// etherscanRow holds the fields of txlist, txlistinternal and tokentx rows that are read
+type etherscanRow struct {
+	BlockNumber      string `json:"blockNumber"`
+	TimeStamp        string `json:"timeStamp"`
+	Hash             string `json:"hash"`
+	TransactionIndex string `json:"transactionIndex"`
+	From             string `json:"from"`
+	To               string `json:"to"`
+	Value            string `json:"value"`
+	GasPrice         string `json:"gasPrice"`
+	GasUsed          string `json:"gasUsed"`
+	IsError          string `json:"isError"`
+}
+
+func (p *EtherscanProvider) Transactions(ctx context.Context, chain string, address base.Address) ([]*Transaction, error) {
+	chainID, ok := p.ChainIDs[chain]
+	if !ok {
+		return nil, fmt.Errorf("no chain id for %s", chain)
+	}
+
+	out := []*Transaction{}
+	for _, action := range []string{"txlist", "txlistinternal", "tokentx"} {
+		rows, err := p.list(ctx, chainID, action, address)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			tx := &Transaction{
+				BlockNumber: base.Blknum(parseUint(row.BlockNumber)),
+				Hash:        base.HexToHash(row.Hash),
+				From:        base.HexToAddress(row.From),
+				To:          base.HexToAddress(row.To),
+			}
+			switch action {
+			case "txlist":
+				tx.TransactionIndex = base.Txnum(parseUint(row.TransactionIndex))
+				tx.Timestamp = base.Timestamp(parseUint(row.TimeStamp))
+				tx.Value = *base.NewWeiStr(row.Value)
+				tx.GasPrice = base.Gas(parseUint(row.GasPrice))
+				tx.GasUsed = base.Gas(parseUint(row.GasUsed))
+				tx.IsError = row.IsError == "1"
+			case "tokentx":
+				tx.TransactionIndex = base.Txnum(parseUint(row.TransactionIndex))
+				tx.HasToken = true
+			}
+			out = append(out, tx)
+		}
+	}
+	return out, nil
+}
+
+// list reads every row of one account action. The API refuses to page past
+// page × offset = 10000, so each request asks for the first page from a start block. The
+// next starts at the last block returned, whose rows are dropped and read again in full.
+func (p *EtherscanProvider) list(ctx context.Context, chainID uint64, action string, address base.Address) ([]etherscanRow, error) {
+	out := []etherscanRow{}
+	start := uint64(0)
+	for {
+		rows, err := p.page(ctx, chainID, action, address, start)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) < p.PageSize {
+			return append(out, rows...), nil
+		}
+		last := parseUint(rows[len(rows)-1].BlockNumber)
+		if parseUint(rows[0].BlockNumber) == last {
+			// A block with a page's worth of rows can't be read in parts, so move past it
+			out = append(out, rows...)
+			start = last + 1
+			continue
+		}
+		for _, row := range rows {
+			if parseUint(row.BlockNumber) < last {
+				out = append(out, row)
+			}
+		}
+		start = last
+	}
+}
+
+// page requests one page of an account action's rows from the start block on
+func (p *EtherscanProvider) page(ctx context.Context, chainID uint64, action string, address base.Address, start uint64) ([]etherscanRow, error) {
+	q := url.Values{}
+	q.Set("chainid", fmt.Sprint(chainID))
+	q.Set("module", "account")
+	q.Set("action", action)
+	q.Set("address", address.Hex())
+	q.Set("startblock", fmt.Sprint(start))
+	q.Set("endblock", "99999999")
+	q.Set("page", "1")
+	q.Set("offset", fmt.Sprint(p.PageSize))
+	q.Set("sort", "asc")
+	q.Set("apikey", p.APIKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.BaseURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Status  string          `json:"status"`
+		Message string          `json:"message"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if err := doJSON(p.Client, req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Status != "1" {
+		if strings.HasPrefix(resp.Message, "No transactions found") {
+			return nil, nil
+		}
+		var detail string
+		_ = json.Unmarshal(resp.Result, &detail)
+		return nil, fmt.Errorf("etherscan %s: %s %s", action, resp.Message, detail)
+	}
+
+	var rows []etherscanRow
+	if err := json.Unmarshal(resp.Result, &rows); err != nil {
+		return nil, fmt.Errorf("etherscan %s: %w", action, err)
+	}
+	return rows, nil
+}
+
+// CovalentProvider reads an account's transactions from a Covalent-style API
+type CovalentProvider struct {
+	BaseURL    string
+	APIKey     string
+	ChainNames map[string]string
+	Client     *http.Client
+}
+
+func NewCovalentProvider(apiKey string) *CovalentProvider {
+	return &CovalentProvider{
+		BaseURL:    "https://api.covalenthq.com",
+		APIKey:     apiKey,
+		ChainNames: map[string]string{"mainnet": "eth-mainnet", "gnosis": "gnosis-mainnet", "sepolia": "eth-sepolia"},
+	}
+}
+
+func (p *CovalentProvider) Name() string {
+	return string(ComparitoorCovalent)
+}
+
+func (p *CovalentProvider) Transactions(ctx context.Context, chain string, address base.Address) ([]*Transaction, error) {
+	chainName := chain
+	if name, ok := p.ChainNames[chain]; ok {
+		chainName = name
+	}
+
+	out := []*Transaction{}
+	for page := 0; ; page++ {
+		endpoint := fmt.Sprintf("%s/v1/%s/address/%s/transactions_v3/page/%d/", p.BaseURL, chainName, address.Hex(), page)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+p.APIKey)
+
+		var resp struct {
+			Data struct {
+				Items []struct {
+					BlockHeight   uint64 `json:"block_height"`
+					BlockSignedAt string `json:"block_signed_at"`
+					TxOffset      uint64 `json:"tx_offset"`
+					TxHash        string `json:"tx_hash"`
+					FromAddress   string `json:"from_address"`
+					ToAddress     string `json:"to_address"`
+					Value         string `json:"value"`
+					GasPrice      uint64 `json:"gas_price"`
+					GasSpent      uint64 `json:"gas_spent"`
+					Successful    bool   `json:"successful"`
+				} `json:"items"`
+				Links struct {
+					Next *string `json:"next"`
+				} `json:"links"`
+			} `json:"data"`
+			Error        bool   `json:"error"`
+			ErrorMessage string `json:"error_message"`
+		}
+		if err := doJSON(p.Client, req, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Error {
+			return nil, fmt.Errorf("covalent: %s", resp.ErrorMessage)
+		}
+
+		for _, item := range resp.Data.Items {
+			tx := &Transaction{
+				BlockNumber:      base.Blknum(item.BlockHeight),
+				TransactionIndex: base.Txnum(item.TxOffset),
+				Hash:             base.HexToHash(item.TxHash),
+				From:             base.HexToAddress(item.FromAddress),
+				To:               base.HexToAddress(item.ToAddress),
+				Value:            *base.NewWeiStr(item.Value),
+				GasPrice:         base.Gas(item.GasPrice),
+				GasUsed:          base.Gas(item.GasSpent),
+				IsError:          !item.Successful,
+			}
+			if ts, err := time.Parse(time.RFC3339, item.BlockSignedAt); err == nil {
+				tx.Timestamp = base.Timestamp(ts.Unix())
+			}
+			out = append(out, tx)
+		}
+		if resp.Data.Links.Next == nil || len(resp.Data.Items) == 0 {
+			return out, nil
+		}
+	}
+}
+
+// AlchemyProvider reads an account's transfers, in and out, from an Alchemy-style
+// alchemy_getAssetTransfers endpoint. Transfers carry no receipt, so only the block,
+// hash and addresses are filled in.
+type AlchemyProvider struct {
+	BaseURL  string // overrides https://<network>.g.alchemy.com
+	APIKey   string
+	Networks map[string]string
+	Client   *http.Client
+}
+
+func NewAlchemyProvider(apiKey string) *AlchemyProvider {
+	return &AlchemyProvider{
+		APIKey:   apiKey,
+		Networks: map[string]string{"mainnet": "eth-mainnet", "sepolia": "eth-sepolia", "gnosis": "gnosis-mainnet"},
+	}
+}
+
+func (p *AlchemyProvider) Name() string {
+	return string(ComparitoorAlchemy)
+}
+
+func (p *AlchemyProvider) Transactions(ctx context.Context, chain string, address base.Address) ([]*Transaction, error) {
+	endpoint := p.BaseURL
+	if endpoint == "" {
+		network, ok := p.Networks[chain]
+		if !ok {
+			return nil, fmt.Errorf("no alchemy network for %s", chain)
+		}
+		endpoint = "https://" + network + ".g.alchemy.com"
+	}
+	endpoint += "/v2/" + p.APIKey
+
+	out := []*Transaction{}
+	for _, direction := range []string{"fromAddress", "toAddress"} {
+		pageKey := ""
+		for {
+			params := map[string]any{
+				"fromBlock":    "0x0",
+				"toBlock":      "latest",
+				direction:      address.Hex(),
+				"category":     []string{"external", "internal", "erc20", "erc721", "erc1155"},
+				"withMetadata": false,
+				"maxCount":     "0x3e8",
+			}
+			if pageKey != "" {
+				params["pageKey"] = pageKey
+			}
+			body, _ := json.Marshal(map[string]any{
+				"jsonrpc": "2.0",
+				"id":      1,
+				"method":  "alchemy_getAssetTransfers",
+				"params":  []any{params},
+			})
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			var resp struct {
+				Result struct {
+					Transfers []struct {
+						BlockNum string `json:"blockNum"`
+						Hash     string `json:"hash"`
+						From     string `json:"from"`
+						To       string `json:"to"`
+						Category string `json:"category"`
+					} `json:"transfers"`
+					PageKey string `json:"pageKey"`
+				} `json:"result"`
+				Error *struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			if err := doJSON(p.Client, req, &resp); err != nil {
+				return nil, err
+			}
+			if resp.Error != nil {
+				return nil, fmt.Errorf("alchemy: %d %s", resp.Error.Code, resp.Error.Message)
+			}
+
+			for _, t := range resp.Result.Transfers {
+				out = append(out, &Transaction{
+					BlockNumber: base.Blknum(parseUint(t.BlockNum)),
+					Hash:        base.HexToHash(t.Hash),
+					From:        base.HexToAddress(t.From),
+					To:          base.HexToAddress(t.To),
+					HasToken:    strings.HasPrefix(t.Category, "erc"),
+				})
+			}
+			if pageKey = resp.Result.PageKey; pageKey == "" {
+				break
+			}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,241 @@
+package comparitoor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/preferences"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+var testAddress = base.HexToAddress("0xf503017d7baf7fbc0fff7492b751025c6a78179b")
+
+func TestEtherscanProvider(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("apikey") != "KEY" || q.Get("chainid") != "1" || q.Get("address") != testAddress.Hex() || q.Get("page") != "1" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		queries = append(queries, q.Get("action")+"@"+q.Get("startblock"))
+		switch q.Get("action") + "@" + q.Get("startblock") {
+		case "txlist@0":
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":[
+				{"blockNumber":"8854723","timeStamp":"1572639538","hash":"0x1a898c5448b37f693343917ea40b7ad1c43b28a4ddd37af1bd6d0bb4a0c99891","transactionIndex":"61","from":"0xbb984f85bd52d78eb2fbf2c5598bae7abb98c5bc","to":"0xf503017d7baf7fbc0fff7492b751025c6a78179b","value":"5000000000000000000","gasPrice":"10000000000","gasUsed":"21000","isError":"0"},
+				{"blockNumber":"8856290","timeStamp":"1572660966","hash":"0xb7d80298ad62d68f47a9e3faeaa78ca7888e33dc714707a83eb4dfbbcdc01b09","transactionIndex":"62","from":"0xf503017d7baf7fbc0fff7492b751025c6a78179b","to":"0xb97073b754660bb356dfe12f78ae366d77dbc80f","value":"0","gasPrice":"10000000000","gasUsed":"30000","isError":"1"}
+			]}`)
+		case "txlist@8856290":
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":[
+				{"blockNumber":"8856290","timeStamp":"1572660966","hash":"0xb7d80298ad62d68f47a9e3faeaa78ca7888e33dc714707a83eb4dfbbcdc01b09","transactionIndex":"62","from":"0xf503017d7baf7fbc0fff7492b751025c6a78179b","to":"0xb97073b754660bb356dfe12f78ae366d77dbc80f","value":"0","gasPrice":"10000000000","gasUsed":"30000","isError":"1"}
+			]}`)
+		case "tokentx@0":
+			fmt.Fprint(w, `{"status":"1","message":"OK","result":[
+				{"blockNumber":"9000000","timeStamp":"1575000000","hash":"0x0d4b4c3e0a55a1e8f8b4d7e1a6c5d1f0e2b3a4c5d6e7f8091a2b3c4d5e6f7081","transactionIndex":"7","from":"0xb97073b754660bb356dfe12f78ae366d77dbc80f","to":"0xf503017d7baf7fbc0fff7492b751025c6a78179b","value":"100"}
+			]}`)
+		default:
+			fmt.Fprint(w, `{"status":"0","message":"No transactions found","result":[]}`)
+		}
+	}))
+	defer server.Close()
+
+	p := NewEtherscanProvider("KEY", map[string]uint64{"mainnet": 1})
+	p.BaseURL, p.PageSize = server.URL, 2
+	txs, err := p.Transactions(context.Background(), "mainnet", testAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(queries, ","); got != "txlist@0,txlist@8856290,txlistinternal@0,tokentx@0" {
+		t.Fatalf("expected paging by start block over every action, got %s", got)
+	}
+	if len(txs) != 3 {
+		t.Fatalf("expected the repeated block read once plus the token transfer, got %d rows", len(txs))
+	}
+	if txs[0].BlockNumber != 8854723 || txs[0].TransactionIndex != 61 || txs[0].Value.String() != "5000000000000000000" || txs[0].Timestamp != 1572639538 {
+		t.Errorf("unexpected transaction %+v", txs[0])
+	}
+	if !txs[1].IsError || txs[1].GasUsed != 30000 {
+		t.Errorf("unexpected transaction %+v", txs[1])
+	}
+	if !txs[2].HasToken || txs[2].TransactionIndex != 7 || hasDetails(txs[2]) {
+		t.Errorf("expected a token transfer without details, got %+v", txs[2])
+	}
+
+	if _, err := p.Transactions(context.Background(), "gnosis", testAddress); err == nil {
+		t.Error("expected an error for a chain without an id")
+	}
+}
+
+func TestEtherscanProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"0","message":"NOTOK","result":"Invalid API Key"}`)
+	}))
+	defer server.Close()
+
+	p := NewEtherscanProvider("bad", map[string]uint64{"mainnet": 1})
+	p.BaseURL = server.URL
+	if _, err := p.Transactions(context.Background(), "mainnet", testAddress); err == nil || !strings.Contains(err.Error(), "Invalid API Key") {
+		t.Errorf("expected the api's error, got %v", err)
+	}
+}
+
+func TestCovalentProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer KEY" {
+			t.Errorf("missing api key")
+		}
+		if !strings.HasPrefix(r.URL.Path, "/v1/eth-mainnet/address/"+testAddress.Hex()+"/transactions_v3/page/") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		next := `null`
+		if strings.HasSuffix(r.URL.Path, "/page/0/") {
+			next = `"more"`
+		}
+		fmt.Fprintf(w, `{"data":{"items":[{"block_height":8854723,"block_signed_at":"2019-11-01T20:18:58Z","tx_offset":61,"tx_hash":"0x1a898c5448b37f693343917ea40b7ad1c43b28a4ddd37af1bd6d0bb4a0c99891","from_address":"0xbb984f85bd52d78eb2fbf2c5598bae7abb98c5bc","to_address":"0xf503017d7baf7fbc0fff7492b751025c6a78179b","value":"5000000000000000000","gas_price":10000000000,"gas_spent":21000,"successful":false}],"links":{"next":%s}},"error":false}`, next)
+	}))
+	defer server.Close()
+
+	p := NewCovalentProvider("KEY")
+	p.BaseURL = server.URL
+	txs, err := p.Transactions(context.Background(), "mainnet", testAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("expected a row from each of two pages, got %d", len(txs))
+	}
+	if tx := txs[0]; tx.Timestamp != 1572639538 || tx.TransactionIndex != 61 || tx.GasUsed != 21000 || !tx.IsError {
+		t.Errorf("unexpected transaction %+v", tx)
+	}
+}
+
+func TestAlchemyProvider(t *testing.T) {
+	var directions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/KEY" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req struct {
+			Method string           `json:"method"`
+			Params []map[string]any `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "alchemy_getAssetTransfers" {
+			t.Errorf("unexpected body %v %+v", err, req)
+		}
+		params := req.Params[0]
+		if _, ok := params["fromAddress"]; ok {
+			directions = append(directions, "from")
+			if params["pageKey"] == nil {
+				fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":{"transfers":[{"blockNum":"0x871c23","hash":"0x1a898c5448b37f693343917ea40b7ad1c43b28a4ddd37af1bd6d0bb4a0c99891","from":"0xf503017d7baf7fbc0fff7492b751025c6a78179b","to":"0xbb984f85bd52d78eb2fbf2c5598bae7abb98c5bc"}],"pageKey":"next"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":{"transfers":[]}}`)
+			return
+		}
+		directions = append(directions, "to")
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":{"transfers":[{"blockNum":"0x871f2c","hash":"0x6d62aaef0653a83fd9c876f58f04aaf1ce6a750699d34ed870dd171e3de2d80a","from":"0xb97073b754660bb356dfe12f78ae366d77dbc80f","to":"0xf503017d7baf7fbc0fff7492b751025c6a78179b"}]}}`)
+	}))
+	defer server.Close()
+
+	p := NewAlchemyProvider("KEY")
+	p.BaseURL = server.URL
+	txs, err := p.Transactions(context.Background(), "mainnet", testAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 || strings.Join(directions, ",") != "from,from,to" {
+		t.Fatalf("expected transfers both ways with paging, got %d via %v", len(txs), directions)
+	}
+	if txs[0].BlockNumber != 8854563 || hasDetails(txs[0]) {
+		t.Errorf("unexpected transfer %+v", txs[0])
+	}
+}
+
+func TestAlchemyProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"Must be authenticated!"}}`)
+	}))
+	defer server.Close()
+
+	p := NewAlchemyProvider("bad")
+	p.BaseURL = server.URL
+	if _, err := p.Transactions(context.Background(), "mainnet", testAddress); err == nil || !strings.Contains(err.Error(), "authenticated") {
+		t.Errorf("expected the rpc error, got %v", err)
+	}
+}
+
+func TestProvidersFromPreferences(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chifra.csv")
+	csv := "hash,transactionIndex,blockNumber\n0x01,2,100\nbad,row\n"
+	if err := os.WriteFile(path, []byte(csv), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	user := preferences.NewUserPreferences()
+	user.ProviderFiles = map[string]string{"chifra": path}
+	user.ProviderKeys = map[string]string{"etherscan": "E", "alchemy": "A"}
+	user.Chains = []preferences.Chain{{Chain: "gnosis", ChainId: 100}}
+	list := ProvidersFromPreferences(user)
+
+	var names []string
+	for _, p := range list {
+		names = append(names, p.Name())
+	}
+	if strings.Join(names, ",") != "chifra,etherscan,alchemy" {
+		t.Fatalf("expected covalent left out without a key, got %v", names)
+	}
+	if e, ok := list[1].(*EtherscanProvider); !ok || e.APIKey != "E" || e.ChainIDs["gnosis"] != 100 {
+		t.Errorf("expected an etherscan adapter with the key and chain ids, got %+v", list[1])
+	}
+	if _, ok := list[2].(*AlchemyProvider); !ok {
+		t.Errorf("expected an alchemy adapter, got %T", list[2])
+	}
+	if _, ok := ProvidersFromPreferences(preferences.NewUserPreferences())[0].(*ChifraProvider); !ok {
+		t.Error("expected chifra to be queried through the sdk without a file")
+	}
+
+	txs, err := list[0].Transactions(context.Background(), "mainnet", testAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 || txs[0].BlockNumber != 100 || txs[0].TransactionIndex != 2 {
+		t.Errorf("expected the file's one good row, got %+v", txs)
+	}
+	if _, err := NewFileProvider("chifra", filepath.Join(t.TempDir(), "missing.csv")).Transactions(context.Background(), "mainnet", testAddress); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestFetchSourcesSurvivesFailure(t *testing.T) {
+	defer ConfigureProviders(DefaultProviders())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	failing := NewCovalentProvider("KEY")
+	failing.BaseURL = server.URL
+	ConfigureProviders([]Provider{NewFileProvider("etherscan", filepath.Join("testdata", "etherscan.csv")), failing})
+
+	sources := fetchSources(context.Background(), "mainnet", testAddress)
+	if len(sources) != 2 || len(sources[0].Transactions) != 706 || sources[1].Name != "covalent" || sources[1].Transactions != nil || sources[1].Err == nil {
+		t.Fatalf("expected the failing source to come back empty with its error, got %d sources", len(sources))
+	}
+	if err := sourcesError(sources); err == nil || !strings.HasPrefix(err.Error(), "covalent: ") {
+		t.Errorf("expected the failure named by provider, got %v", err)
+	}
+
+	report := Compare(sources)
+	if strings.Join(report.Sources, ",") != "etherscan" || report.Failed["covalent"] == "" {
+		t.Errorf("expected covalent reported as failed, got %v %v", report.Sources, report.Failed)
+	}
+	if card := NewScorecard(report, 0); card.Failed["covalent"] == "" {
+		t.Error("expected the scorecard to list the failed provider")
+	}
+}
@@ -0,0 +1,226 @@
+package comparitoor
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+)
+
+// Category is the kind of transaction a comparison is, for breaking down the scorecard
+type Category string
+
+const (
+	External      Category = "external"
+	Internal      Category = "internal"
+	TokenTransfer Category = "token"
+	Failed        Category = "failed"
+)
+
+var categories = []Category{External, Internal, TokenTransfer, Failed}
+
+// Score is how completely and accurately one provider reported a set of comparisons,
+// measured against the union of all sources
+type Score struct {
+	Provider     string  `json:"provider"`
+	Union        int     `json:"union"`
+	Found        int     `json:"found"`
+	Confirmed    int     `json:"confirmed"`  // found by another source as well
+	Mismatched   int     `json:"mismatched"` // found with a field that differs from the agreed value
+	Recall       float64 `json:"recall"`
+	Precision    float64 `json:"precision"`
+	MismatchRate float64 `json:"mismatchRate"`
+}
+
+// RangeScores are the scores for the comparisons in a block range
+type RangeScores struct {
+	StartBlock uint64  `json:"startBlock"`
+	EndBlock   uint64  `json:"endBlock"`
+	Scores     []Score `json:"scores"`
+}
+
+// Scorecard breaks down each provider's scores by category and by block range. Providers
+// that failed have no scores and are listed in Failed with their errors.
+type Scorecard struct {
+	Sources    []string             `json:"sources"`
+	Failed     map[string]string    `json:"failed,omitempty"`
+	RangeSize  uint64               `json:"rangeSize"`
+	Overall    []Score              `json:"overall"`
+	ByCategory map[Category][]Score `json:"byCategory"`
+	ByRange    []RangeScores        `json:"byRange"`
+}
+
+// maxScoreRanges keeps the block range breakdown to a chartable number of buckets
+const maxScoreRanges = 100
+
+// NewScorecard scores the report's sources. A rangeSize of zero picks one from the span of
+// the comparisons.
+func NewScorecard(report *Report, rangeSize uint64) *Scorecard {
+	card := &Scorecard{
+		Sources:    report.Sources,
+		Failed:     report.Failed,
+		ByCategory: make(map[Category][]Score),
+		ByRange:    []RangeScores{},
+	}
+	card.Overall = scoreComparisons(report.Sources, report.Comparisons)
+	for _, cat := range categories {
+		var in []Comparison
+		for _, cmp := range report.Comparisons {
+			if cmp.Category == cat {
+				in = append(in, cmp)
+			}
+		}
+		card.ByCategory[cat] = scoreComparisons(report.Sources, in)
+	}
+
+	if len(report.Comparisons) == 0 {
+		return card
+	}
+	// Comparisons are in block order
+	first := uint64(report.Comparisons[0].BlockNumber)
+	last := uint64(report.Comparisons[len(report.Comparisons)-1].BlockNumber)
+	if rangeSize == 0 {
+		rangeSize = types.NewGridInfo().Size
+		if span := last - first + 1; span/rangeSize >= maxScoreRanges {
+			rangeSize = (span + maxScoreRanges - 1) / maxScoreRanges
+		}
+	}
+	card.RangeSize = rangeSize
+
+	start := 0
+	for start < len(report.Comparisons) {
+		key := uint64(report.Comparisons[start].BlockNumber) / rangeSize
+		end := start
+		for end < len(report.Comparisons) && uint64(report.Comparisons[end].BlockNumber)/rangeSize == key {
+			end++
+		}
+		card.ByRange = append(card.ByRange, RangeScores{
+			StartBlock: key * rangeSize,
+			EndBlock:   (key+1)*rangeSize - 1,
+			Scores:     scoreComparisons(report.Sources, report.Comparisons[start:end]),
+		})
+		start = end
+	}
+	return card
+}
+
+func scoreComparisons(sources []string, comparisons []Comparison) []Score {
+	scores := make([]Score, len(sources))
+	for i, name := range sources {
+		score := Score{Provider: name, Union: len(comparisons)}
+		for _, cmp := range comparisons {
+			found := false
+			for _, p := range cmp.Present {
+				if p == name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+			score.Found++
+			if len(cmp.Present) > 1 {
+				score.Confirmed++
+			}
+			for _, diff := range cmp.Diffs {
+				if diff.disagrees(name) {
+					score.Mismatched++
+					break
+				}
+			}
+		}
+		score.Recall = ratio(score.Found, score.Union)
+		score.Precision = ratio(score.Confirmed, score.Found)
+		score.MismatchRate = ratio(score.Mismatched, score.Found)
+		scores[i] = score
+	}
+	return scores
+}
+
+// disagrees reports whether the named source's value differs from the agreed value, which
+// is the one most sources report or, on a tie, chifra's. With neither, every source that
+// reported a value is charged.
+func (d FieldDiff) disagrees(name string) bool {
+	mine, ok := d.Values[name]
+	if !ok {
+		return false
+	}
+	counts := make(map[string]int)
+	for _, v := range d.Values {
+		counts[v]++
+	}
+	agreed, best, tied := "", 0, false
+	for v, n := range counts {
+		switch {
+		case n > best:
+			agreed, best, tied = v, n, false
+		case n == best:
+			tied = true
+		}
+	}
+	if tied {
+		chifra, ok := d.Values[string(ComparitoorChifra)]
+		if !ok {
+			return true
+		}
+		agreed = chifra
+	}
+	return mine != agreed
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+// Buckets charts the block range breakdown as a recall, precision and mismatch series per
+// provider, named like "etherscan_recall", in percent
+func (s *Scorecard) Buckets() *types.Buckets {
+	buckets := types.NewBuckets()
+	if s.RangeSize != 0 {
+		buckets.GridInfo.Size = s.RangeSize
+	}
+	for _, name := range s.Sources {
+		for _, metric := range []string{"recall", "precision", "mismatch"} {
+			buckets.EnsureSeriesExists(seriesName(name, metric))
+		}
+	}
+
+	for _, rng := range s.ByRange {
+		key := strconv.FormatUint(rng.StartBlock/s.RangeSize, 10)
+		for _, score := range rng.Scores {
+			for metric, value := range map[string]float64{"recall": score.Recall, "precision": score.Precision, "mismatch": score.MismatchRate} {
+				b := types.NewBucket(key, rng.StartBlock, rng.EndBlock)
+				b.Total = value * 100
+				b.ColorValue = b.Total
+				name := seriesName(score.Provider, metric)
+				buckets.SetSeries(name, append(buckets.Series[name], b))
+			}
+		}
+		if rng.EndBlock > buckets.GridInfo.MaxBlock {
+			buckets.GridInfo.MaxBlock = rng.EndBlock
+		}
+	}
+	buckets.GridInfo.BucketCount = len(s.ByRange)
+	buckets.GridInfo.Rows = (buckets.GridInfo.BucketCount + buckets.GridInfo.Columns - 1) / buckets.GridInfo.Columns
+	return buckets
+}
+
+// ProviderBuckets keeps only the named provider's series
+func (s *Scorecard) ProviderBuckets(name string) *types.Buckets {
+	all := s.Buckets()
+	ret := types.NewBucketsWithGridInfo(&all.GridInfo)
+	for _, metric := range []string{"recall", "precision", "mismatch"} {
+		if series, ok := all.Series[seriesName(name, metric)]; ok {
+			ret.SetSeries(seriesName(name, metric), series)
+		}
+	}
+	return ret
+}
+
+func seriesName(provider, metric string) string {
+	return fmt.Sprintf("%s_%s", provider, metric)
+}
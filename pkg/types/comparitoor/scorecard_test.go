@@ -0,0 +1,107 @@
+package comparitoor
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+func findScore(scores []Score, provider string) Score {
+	for _, s := range scores {
+		if s.Provider == provider {
+			return s
+		}
+	}
+	return Score{}
+}
+
+func TestScorecard(t *testing.T) {
+	tx := func(hash string, blk uint64, failed bool) *Transaction {
+		return &Transaction{Hash: base.HexToHash(hash), BlockNumber: base.Blknum(blk), GasUsed: 21000, IsError: failed}
+	}
+	report := Compare([]Source{
+		{Name: "a", Transactions: []*Transaction{tx("0x01", 10, false), tx("0x02", 20, false), tx("0x03", 150, true)}},
+		{Name: "b", Transactions: []*Transaction{tx("0x01", 10, false), tx("0x03", 150, false), tx("0x04", 160, false)}},
+	})
+	card := NewScorecard(report, 100)
+
+	a, b := findScore(card.Overall, "a"), findScore(card.Overall, "b")
+	if a.Union != 4 || a.Found != 3 || a.Confirmed != 2 || a.Mismatched != 1 {
+		t.Errorf("unexpected score %+v", a)
+	}
+	if a.Recall != 0.75 || b.Recall != 0.75 || b.Precision != 2.0/3 || b.MismatchRate != 1.0/3 {
+		t.Errorf("unexpected rates %+v %+v", a, b)
+	}
+	if failed := findScore(card.ByCategory[Failed], "b"); failed.Union != 1 || failed.Found != 1 || failed.Mismatched != 1 {
+		t.Errorf("expected the disputed failure in the failed category, got %+v", failed)
+	}
+
+	if len(card.ByRange) != 2 || card.ByRange[1].StartBlock != 100 || card.ByRange[1].EndBlock != 199 {
+		t.Fatalf("unexpected ranges %+v", card.ByRange)
+	}
+	if first := findScore(card.ByRange[0].Scores, "b"); first.Recall != 0.5 {
+		t.Errorf("expected b to miss half of the first range, got %+v", first)
+	}
+
+	buckets := card.Buckets()
+	series := buckets.Series["b_recall"]
+	if len(buckets.Series) != 6 || len(series) != 2 || series[0].Total != 50 || series[1].BucketKey != "1" {
+		t.Errorf("unexpected buckets %+v", buckets.Series)
+	}
+	if own := card.ProviderBuckets("a"); len(own.Series) != 3 || own.Series["a_mismatch"][1].Total != 100 {
+		t.Errorf("unexpected provider buckets %+v", own.Series)
+	}
+}
+
+func TestScorecardChargesDissent(t *testing.T) {
+	tx := func(gasUsed uint64) *Transaction {
+		return &Transaction{Hash: base.HexToHash("0x01"), BlockNumber: 10, GasUsed: base.Gas(gasUsed)}
+	}
+	majority := NewScorecard(Compare([]Source{
+		{Name: "a", Transactions: []*Transaction{tx(21000)}},
+		{Name: "b", Transactions: []*Transaction{tx(21000)}},
+		{Name: "c", Transactions: []*Transaction{tx(30000)}},
+	}), 0)
+	for name, want := range map[string]int{"a": 0, "b": 0, "c": 1} {
+		if got := findScore(majority.Overall, name).Mismatched; got != want {
+			t.Errorf("expected %s charged %d mismatches against the majority, got %d", name, want, got)
+		}
+	}
+
+	tie := NewScorecard(Compare([]Source{
+		{Name: "chifra", Transactions: []*Transaction{tx(21000)}},
+		{Name: "etherscan", Transactions: []*Transaction{tx(30000)}},
+	}), 0)
+	if findScore(tie.Overall, "chifra").Mismatched != 0 || findScore(tie.Overall, "etherscan").Mismatched != 1 {
+		t.Errorf("expected a tie settled by chifra's value, got %+v", tie.Overall)
+	}
+}
+
+func TestScorecardTestdata(t *testing.T) {
+	card := NewScorecard(Compare(standIns(t)), 0)
+
+	chifra, etherscan, alchemy := findScore(card.Overall, "chifra"), findScore(card.Overall, "etherscan"), findScore(card.Overall, "alchemy")
+	if chifra.Recall != 1 || chifra.Confirmed != 4264-946 || chifra.Mismatched != 0 {
+		t.Errorf("unexpected chifra score %+v", chifra)
+	}
+	if etherscan.Found != 706 || etherscan.Precision != 1 || etherscan.Mismatched != 12 {
+		t.Errorf("unexpected etherscan score %+v", etherscan)
+	}
+	if alchemy.Found != 2973 || alchemy.Mismatched != 0 {
+		t.Errorf("unexpected alchemy score %+v", alchemy)
+	}
+	if failed := findScore(card.ByCategory[Failed], "chifra"); failed.Found != 142 {
+		t.Errorf("expected chifra's 142 failed transactions, got %+v", failed)
+	}
+
+	if len(card.ByRange) == 0 || len(card.ByRange) > maxScoreRanges {
+		t.Fatalf("expected at most %d ranges, got %d", maxScoreRanges, len(card.ByRange))
+	}
+	union := 0
+	for _, rng := range card.ByRange {
+		union += findScore(rng.Scores, "chifra").Union
+	}
+	if union != 4264 {
+		t.Errorf("expected the ranges to cover every comparison, got %d", union)
+	}
+}
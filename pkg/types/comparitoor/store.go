@@ -18,6 +18,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/names"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
 )
 
@@ -45,26 +46,24 @@ func (c *ComparitoorCollection) getTransactionStore(payload *types.Payload, face
 			go func() {
 				defer close(ctx.ModelChan)
 				defer close(ctx.ErrorChan)
-				var src []*Transaction
-				// Import mock variables from mocks.go
-				// (They are package-level, so no explicit import needed)
-				switch facet {
-				case ComparitoorChifra:
-					src = mockChifra
-				case ComparitoorEtherscan:
-					src = mockEtherscan
-				case ComparitoorCovalent:
-					src = mockCovalent
-				case ComparitoorAlchemy:
-					src = mockAlchemy
-				case ComparitoorComparitoor:
-					src = append([]*Transaction{}, mockChifra...)
-					src = append(src, mockEtherscan...)
-					src = append(src, mockCovalent...)
-					src = append(src, mockAlchemy...)
+				// Every source goes into the one store and the facets pick theirs by tag
+				sources := fetchSources(ctx.Ctx, payload.ActiveChain, base.HexToAddress(payload.ActiveAddress))
+				report := Compare(sources)
+				setReport(storeKey, sources, report)
+				scorecard := NewScorecard(report, 0)
+				c.setComparisonSummary(report, scorecard)
+				c.setScorecardBuckets(scorecard)
+				for _, src := range sources {
+					for _, tx := range src.Transactions {
+						ctx.ModelChan <- tx
+					}
 				}
-				for _, tx := range src {
-					ctx.ModelChan <- tx
+				// The others' rows are already in the store when the failures are reported
+				if err := sourcesError(sources); err != nil {
+					select {
+					case ctx.ErrorChan <- err:
+					case <-ctx.Ctx.Done():
+					}
 				}
 			}()
 			// EXISTING_CODE
@@ -119,6 +118,10 @@ func (c *ComparitoorCollection) getStoreName(payload *types.Payload, facet types
 		return ""
 	}
 	name = fmt.Sprintf("%s-%s-%s", name, payload.ActiveChain, payload.ActiveAddress)
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
 	return name
 }
 
@@ -232,7 +232,7 @@ func (c *ContractsCollection) ResetSummary() {
 	}
 }
 
-func (c *ContractsCollection) ExportData(payload *types.Payload) (string, error) {
+func (c *ContractsCollection) ExportData(payload *types.Payload) (string, int, error) {
 	switch payload.DataFacet {
 	case ContractsDashboard:
 		return c.dashboardFacet.ExportData(payload, string(ContractsDashboard))
@@ -241,7 +241,7 @@ func (c *ContractsCollection) ExportData(payload *types.Payload) (string, error)
 	case ContractsEvents:
 		return c.eventsFacet.ExportData(payload, string(ContractsEvents))
 	default:
-		return "", fmt.Errorf("[ExportData] unsupported contracts facet: %s", payload.DataFacet)
+		return "", 0, fmt.Errorf("[ExportData] unsupported contracts facet: %s", payload.DataFacet)
 	}
 }
 
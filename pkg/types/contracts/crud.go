@@ -0,0 +1,111 @@
+package contracts
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/project"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/names"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/crud"
+)
+
+func (c *ContractsCollection) Crud(
+	payload *types.Payload,
+	op crud.Operation,
+	item interface{},
+) error {
+	var entry = project.Contract{Address: base.HexToAddress(payload.TargetAddress)}
+	switch cast := item.(type) {
+	case *project.Contract:
+		if cast != nil {
+			entry = *cast
+		}
+	case *any:
+		// From the frontend the contract arrives as a decoded JSON object
+		if cast != nil && *cast != nil {
+			data, _ := json.Marshal(*cast)
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return fmt.Errorf("item is not a contract: %w", err)
+			}
+		}
+	}
+	if entry.Chain == "" {
+		entry.Chain = payload.ActiveChain
+	}
+	if entry.Address.IsZero() {
+		return fmt.Errorf("a contract address is required")
+	}
+
+	if op == crud.Autoname {
+		if err := names.AutonameAddress(entry.Address.Hex()); err != nil {
+			msgs.EmitError("Contracts.Crud.Autoname", err)
+			return err
+		}
+		msgs.EmitStatus(fmt.Sprintf("completed autoname operation for address: %s", entry.Address))
+		return nil
+	}
+
+	reg := getRegistry()
+	if reg == nil {
+		return fmt.Errorf("no project is open")
+	}
+
+	var err error
+	switch op {
+	case crud.Create, crud.Update:
+		if entry.DeployedBlock == 0 {
+			if entry.DeployedBlock, err = findDeployment(entry.Chain, entry.Address); err != nil {
+				logging.LogBEWarning(fmt.Sprintf("Contracts.Crud: %v", err))
+			}
+		}
+		err = reg.PutContract(entry)
+	case crud.Remove:
+		err = reg.RemoveContract(entry.Chain, entry.Address.Hex())
+	default:
+		return fmt.Errorf("operation %s not yet implemented for Contracts", op)
+	}
+	if err != nil {
+		msgs.EmitError("Contracts.Crud", err)
+		return err
+	}
+
+	if entry.Chain == payload.ActiveChain {
+		var contract *Contract
+		if op != crud.Remove {
+			contract = newContract(payload, entry)
+		}
+		store := c.dashboardFacet.GetStore()
+		store.UpdateData(func(data []*Contract) []*Contract {
+			return updateContractInData(data, entry.Address, contract)
+		})
+		c.dashboardFacet.SyncWithStore()
+		c.executeFacet.SyncWithStore()
+	}
+
+	msgs.EmitStatus(fmt.Sprintf("completed %s operation for contract: %s", op, entry.Address))
+	return nil
+}
+
+// updateContractInData replaces the contract at the address, adds it if it is new or,
+// if contract is nil, removes it
+func updateContractInData(data []*Contract, address base.Address, contract *Contract) []*Contract {
+	result := make([]*Contract, 0, len(data)+1)
+	found := false
+	for _, existing := range data {
+		if existing.Address != address {
+			result = append(result, existing)
+		} else if contract != nil && !found {
+			result = append(result, contract)
+			found = true
+		}
+	}
+	if contract != nil && !found {
+		result = append(result, contract)
+	}
+	return result
+}
@@ -0,0 +1,272 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// EventView is a sub-view of the events facet narrowed to one of the contract's events,
+// with a column for each of the event's decoded parameters
+type EventView struct {
+	Name      string               `json:"name"`
+	Signature string               `json:"signature"`
+	Topic     string               `json:"topic"`
+	Count     int                  `json:"count"`
+	Columns   []types.ColumnConfig `json:"columns"`
+}
+
+// eventViews lists a view for each event in the ABI with the number of stored logs it has
+func eventViews(abi *sdk.Abi, logs []*Log) []EventView {
+	ret := []EventView{}
+	if abi == nil {
+		return ret
+	}
+	for _, fn := range abi.Functions {
+		if fn.FunctionType != "event" {
+			continue
+		}
+		view := EventView{Name: fn.Name, Signature: fn.Signature, Topic: strings.ToLower(fn.Encoding), Columns: []types.ColumnConfig{}}
+		for i, in := range fn.Inputs {
+			view.Columns = append(view.Columns, types.ColumnConfig{
+				Key:      paramKey(i, in.Name),
+				Header:   in.Name,
+				Sortable: true,
+				Type:     paramDisplayType(in.ParameterType),
+				Order:    i,
+			})
+		}
+		for _, log := range logs {
+			if view.matches(log) {
+				view.Count++
+			}
+		}
+		ret = append(ret, view)
+	}
+	return ret
+}
+
+func (v *EventView) matches(log *Log) bool {
+	if len(log.Topics) > 0 {
+		return strings.EqualFold(log.Topics[0].Hex(), v.Topic)
+	}
+	return log.ArticulatedLog != nil && log.ArticulatedLog.Name == v.Name
+}
+
+// logFields are the log's own columns, which decoded parameters must not shadow
+var logFields = map[string]bool{
+	"address": true, "addressName": true, "articulatedLog": true, "blockHash": true, "blockNumber": true,
+	"data": true, "logIndex": true, "timestamp": true, "topics": true, "transactionHash": true,
+	"transactionIndex": true, "compressedLog": true, "date": true,
+}
+
+// paramKey is the column key of an event's i-th parameter
+func paramKey(i int, name string) string {
+	if name == "" {
+		return fmt.Sprintf("arg%d", i)
+	}
+	if logFields[name] {
+		return name + "_"
+	}
+	return name
+}
+
+func paramDisplayType(solidityType string) string {
+	switch solidityType {
+	case "address":
+		return "address"
+	case "bool":
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+// decodeLog returns the log's articulated parameters by column key as they were decoded.
+// Filters and sorts compare them without regard to case.
+func decodeLog(log *Log) map[string]string {
+	ret := make(map[string]string)
+	if log.ArticulatedLog == nil {
+		return ret
+	}
+	for i, in := range log.ArticulatedLog.Inputs {
+		ret[paramKey(i, in.Name)] = fmt.Sprint(in.Value)
+	}
+	return ret
+}
+
+// eventFilter is a parsed events facet filter. Words like "spender:0xabc" match a decoded
+// parameter containing the value, "value=0" one equal to it and "value>1000" or
+// "value<1000" compare numerically. The word "event:approval" narrows the logs to an
+// event by name, signature or topic. Other words match a decoded value or the hash.
+type eventFilter struct {
+	event string
+	terms []filterTerm
+	words []string
+}
+
+type filterTerm struct {
+	key, op, value string
+}
+
+func parseEventFilter(filter string) eventFilter {
+	var ret eventFilter
+	for _, word := range strings.Fields(filter) {
+		if i := strings.IndexAny(word, ":=<>"); i > 0 && i < len(word)-1 {
+			key, op, value := word[:i], word[i:i+1], strings.ToLower(word[i+1:])
+			if strings.EqualFold(key, "event") && op == ":" {
+				ret.event = value
+			} else {
+				ret.terms = append(ret.terms, filterTerm{key: key, op: op, value: value})
+			}
+			continue
+		}
+		ret.words = append(ret.words, strings.ToLower(word))
+	}
+	return ret
+}
+
+func (f *eventFilter) matches(log *Log) bool {
+	if f.event != "" {
+		if log.ArticulatedLog == nil {
+			return false
+		}
+		name := strings.ToLower(log.ArticulatedLog.Name)
+		sig := strings.ToLower(log.ArticulatedLog.Signature)
+		topic := ""
+		if len(log.Topics) > 0 {
+			topic = strings.ToLower(log.Topics[0].Hex())
+		}
+		if f.event != name && f.event != sig && f.event != topic {
+			return false
+		}
+	}
+
+	decoded := decodeLog(log)
+	for _, term := range f.terms {
+		value, ok := decodedValue(decoded, term.key)
+		if !ok {
+			return false
+		}
+		value = strings.ToLower(value)
+		switch term.op {
+		case ":":
+			if !strings.Contains(value, term.value) {
+				return false
+			}
+		case "=":
+			if value != term.value {
+				return false
+			}
+		case ">", "<":
+			cmp, ok := compareNumbers(value, term.value)
+			if !ok || (term.op == ">" && cmp <= 0) || (term.op == "<" && cmp >= 0) {
+				return false
+			}
+		}
+	}
+
+	for _, word := range f.words {
+		found := strings.Contains(strings.ToLower(log.TransactionHash.Hex()), word)
+		for _, value := range decoded {
+			found = found || strings.Contains(strings.ToLower(value), word)
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// decodedValue looks up a decoded parameter by name, ignoring case
+func decodedValue(decoded map[string]string, key string) (string, bool) {
+	if value, ok := decoded[key]; ok {
+		return value, true
+	}
+	for k, value := range decoded {
+		if strings.EqualFold(k, key) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func compareNumbers(a, b string) (int, bool) {
+	x, ok1 := new(big.Int).SetString(a, 0)
+	y, ok2 := new(big.Int).SetString(b, 0)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	return x.Cmp(y), true
+}
+
+// sortLogs sorts by the log's position fields and by decoded parameters, numerically when
+// both values are numbers. Without a sort the logs are in chain order.
+func sortLogs(items []Log, spec sdk.SortSpec) error {
+	decoded := make([]map[string]string, len(items))
+	for i := range items {
+		decoded[i] = decodeLog(&items[i])
+	}
+	position := func(log *Log, field string) (uint64, bool) {
+		switch field {
+		case "blockNumber":
+			return uint64(log.BlockNumber), true
+		case "transactionIndex":
+			return uint64(log.TransactionIndex), true
+		case "logIndex":
+			return uint64(log.LogIndex), true
+		}
+		return 0, false
+	}
+	compare := func(i, j int, field string) int {
+		if a, ok := position(&items[i], field); ok {
+			b, _ := position(&items[j], field)
+			switch {
+			case a < b:
+				return -1
+			case a > b:
+				return 1
+			}
+			return 0
+		}
+		a, b := decoded[i][field], decoded[j][field]
+		if cmp, ok := compareNumbers(a, b); ok {
+			return cmp
+		}
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+
+	fields, orders := spec.Fields, spec.Order
+	if len(fields) == 0 {
+		fields = []string{"blockNumber", "transactionIndex", "logIndex"}
+		orders = nil
+	}
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(x, y int) bool {
+		for f, field := range fields {
+			cmp := compare(order[x], order[y], field)
+			if f < len(orders) && orders[f] == sdk.Dec {
+				cmp = -cmp
+			}
+			if cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	})
+
+	sorted := make([]Log, len(items))
+	for i, idx := range order {
+		sorted[i] = items[idx]
+	}
+	copy(items, sorted)
+	return nil
+}
@@ -0,0 +1,98 @@
+package contracts
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+const (
+	approvalTopic = "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+	transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	mintTopic     = "0x0f6798a560793a54c3bcfe86a93cde1e73087d944c0ea20544137d4121396885"
+)
+
+func eventLog(blk uint64, topic, name string, args ...any) Log {
+	fn := &sdk.Function{Name: name, FunctionType: "event"}
+	keys := map[string][]string{"Approval": {"owner", "spender", "value"}, "Transfer": {"from", "to", "value"}, "Mint": {"to", "tokenId"}}[name]
+	for i, v := range args {
+		fn.Inputs = append(fn.Inputs, sdk.Parameter{Name: keys[i], Value: v})
+	}
+	return Log{BlockNumber: base.Blknum(blk), Topics: []base.Hash{base.HexToHash(topic)}, ArticulatedLog: fn}
+}
+
+func TestEventViews(t *testing.T) {
+	addr := sdk.Parameter{ParameterType: "address"}
+	abi := &sdk.Abi{Functions: []sdk.Function{
+		{Name: "Approval", FunctionType: "event", Encoding: approvalTopic, Inputs: []sdk.Parameter{
+			{Name: "owner", ParameterType: "address"}, {Name: "spender", ParameterType: "address"}, {Name: "value", ParameterType: "uint256"}}},
+		{Name: "Transfer", FunctionType: "event", Encoding: transferTopic, Inputs: []sdk.Parameter{addr, {Name: "address", ParameterType: "address"}}},
+		{Name: "approve", FunctionType: "function"},
+	}}
+	a := eventLog(1, approvalTopic, "Approval", "0xaa", "0xbb", "5")
+	b := eventLog(2, transferTopic, "Transfer", "0xaa", "0xbb", "7")
+	views := eventViews(abi, []*Log{&a, &b, &a})
+
+	if len(views) != 2 || views[0].Count != 2 || views[1].Count != 1 {
+		t.Fatalf("unexpected views %+v", views)
+	}
+	if cols := views[0].Columns; len(cols) != 3 || cols[1].Key != "spender" || cols[0].Type != "address" || cols[2].Type != "string" {
+		t.Errorf("unexpected Approval columns %+v", cols)
+	}
+	if cols := views[1].Columns; cols[0].Key != "arg0" || cols[1].Key != "address_" {
+		t.Errorf("expected unnamed and shadowing parameters to get their own keys, got %+v", cols)
+	}
+}
+
+func TestEventFilterAndSort(t *testing.T) {
+	logs := []Log{
+		eventLog(1, approvalTopic, "Approval", "0xAA", "0xbb", "1000"),
+		eventLog(2, transferTopic, "Transfer", "0xaa", "0xbb", "50"),
+		eventLog(3, approvalTopic, "Approval", "0xaa", "0xcc", "20"),
+		eventLog(4, approvalTopic, "Approval", "0xdd", "0xbb", "300"),
+		eventLog(5, mintTopic, "Mint", "0xee", "7"),
+	}
+	match := func(filter string) []base.Blknum {
+		f := parseEventFilter(filter)
+		ret := []base.Blknum{}
+		for i := range logs {
+			if f.matches(&logs[i]) {
+				ret = append(ret, logs[i].BlockNumber)
+			}
+		}
+		return ret
+	}
+	cases := map[string]int{
+		"event:approval":                 3,
+		"event:" + transferTopic:         1,
+		"event:approval spender:0xbb":    2,
+		"event:approval value>100":       2,
+		"value<100":                      2,
+		"tokenId>5":                      1,
+		"tokenid=7":                      1,
+		"Event:Mint tokenId<5":           0,
+		"owner=0xaa":                     2,
+		"0xcc":                           1,
+		"event:approval from:0xaa":       0,
+		"event:approval value>notnumber": 0,
+	}
+	for filter, want := range cases {
+		if got := match(filter); len(got) != want {
+			t.Errorf("%q matched %v, want %d logs", filter, got, want)
+		}
+	}
+
+	if got := decodeLog(&logs[0])["owner"]; got != "0xAA" {
+		t.Errorf("expected decoded values returned as decoded, got %q", got)
+	}
+
+	_ = sortLogs(logs, sdk.SortSpec{Fields: []string{"value"}, Order: []sdk.SortOrder{sdk.Dec}})
+	if logs[0].BlockNumber != 1 || logs[1].BlockNumber != 4 || logs[3].BlockNumber != 3 {
+		t.Errorf("expected a numeric sort on value, got %v %v %v %v", logs[0].BlockNumber, logs[1].BlockNumber, logs[2].BlockNumber, logs[3].BlockNumber)
+	}
+	_ = sortLogs(logs, sdk.SortSpec{})
+	if logs[0].BlockNumber != 1 || logs[3].BlockNumber != 4 {
+		t.Errorf("expected chain order without a sort")
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
 
@@ -27,6 +28,9 @@ type ContractsPage struct {
 	ExpectedTotal int              `json:"expectedTotal"`
 	State         types.StoreState `json:"state"`
 	// EXISTING_CODE
+	DeployedBlocks []base.Blknum       `json:"deployedBlocks"`
+	Events         []EventView         `json:"events"`
+	EventValues    []map[string]string `json:"eventValues"`
 	// EXISTING_CODE
 }
 
@@ -112,7 +116,7 @@ func (c *ContractsCollection) GetPage(
 			}
 		}
 		sortFunc := func(items []Log, sort sdk.SortSpec) error {
-			return sdk.SortLogs(items, sort)
+			return sortLogs(items, sort)
 		}
 		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
 			return nil, types.NewStoreError("contracts", dataFacet, "GetPage", err)
@@ -127,6 +131,23 @@ func (c *ContractsCollection) GetPage(
 			fmt.Errorf("[GetPage] unsupported dataFacet: %v", payload.DataFacet))
 	}
 
+	// EXISTING_CODE
+	if len(page.Contracts) > 0 {
+		page.DeployedBlocks = deployedBlocks(payload, page.Contracts)
+	}
+	if dataFacet == ContractsEvents {
+		page.Events = []EventView{}
+		if base.IsValidAddress(payload.ActiveContract) {
+			if abi, err := c.contractAbi(payload, base.HexToAddress(payload.ActiveContract)); err == nil {
+				page.Events = eventViews(abi, c.eventsFacet.GetStore().GetItems(false))
+			}
+		}
+		page.EventValues = make([]map[string]string, len(page.Logs))
+		for i := range page.Logs {
+			page.EventValues[i] = decodeLog(&page.Logs[i])
+		}
+	}
+	// EXISTING_CODE
 	return page, nil
 }
 
@@ -220,10 +241,8 @@ func (c *ContractsCollection) matchesExecuteFilter(item *Contract, filter string
 }
 
 func (c *ContractsCollection) matchesEventFilter(item *Log, filter string) bool {
-	_ = item    // delint
-	_ = filter  // delint
-	return true // strings.Contains(strings.ToLower(item.TransactionHash.Hex()), filter) ||
-	// strings.Contains(strings.ToLower(item.ContractAddress.Hex()), filter)
+	f := parseEventFilter(filter)
+	return f.matches(item)
 }
 
 // EXISTING_CODE
@@ -0,0 +1,252 @@
+package contracts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/abis"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// ReadCall is a view or pure function with its arguments filled in
+type ReadCall struct {
+	Name      string   `json:"name"`
+	Signature string   `json:"signature"`
+	Args      []string `json:"args,omitempty"`
+	encoding  string
+}
+
+// ReadResult is a read call's decoded outputs, or the reason it failed
+type ReadResult struct {
+	ReadCall
+	Outputs []sdk.Parameter `json:"outputs"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// ContractState is what the dashboard read from a contract at one block
+type ContractState struct {
+	Address     base.Address `json:"address"`
+	Holder      base.Address `json:"holder"`
+	BlockNumber base.Blknum  `json:"blockNumber"`
+	Results     []ReadResult `json:"results"`
+}
+
+var (
+	states   = make(map[string]*ContractState)
+	statesMu sync.Mutex
+)
+
+var latestBlock = sdk.GetLatestBlock
+
+// callContract makes one read call at a block and returns its decoded outputs
+var callContract = func(chain string, address base.Address, block base.Blknum, calldata string) ([]sdk.Parameter, error) {
+	blockId := "latest"
+	if block != 0 {
+		blockId = fmt.Sprintf("%d", block)
+	}
+	opts := sdk.StateOptions{
+		Globals:    sdk.Globals{Cache: true, Chain: chain},
+		Addrs:      []string{address.Hex()},
+		BlockIds:   []string{blockId},
+		Calldata:   calldata,
+		Articulate: true,
+	}
+	results, _, err := opts.StateCall()
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no result")
+	}
+	if out := results[0].ArticulatedOut; out != nil && len(out.Outputs) > 0 {
+		return out.Outputs, nil
+	}
+	keys := make([]string, 0, len(results[0].Values))
+	for k := range results[0].Values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	ret := make([]sdk.Parameter, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, sdk.Parameter{Name: k, Value: results[0].Values[k]})
+	}
+	return ret, nil
+}
+
+// readCalls lists the ABI's view and pure functions that can be called without user input.
+// Functions that take no arguments are called as they are. Functions that take only
+// addresses are called with the holder, such as balanceOf(holder), and, with a second
+// address, once for each spender, such as allowance(holder, spender).
+func readCalls(abi *sdk.Abi, holder base.Address, spenders []base.Address) []ReadCall {
+	ret := []ReadCall{}
+	if abi == nil {
+		return ret
+	}
+	for _, fn := range abi.Functions {
+		if fn.FunctionType != "function" {
+			continue
+		}
+		if fn.StateMutability != "view" && fn.StateMutability != "pure" && !(fn.StateMutability == "" && fn.Constant) {
+			continue
+		}
+		call := ReadCall{Name: fn.Name, Signature: fn.Signature, encoding: fn.Encoding}
+		allAddresses := len(fn.Inputs) > 0
+		for _, in := range fn.Inputs {
+			allAddresses = allAddresses && in.ParameterType == "address"
+		}
+		switch {
+		case len(fn.Inputs) == 0:
+			ret = append(ret, call)
+		case allAddresses && !holder.IsZero() && len(fn.Inputs) == 1:
+			call.Args = []string{holder.Hex()}
+			ret = append(ret, call)
+		case allAddresses && !holder.IsZero() && len(fn.Inputs) == 2:
+			for _, spender := range spenders {
+				call.Args = []string{holder.Hex(), spender.Hex()}
+				ret = append(ret, call)
+			}
+		}
+	}
+	return ret
+}
+
+// calldata is the call as chifra reads it, by four-byte when the ABI has it so overloaded
+// names are not ambiguous
+func (c ReadCall) calldata() string {
+	name := c.encoding
+	if name == "" {
+		name = c.Name
+	}
+	return name + "(" + strings.Join(c.Args, ", ") + ")"
+}
+
+// ReadState calls the active contract's read functions at the latest block for the
+// payload's address. Results are kept per block, so unless refresh is set a block is only
+// read once. The registry's other contracts are always among the spenders.
+func (c *ContractsCollection) ReadState(payload *types.Payload, spenders []string, refresh bool) (*ContractState, error) {
+	if !base.IsValidAddress(payload.ActiveContract) {
+		return nil, fmt.Errorf("no contract is selected")
+	}
+	address := base.HexToAddress(payload.ActiveContract)
+	holder := base.HexToAddress(payload.ActiveAddress)
+
+	spenderAddrs := []base.Address{}
+	seen := map[base.Address]bool{address: true}
+	add := func(addr base.Address) {
+		if !addr.IsZero() && !seen[addr] {
+			seen[addr] = true
+			spenderAddrs = append(spenderAddrs, addr)
+		}
+	}
+	for _, s := range spenders {
+		if base.IsValidAddress(s) {
+			add(base.HexToAddress(s))
+		}
+	}
+	if reg := getRegistry(); reg != nil {
+		for _, entry := range reg.GetContracts(payload.ActiveChain) {
+			add(entry.Address)
+		}
+	}
+
+	// Only the latest block read is kept for each contract, holder and set of spenders
+	keyParts := []string{payload.ActiveChain, address.Hex(), holder.Hex()}
+	for _, s := range spenderAddrs {
+		keyParts = append(keyParts, s.Hex())
+	}
+	key := strings.Join(keyParts, "_")
+	block := latestBlock(payload.ActiveChain)
+
+	statesMu.Lock()
+	cached := states[key]
+	statesMu.Unlock()
+	if cached != nil && cached.BlockNumber == block && !refresh {
+		return cached, nil
+	}
+
+	abi, err := c.contractAbi(payload, address)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := readCalls(abi, holder, spenderAddrs)
+	state := &ContractState{Address: address, Holder: holder, BlockNumber: block, Results: make([]ReadResult, len(calls))}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 8)
+	for i, call := range calls {
+		wg.Add(1)
+		go func(i int, call ReadCall) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			result := ReadResult{ReadCall: call}
+			if outputs, err := callContract(payload.ActiveChain, address, block, call.calldata()); err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Outputs = outputs
+			}
+			state.Results[i] = result
+		}(i, call)
+	}
+	wg.Wait()
+
+	statesMu.Lock()
+	states[key] = state
+	statesMu.Unlock()
+	return state, nil
+}
+
+var (
+	resolvedAbis   = make(map[string]*sdk.Abi)
+	resolvedAbisMu sync.Mutex
+)
+
+func init() {
+	abis.OnAbiChanged(forgetAbi)
+}
+
+// forgetAbi drops the address's resolved ABI on every chain and reloads the dashboard,
+// whose rows carry their ABIs, after the ABI is imported or removed
+func forgetAbi(address base.Address) {
+	suffix := "_" + address.Hex()
+	resolvedAbisMu.Lock()
+	for key := range resolvedAbis {
+		if strings.HasSuffix(key, suffix) {
+			delete(resolvedAbis, key)
+		}
+	}
+	resolvedAbisMu.Unlock()
+	ResetRegistryFacets()
+}
+
+// contractAbi returns the ABI the dashboard already loaded for the contract, resolving it
+// once per chain if the contract is not in the store
+func (c *ContractsCollection) contractAbi(payload *types.Payload, address base.Address) (*sdk.Abi, error) {
+	for _, contract := range c.dashboardFacet.GetStore().GetItems(false) {
+		if contract.Address == address && contract.Abi != nil {
+			return contract.Abi, nil
+		}
+	}
+
+	key := payload.ActiveChain + "_" + address.Hex()
+	resolvedAbisMu.Lock()
+	cached := resolvedAbis[key]
+	resolvedAbisMu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	abi, err := resolveAbi(payload, address)
+	if err != nil {
+		return nil, err
+	}
+	resolvedAbisMu.Lock()
+	resolvedAbis[key] = abi
+	resolvedAbisMu.Unlock()
+	return abi, nil
+}
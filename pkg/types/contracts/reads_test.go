@@ -0,0 +1,141 @@
+package contracts
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+func testAbi() *sdk.Abi {
+	addr := sdk.Parameter{ParameterType: "address"}
+	return &sdk.Abi{Functions: []sdk.Function{
+		{Name: "totalSupply", FunctionType: "function", StateMutability: "view", Encoding: "0x18160ddd"},
+		{Name: "paused", FunctionType: "function", StateMutability: "view"},
+		{Name: "balanceOf", FunctionType: "function", StateMutability: "view", Inputs: []sdk.Parameter{addr}},
+		{Name: "allowance", FunctionType: "function", StateMutability: "view", Inputs: []sdk.Parameter{addr, addr}},
+		{Name: "getRole", FunctionType: "function", StateMutability: "view", Inputs: []sdk.Parameter{{ParameterType: "uint256"}}},
+		{Name: "transfer", FunctionType: "function", StateMutability: "nonpayable"},
+		{Name: "Transfer", FunctionType: "event"},
+	}}
+}
+
+func TestReadCalls(t *testing.T) {
+	holder := base.HexToAddress("0x1111111111111111111111111111111111111111")
+	spenders := []base.Address{base.HexToAddress("0x2222222222222222222222222222222222222222"), base.HexToAddress("0x3333333333333333333333333333333333333333")}
+
+	calls := readCalls(testAbi(), holder, spenders)
+	got := []string{}
+	for _, call := range calls {
+		got = append(got, call.calldata())
+	}
+	want := []string{
+		"0x18160ddd()",
+		"paused()",
+		"balanceOf(" + holder.Hex() + ")",
+		"allowance(" + holder.Hex() + ", " + spenders[0].Hex() + ")",
+		"allowance(" + holder.Hex() + ", " + spenders[1].Hex() + ")",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if calls := readCalls(testAbi(), base.ZeroAddr, spenders); len(calls) != 2 {
+		t.Errorf("expected only the no-input calls without a holder, got %d", len(calls))
+	}
+}
+
+func TestReadStateCachedPerBlock(t *testing.T) {
+	savedResolve, savedCall, savedLatest := resolveAbi, callContract, latestBlock
+	defer func() { resolveAbi, callContract, latestBlock = savedResolve, savedCall, savedLatest }()
+	resolveAbi = func(payload *types.Payload, address base.Address) (*sdk.Abi, error) {
+		return testAbi(), nil
+	}
+	var calls atomic.Int32
+	callContract = func(chain string, address base.Address, block base.Blknum, calldata string) ([]sdk.Parameter, error) {
+		calls.Add(1)
+		if calldata == "paused()" {
+			return nil, fmt.Errorf("execution reverted")
+		}
+		return []sdk.Parameter{{Name: "val_0", Value: fmt.Sprint(block)}}, nil
+	}
+	block := base.Blknum(100)
+	latestBlock = func(chain string) base.Blknum { return block }
+
+	payload := &types.Payload{
+		Collection:     "contracts",
+		DataFacet:      ContractsDashboard,
+		ActiveChain:    "mainnet",
+		ActiveAddress:  "0x1111111111111111111111111111111111111111",
+		ActiveContract: "0x0c316b7042b419d07d343f2f4f5bd54ff731183d",
+	}
+	c := NewContractsCollection(payload)
+	spenders := []string{"0x2222222222222222222222222222222222222222"}
+
+	state, err := c.ReadState(payload, spenders, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Results) != 4 || calls.Load() != 4 || state.BlockNumber != 100 {
+		t.Fatalf("expected four reads at block 100, got %d results, %d calls", len(state.Results), calls.Load())
+	}
+	if state.Results[1].Error == "" || state.Results[0].Outputs[0].Value != "100" {
+		t.Errorf("unexpected results %+v", state.Results)
+	}
+
+	_, _ = c.ReadState(payload, spenders, false)
+	if calls.Load() != 4 {
+		t.Errorf("expected the same block to come from the cache")
+	}
+	_, _ = c.ReadState(payload, spenders, true)
+	if calls.Load() != 8 {
+		t.Errorf("expected a refresh to read again")
+	}
+	block = 101
+	if state, _ = c.ReadState(payload, spenders, false); calls.Load() != 12 || state.BlockNumber != 101 {
+		t.Errorf("expected a new block to read again")
+	}
+}
+
+func TestContractAbiResolvedOnce(t *testing.T) {
+	savedResolve := resolveAbi
+	defer func() { resolveAbi = savedResolve }()
+	var resolves atomic.Int32
+	resolveAbi = func(payload *types.Payload, address base.Address) (*sdk.Abi, error) {
+		if resolves.Add(1) == 1 {
+			return nil, fmt.Errorf("not found")
+		}
+		return testAbi(), nil
+	}
+
+	payload := &types.Payload{
+		Collection:     "contracts",
+		DataFacet:      ContractsEvents,
+		ActiveChain:    "gnosis",
+		ActiveAddress:  "0x1111111111111111111111111111111111111111",
+		ActiveContract: "0x3333333333333333333333333333333333333333",
+	}
+	c := NewContractsCollection(payload)
+	address := base.HexToAddress(payload.ActiveContract)
+
+	if _, err := c.contractAbi(payload, address); err == nil {
+		t.Fatal("expected the first resolve to fail")
+	}
+	for i := 0; i < 3; i++ {
+		if abi, err := c.contractAbi(payload, address); err != nil || abi == nil {
+			t.Fatalf("expected an abi, got %v", err)
+		}
+	}
+	if resolves.Load() != 2 {
+		t.Errorf("expected a failed resolve to be retried and a found abi to be cached, got %d resolves", resolves.Load())
+	}
+
+	forgetAbi(address)
+	if _, err := c.contractAbi(payload, address); err != nil || resolves.Load() != 3 {
+		t.Errorf("expected an imported or removed abi to be resolved again, got %d resolves", resolves.Load())
+	}
+}
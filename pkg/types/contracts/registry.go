@@ -0,0 +1,130 @@
+package contracts
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/project"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/abis"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/names"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// Registry holds the contracts the view shows on each chain, usually the active project
+type Registry interface {
+	GetContracts(chain string) []project.Contract
+	PutContract(contract project.Contract) error
+	RemoveContract(chain, contract string) error
+}
+
+var (
+	registry   func() Registry
+	registryMu sync.RWMutex
+)
+
+// ConfigureRegistry sets how the current registry is found. The function may return nil
+// when there is none, such as before a project is open.
+func ConfigureRegistry(get func() Registry) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = get
+}
+
+func getRegistry() Registry {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if registry == nil {
+		return nil
+	}
+	return registry()
+}
+
+var resolveAbi = func(payload *types.Payload, address base.Address) (*sdk.Abi, error) {
+	return abis.GetAbisCollection(payload).ResolveAbi(payload, address)
+}
+
+var findDeployment = func(chain string, address base.Address) (base.Blknum, error) {
+	opts := sdk.StateOptions{
+		Addrs:   []string{address.Hex()},
+		Parts:   sdk.SPDeployed,
+		Globals: sdk.Globals{Cache: true, Chain: chain},
+	}
+	states, _, err := opts.State()
+	if err != nil {
+		return 0, err
+	}
+	if len(states) == 0 || states[0].Deployed == 0 {
+		return 0, fmt.Errorf("no deployment found for %s", address.Hex())
+	}
+	return states[0].Deployed, nil
+}
+
+// RegisteredContracts returns the registry's contracts on the payload's chain, each with
+// its ABI. A contract whose ABI cannot be resolved carries the error instead.
+func RegisteredContracts(payload *types.Payload) []*Contract {
+	ret := []*Contract{}
+	reg := getRegistry()
+	if reg == nil {
+		return ret
+	}
+	for _, entry := range reg.GetContracts(payload.ActiveChain) {
+		ret = append(ret, newContract(payload, entry))
+	}
+	return ret
+}
+
+func newContract(payload *types.Payload, entry project.Contract) *Contract {
+	contract := &Contract{
+		Address:     entry.Address,
+		Name:        entry.Name,
+		LastUpdated: base.Timestamp(time.Now().Unix()),
+	}
+	if contract.Name == "" {
+		contract.Name = names.NameAddress(entry.Address)
+	}
+	if abi, err := resolveAbi(payload, entry.Address); err != nil {
+		contract.ErrorCount++
+		contract.LastError = err.Error()
+	} else {
+		contract.Abi = abi
+	}
+	return contract
+}
+
+// deployedBlocks returns the deployment block of each contract, aligned with the rows
+func deployedBlocks(payload *types.Payload, rows []Contract) []base.Blknum {
+	ret := make([]base.Blknum, len(rows))
+	reg := getRegistry()
+	if reg == nil {
+		return ret
+	}
+	blocks := make(map[base.Address]base.Blknum)
+	for _, entry := range reg.GetContracts(payload.ActiveChain) {
+		blocks[entry.Address] = entry.DeployedBlock
+	}
+	for i := range rows {
+		ret[i] = blocks[rows[i].Address]
+	}
+	return ret
+}
+
+// ResetRegistryFacets clears every loaded dashboard and execute facet so that they reload
+// from the registry. Call it when the registry changes, such as when another project
+// becomes active.
+func ResetRegistryFacets() {
+	collectionsMu.Lock()
+	list := make([]*ContractsCollection, 0, len(collections))
+	for _, c := range collections {
+		list = append(list, c)
+	}
+	collectionsMu.Unlock()
+
+	for _, c := range list {
+		c.dashboardFacet.Reset()
+		c.executeFacet.Reset()
+	}
+}
@@ -0,0 +1,81 @@
+package contracts
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/project"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/crud"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+func TestRegistryCrud(t *testing.T) {
+	proj := project.NewProject("test", base.ZeroAddr, []string{"mainnet"})
+	ConfigureRegistry(func() Registry { return proj })
+	defer ConfigureRegistry(nil)
+
+	savedResolve, savedFind := resolveAbi, findDeployment
+	defer func() { resolveAbi, findDeployment = savedResolve, savedFind }()
+	resolveAbi = func(payload *types.Payload, address base.Address) (*sdk.Abi, error) {
+		return &sdk.Abi{Address: address, NFunctions: 2}, nil
+	}
+	findDeployment = func(chain string, address base.Address) (base.Blknum, error) {
+		return 1234, nil
+	}
+
+	payload := &types.Payload{Collection: "contracts", DataFacet: ContractsDashboard, ActiveChain: "mainnet"}
+	c := NewContractsCollection(payload)
+	addr := base.HexToAddress("0x0c316b7042b419d07d343f2f4f5bd54ff731183d")
+
+	var item any = map[string]any{"address": addr.Hex(), "name": "Ours"}
+	if err := c.Crud(payload, crud.Create, &item); err != nil {
+		t.Fatal(err)
+	}
+	entries := proj.GetContracts("mainnet")
+	if len(entries) != 1 || entries[0].Name != "Ours" || entries[0].DeployedBlock != 1234 {
+		t.Fatalf("expected the contract in the project with its deployment, got %+v", entries)
+	}
+
+	rows := c.dashboardFacet.GetStore().GetItems(false)
+	if len(rows) != 1 || rows[0].Name != "Ours" || rows[0].Abi == nil || rows[0].Abi.NFunctions != 2 {
+		t.Fatalf("expected the contract with its ABI in the store, got %+v", rows)
+	}
+	if blocks := deployedBlocks(payload, []Contract{*rows[0]}); blocks[0] != 1234 {
+		t.Errorf("expected the deployment block alongside the row, got %v", blocks)
+	}
+
+	if err := c.Crud(payload, crud.Update, &project.Contract{Address: addr, Name: "Renamed", DeployedBlock: 99}); err != nil {
+		t.Fatal(err)
+	}
+	if rows := c.dashboardFacet.GetStore().GetItems(false); len(rows) != 1 || rows[0].Name != "Renamed" {
+		t.Errorf("expected the contract to be replaced, got %+v", rows)
+	}
+
+	if err := c.Crud(&types.Payload{ActiveChain: "mainnet", TargetAddress: addr.Hex()}, crud.Remove, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(proj.GetContracts("mainnet")) != 0 || len(c.dashboardFacet.GetStore().GetItems(false)) != 0 {
+		t.Errorf("expected the contract to be removed from the project and the store")
+	}
+}
+
+func TestResetRegistryFacets(t *testing.T) {
+	payload := &types.Payload{Collection: "contracts", DataFacet: ContractsDashboard, ActiveChain: "mainnet", ActiveAddress: "0xf503017d7baf7fbc0fff7492b751025c6a78179b"}
+	c := GetContractsCollection(payload)
+	defer func() {
+		collectionsMu.Lock()
+		delete(collections, getStoreKey(payload))
+		collectionsMu.Unlock()
+		contractsStoreMu.Lock()
+		delete(contractsStore, getStoreKey(payload))
+		contractsStoreMu.Unlock()
+	}()
+
+	c.dashboardFacet.GetStore().AddItem(&Contract{Name: "Other project's"}, 0)
+	ResetRegistryFacets()
+	if n := len(c.dashboardFacet.GetStore().GetItems(false)); n != 0 || !c.NeedsUpdate(payload) {
+		t.Errorf("expected the dashboard to reload from the new registry, got %d rows", n)
+	}
+}
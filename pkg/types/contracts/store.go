@@ -50,7 +50,13 @@ func (c *ContractsCollection) getContractsStore(payload *types.Payload, facet ty
 	if theStore == nil {
 		queryFunc := func(ctx *output.RenderCtx) error {
 			// EXISTING_CODE
-			// Real SDK calls would go here
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				for _, contract := range RegisteredContracts(payload) {
+					ctx.ModelChan <- contract
+				}
+			}()
 			// EXISTING_CODE
 			return nil
 		}
@@ -73,13 +79,6 @@ func (c *ContractsCollection) getContractsStore(payload *types.Payload, facet ty
 		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
 
 		// EXISTING_CODE
-		// TODO: BOGUS Fix this mocking
-		// Add mock data to store
-		mockContracts := sdk.CreateContracts()
-		for i, c := range mockContracts {
-			theStore.AddItem(c, i)
-		}
-		theStore.ChangeState(types.StateLoaded, "Mock data loaded")
 		// EXISTING_CODE
 
 		contractsStore[storeKey] = theStore
@@ -173,6 +172,10 @@ func (c *ContractsCollection) getStoreName(payload *types.Payload, facet types.D
 		return ""
 	}
 	name = fmt.Sprintf("%s-%s-%s", name, payload.ActiveChain, payload.ActiveAddress)
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
 	return name
 }
 
@@ -323,7 +323,7 @@ func (c *DressesCollection) ResetSummary() {
 	}
 }
 
-func (c *DressesCollection) ExportData(payload *types.Payload) (string, error) {
+func (c *DressesCollection) ExportData(payload *types.Payload) (string, int, error) {
 	switch payload.DataFacet {
 	case DressesGenerator:
 		return c.generatorFacet.ExportData(payload, string(DressesGenerator))
@@ -338,7 +338,7 @@ func (c *DressesCollection) ExportData(payload *types.Payload) (string, error) {
 	case DressesGallery:
 		return c.galleryFacet.ExportData(payload, string(DressesGallery))
 	default:
-		return "", fmt.Errorf("[ExportData] unsupported dresses facet: %s", payload.DataFacet)
+		return "", 0, fmt.Errorf("[ExportData] unsupported dresses facet: %s", payload.DataFacet)
 	}
 }
 
@@ -193,6 +193,8 @@ func (c *DressesCollection) GetPage(
 			fmt.Errorf("[GetPage] unsupported dataFacet: %v", payload.DataFacet))
 	}
 
+	// EXISTING_CODE
+	// EXISTING_CODE
 	return page, nil
 }
 
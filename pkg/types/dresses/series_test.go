@@ -94,7 +94,7 @@ func TestSeriesExportCSV(t *testing.T) {
 		t.Fatalf("create2: %v", err)
 	}
 
-	out, err := coll.ExportData(payload)
+	out, _, err := coll.ExportData(payload)
 	if err != nil {
 		t.Fatalf("export: %v", err)
 	}
@@ -410,6 +410,10 @@ func (c *DressesCollection) getStoreName(payload *types.Payload, facet types.Dat
 		return ""
 	}
 	name = fmt.Sprintf("%s-%s-%s", name, payload.ActiveChain, payload.ActiveAddress)
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
 	return name
 }
 
@@ -1,7 +1,12 @@
 package types
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"regexp"
+	"strings"
 )
 
 // ErrorType represents different categories of errors that can occur
@@ -116,3 +121,78 @@ func GetErrorContext(err error) (ErrorType, string, string) {
 	}
 	return ErrorTypeUnknown, "", ""
 }
+
+// NewNetworkError creates an error for RPC or transport failures that may succeed on retry
+func NewNetworkError(collection string, dataFacet DataFacet, operation string, err error) *CollectionError {
+	return &CollectionError{
+		Type:       ErrorTypeNetwork,
+		Operation:  operation,
+		Collection: collection,
+		DataFacet:  dataFacet,
+		Underlying: err,
+		Message:    fmt.Sprintf("network failure during '%s' for %s in %s collection: %v", operation, dataFacet, collection, err),
+	}
+}
+
+// IsNetworkError checks if an error is a network or transport error
+func IsNetworkError(err error) bool {
+	var collErr *CollectionError
+	if errors.As(err, &collErr) {
+		return collErr.Type == ErrorTypeNetwork
+	}
+	return false
+}
+
+// transientMarkers are fragments of error text the SDK and RPC clients produce for
+// failures that usually clear up on their own
+var transientMarkers = []string{
+	"timeout",
+	"deadline exceeded",
+	"connection refused",
+	"connection reset",
+	"broken pipe",
+	"unexpected eof",
+	"too many requests",
+	"rate limit",
+	"bad gateway",
+	"service unavailable",
+	"temporarily unavailable",
+}
+
+// transientStatus matches an HTTP 429, 502, 503 or 504 status code as a whole token after
+// "status", "code" or "HTTP", so that digits in block numbers, hashes and addresses do not
+var transientStatus = regexp.MustCompile(`\b(?:status|code|http(?:/[\d.]+)?)\b[\s:=]*(?:429|50[234])\b`)
+
+// IsRetryableError reports whether a fetch that failed with err is worth retrying.
+// Network errors are always retryable, validation and cache errors never are, and
+// everything else is retried only if the underlying error looks transient.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var collErr *CollectionError
+	if errors.As(err, &collErr) {
+		switch collErr.Type {
+		case ErrorTypeNetwork:
+			return true
+		case ErrorTypeValidation, ErrorTypeCache:
+			return false
+		}
+		if collErr.Underlying != nil {
+			err = collErr.Underlying
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, marker := range transientMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return transientStatus.MatchString(msg)
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want bool
+	}{
+		{"dial tcp: connection refused", true},
+		{"429 Too Many Requests", true},
+		{"unexpected status 503", true},
+		{"HTTP 502: upstream failed", true},
+		{"HTTP/1.1 504", true},
+		{"rpc error: status code: 429", true},
+		{"block 18429502 not found", false},
+		{"no logs for 0x5034d0000000000000000000000000000000504a", false},
+		{"invalid transaction hash 0x429503", false},
+		{"status 404", false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryableError(errors.New(tt.msg)); got != tt.want {
+			t.Errorf("IsRetryableError(%q) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+
+	if IsRetryableError(NewValidationError("exports", "logs", "fetch", errors.New("timeout"))) {
+		t.Error("validation errors are never retryable")
+	}
+}
@@ -0,0 +1,165 @@
+package types
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// BundleManifest is written to an export bundle as manifest.json so that whoever receives
+// the bundle can check what it holds and that its files are intact
+type BundleManifest struct {
+	AppName    string        `json:"appName"`
+	AppVersion string        `json:"appVersion"`
+	CreatedAt  string        `json:"createdAt"`
+	Project    string        `json:"project"`
+	Address    string        `json:"address"`
+	Chain      string        `json:"chain"`
+	FirstBlock uint64        `json:"firstBlock,omitempty"`
+	LastBlock  uint64        `json:"lastBlock,omitempty"`
+	FirstDate  string        `json:"firstDate,omitempty"`
+	LastDate   string        `json:"lastDate,omitempty"`
+	Format     string        `json:"format"`
+	Entries    []BundleEntry `json:"entries"`
+}
+
+// BundleEntry is one exported facet. Facets exported to a shared SQLite database have
+// entries naming the same file.
+type BundleEntry struct {
+	Collection string    `json:"collection"`
+	DataFacet  DataFacet `json:"dataFacet"`
+	File       string    `json:"file"`
+	Rows       int       `json:"rows"`
+	Size       int64     `json:"size"`
+	Sha256     string    `json:"sha256"`
+	Path       string    `json:"-"` // the exported file on disk
+}
+
+// NewBundleManifest starts a manifest for a bundle of the payload's exports
+func NewBundleManifest(payload *Payload, appName, appVersion, project string) *BundleManifest {
+	format := payload.Format
+	if format == "" {
+		format = "csv"
+	}
+	return &BundleManifest{
+		AppName:    appName,
+		AppVersion: appVersion,
+		CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+		Project:    project,
+		Address:    payload.ActiveAddress,
+		Chain:      payload.ActiveChain,
+		FirstBlock: payload.FirstBlock,
+		LastBlock:  payload.LastBlock,
+		FirstDate:  payload.FirstDate,
+		LastDate:   payload.LastDate,
+		Format:     format,
+		Entries:    []BundleEntry{},
+	}
+}
+
+// ExportBundlePath returns the zip file a bundle named name is written to, next to the
+// payload's other exports
+func ExportBundlePath(payload *Payload, name string) (string, error) {
+	dir, err := exportDir(payload)
+	if err != nil {
+		return "", err
+	}
+	rawFilename := fmt.Sprintf("%s-%s-bundle-%s.zip", name, exportAddressPart(payload.ActiveAddress), time.Now().Format("20060102-150405"))
+	return filepath.Join(dir, normalizeFilename(rawFilename, ".zip")), nil
+}
+
+// WriteExportBundle zips the manifest's files, each under its collection's folder, and the
+// manifest itself. The entries' File, Size and Sha256 are filled in as the files are added.
+func WriteExportBundle(path string, manifest *BundleManifest) (err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Checksum every file first so the manifest can lead the archive
+	type bundled struct {
+		name   string
+		size   int64
+		sha256 string
+	}
+	files := make(map[string]bundled)
+	var order []string
+	for i := range manifest.Entries {
+		entry := &manifest.Entries[i]
+		b, seen := files[entry.Path]
+		if !seen {
+			b.name = filepath.ToSlash(filepath.Join(entry.Collection, filepath.Base(entry.Path)))
+			if b.size, b.sha256, err = checksumFile(entry.Path); err != nil {
+				return err
+			}
+			files[entry.Path] = b
+			order = append(order, entry.Path)
+		}
+		entry.File, entry.Size, entry.Sha256 = b.name, b.size, b.sha256
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	defer func() {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+
+	archive := zip.NewWriter(out)
+	w, err := archive.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	for _, src := range order {
+		if err := addToArchive(archive, files[src].name, src); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func checksumFile(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func addToArchive(archive *zip.Writer, name, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(src), err)
+	}
+	defer f.Close()
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	return nil
+}
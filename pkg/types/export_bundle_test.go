@@ -0,0 +1,91 @@
+package types
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteExportBundle(t *testing.T) {
+	payload := exportPayload(t, "sqlite")
+	payload.ActiveChain = "mainnet"
+	payload.FirstBlock = 100
+	manifest := NewBundleManifest(payload, "Approvals", "v1.0.0", "test")
+
+	// Two facets in one database and a csv file
+	for _, facet := range []DataFacet{"logs", "traces"} {
+		p := *payload
+		p.DataFacet = facet
+		rows := exportRows(3)
+		path, count, err := StreamExport(&p, string(facet), len(rows), chunksOf(rows), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 3 {
+			t.Fatalf("expected 3 rows written for %s, got %d", facet, count)
+		}
+		manifest.Entries = append(manifest.Entries, BundleEntry{Collection: "exports", DataFacet: facet, Rows: count, Path: path})
+	}
+	p := *payload
+	p.Collection, p.DataFacet, p.Format = "names", "custom", "csv"
+	path, _, err := StreamExport(&p, "custom", 0, chunksOf([]exportRow{}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest.Entries = append(manifest.Entries, BundleEntry{Collection: "names", DataFacet: "custom", Path: path})
+
+	bundlePath, err := ExportBundlePath(payload, "exports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(bundlePath, ".zip") || filepath.Dir(bundlePath) != filepath.Dir(path) {
+		t.Errorf("unexpected bundle path %s", bundlePath)
+	}
+	if err := WriteExportBundle(bundlePath, manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.OpenReader(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	contents := make(map[string][]byte)
+	var names []string
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(r)
+		r.Close()
+		contents[f.Name] = data
+		names = append(names, f.Name)
+	}
+	if len(names) != 3 || names[0] != "manifest.json" {
+		t.Fatalf("expected the manifest and two files, got %v", names)
+	}
+
+	var read BundleManifest
+	if err := json.Unmarshal(contents["manifest.json"], &read); err != nil {
+		t.Fatal(err)
+	}
+	if read.Address != payload.ActiveAddress || read.Chain != "mainnet" || read.FirstBlock != 100 || read.AppVersion != "v1.0.0" || len(read.Entries) != 3 {
+		t.Errorf("unexpected manifest %+v", read)
+	}
+	if read.Entries[0].File != read.Entries[1].File || read.Entries[1].Rows != 3 {
+		t.Errorf("expected both facets in the shared database, got %+v", read.Entries[:2])
+	}
+	for _, entry := range read.Entries {
+		sum := sha256.Sum256(contents[entry.File])
+		if entry.Sha256 != hex.EncodeToString(sum[:]) || entry.Size != int64(len(contents[entry.File])) {
+			t.Errorf("checksum of %s does not match its contents", entry.File)
+		}
+	}
+}
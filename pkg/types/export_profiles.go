@@ -0,0 +1,84 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ExportProfile reshapes a facet's rows into the CSV columns another tool imports. A profile
+// is selected by the payload's Profile or, failing that, by a Format naming it.
+type ExportProfile struct {
+	Name       string    `json:"name"`
+	Label      string    `json:"label"`
+	Collection string    `json:"collection"`
+	DataFacet  DataFacet `json:"dataFacet"`
+	Columns    []string  `json:"columns"`
+	// Rows returns the profile's rows for one item, none if the item has nothing to report
+	Rows func(item any, payload *Payload) []map[string]any `json:"-"`
+}
+
+var (
+	exportProfiles   []ExportProfile
+	exportProfilesMu sync.RWMutex
+)
+
+// RegisterExportProfile adds a profile, replacing any profile with the same name
+func RegisterExportProfile(profile ExportProfile) {
+	exportProfilesMu.Lock()
+	defer exportProfilesMu.Unlock()
+	for i := range exportProfiles {
+		if exportProfiles[i].Name == profile.Name {
+			exportProfiles[i] = profile
+			return
+		}
+	}
+	exportProfiles = append(exportProfiles, profile)
+}
+
+// GetExportProfiles returns the profiles that apply to the payload's facet in registration order
+func GetExportProfiles(payload *Payload) []ExportProfile {
+	exportProfilesMu.RLock()
+	defer exportProfilesMu.RUnlock()
+	ret := []ExportProfile{}
+	for _, profile := range exportProfiles {
+		if profile.Collection == payload.Collection && profile.DataFacet == payload.DataFacet {
+			ret = append(ret, profile)
+		}
+	}
+	return ret
+}
+
+// SelectsExportProfile reports whether the payload names an export profile, either as its
+// profile or as its format
+func SelectsExportProfile(payload *Payload) bool {
+	profile, err := exportProfile(payload)
+	return profile != nil || err != nil
+}
+
+// exportProfile returns the profile the payload selects, or nil if it selects none
+func exportProfile(payload *Payload) (*ExportProfile, error) {
+	name := payload.Profile
+	if name == "" {
+		name = payload.Format
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	exportProfilesMu.RLock()
+	defer exportProfilesMu.RUnlock()
+	for i := range exportProfiles {
+		if exportProfiles[i].Name != name {
+			continue
+		}
+		profile := exportProfiles[i]
+		if profile.Collection != payload.Collection || profile.DataFacet != payload.DataFacet {
+			return nil, fmt.Errorf("export profile %s does not apply to %s %s", name, payload.Collection, payload.DataFacet)
+		}
+		return &profile, nil
+	}
+	if payload.Profile != "" {
+		return nil, fmt.Errorf("unknown export profile %s", name)
+	}
+	return nil, nil
+}
@@ -0,0 +1,175 @@
+package types
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteRowWriter writes a facet as a table in an export database, replacing the table if
+// the facet was exported before. Columns follow the model's field order and their types come
+// from the model of an empty row, so a facet's schema does not depend on its data.
+type sqliteRowWriter struct {
+	db      *sql.DB
+	tx      *sql.Tx
+	insert  *sql.Stmt
+	table   string
+	columns []string
+}
+
+func newSQLiteRowWriter(path, table string, schema sdk.Model) (rowWriter, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	w := &sqliteRowWriter{db: db, tx: tx, table: table}
+	if len(schema.Order) > 0 {
+		if err := w.create(schema); err != nil {
+			w.abort()
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// create replaces the facet's table with one whose columns match the model
+func (w *sqliteRowWriter) create(schema sdk.Model) error {
+	defs := make([]string, len(schema.Order))
+	for i, name := range schema.Order {
+		defs[i] = quoteIdent(name) + " " + sqliteType(schema.Data[name])
+	}
+	table := quoteIdent(w.table)
+	if _, err := w.tx.Exec("DROP TABLE IF EXISTS " + table); err != nil {
+		return fmt.Errorf("failed to drop table %s: %w", w.table, err)
+	}
+	if _, err := w.tx.Exec(fmt.Sprintf("CREATE TABLE %s (%s)", table, strings.Join(defs, ", "))); err != nil {
+		return fmt.Errorf("failed to create table %s: %w", w.table, err)
+	}
+
+	quoted := make([]string, len(schema.Order))
+	for i, name := range schema.Order {
+		quoted[i] = quoteIdent(name)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(schema.Order)), ", ")
+	stmt, err := w.tx.Prepare(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(quoted, ", "), placeholders))
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert into %s: %w", w.table, err)
+	}
+	w.insert = stmt
+	w.columns = schema.Order
+	return nil
+}
+
+func (w *sqliteRowWriter) modelFormat() string {
+	return "csv"
+}
+
+func (w *sqliteRowWriter) write(model sdk.Model) error {
+	if w.insert == nil {
+		if err := w.create(model); err != nil {
+			return err
+		}
+	}
+	args := make([]any, len(w.columns))
+	for i, name := range w.columns {
+		args[i] = sqliteValue(model.Data[name])
+	}
+	if _, err := w.insert.Exec(args...); err != nil {
+		return fmt.Errorf("failed to insert into %s: %w", w.table, err)
+	}
+	return nil
+}
+
+func (w *sqliteRowWriter) writeEmpty(order []string, typeName string) error {
+	if w.insert != nil || len(order) == 0 {
+		return nil
+	}
+	return w.create(sdk.Model{Order: order})
+}
+
+func (w *sqliteRowWriter) finish() error {
+	if w.insert != nil {
+		_ = w.insert.Close()
+	}
+	err := w.tx.Commit()
+	if closeErr := w.db.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (w *sqliteRowWriter) abort() {
+	if w.insert != nil {
+		_ = w.insert.Close()
+	}
+	_ = w.tx.Rollback()
+	_ = w.db.Close()
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// sqliteType maps a model value to a column type
+func sqliteType(value any) string {
+	if value == nil {
+		return "TEXT"
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "INTEGER"
+	case reflect.Float32, reflect.Float64:
+		return "REAL"
+	default:
+		return "TEXT"
+	}
+}
+
+// sqliteValue converts a model value to one the driver stores: numbers as numbers, nested
+// values as JSON and everything else as the text the CSV export would show
+func sqliteValue(value any) any {
+	if value == nil {
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return 1
+		}
+		return 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return fmt.Sprintf("%d", v.Uint())
+		}
+		return int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+	case reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		if data, err := json.Marshal(value); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"testing"
+
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+func TestStreamExportNDJSON(t *testing.T) {
+	rows := exportRows(5)
+	path, _, err := StreamExport(exportPayload(t, "ndjson"), "logs", len(rows), chunksOf(rows), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	n := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var obj map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &obj); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v", n, err)
+		}
+		if obj["id"] != float64(n) {
+			t.Errorf("line %d: unexpected object %v", n, obj)
+		}
+		n++
+	}
+	if n != len(rows) {
+		t.Errorf("expected %d lines, got %d", len(rows), n)
+	}
+}
+
+type typedRow struct {
+	Block  uint64
+	Amount float64
+	Ok     bool
+	Tags   []string
+}
+
+func (r *typedRow) Model(chain, format string, verbose bool, extraOptions map[string]any) sdk.Model {
+	return sdk.Model{
+		Data:  map[string]any{"block": r.Block, "amount": r.Amount, "ok": r.Ok, "tags": r.Tags},
+		Order: []string{"block", "amount", "ok", "tags"},
+	}
+}
+
+func TestStreamExportSQLite(t *testing.T) {
+	payload := exportPayload(t, "sqlite")
+	rows := exportRows(ExportChunkSize + 5)
+	logsPath, _, err := StreamExport(payload, "logs", len(rows), chunksOf(rows), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload.DataFacet = "transfers"
+	typed := []typedRow{{Block: 1, Amount: 1.5, Ok: true, Tags: []string{"a"}}, {Block: 2}}
+	transfersPath, _, err := StreamExport(payload, "transfers", len(typed), chunksOf(typed), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logsPath != transfersPath {
+		t.Fatalf("expected facets to share a database, got %s and %s", logsPath, transfersPath)
+	}
+
+	// Re-exporting a facet replaces its table
+	if _, _, err := StreamExport(payload, "transfers", len(typed), chunksOf(typed), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite", logsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM logs`).Scan(&count); err != nil || count != len(rows) {
+		t.Errorf("expected %d logs rows, got %d (%v)", len(rows), count, err)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM transfers`).Scan(&count); err != nil || count != len(typed) {
+		t.Errorf("expected %d transfers rows, got %d (%v)", len(typed), count, err)
+	}
+
+	columns, err := db.Query(`SELECT name, type FROM pragma_table_info('transfers') ORDER BY cid`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer columns.Close()
+	var schema []string
+	for columns.Next() {
+		var name, typ string
+		if err := columns.Scan(&name, &typ); err != nil {
+			t.Fatal(err)
+		}
+		schema = append(schema, name+" "+typ)
+	}
+	expected := []string{"block INTEGER", "amount REAL", "ok INTEGER", "tags TEXT"}
+	if len(schema) != len(expected) {
+		t.Fatalf("unexpected schema %v", schema)
+	}
+	for i := range expected {
+		if schema[i] != expected[i] {
+			t.Errorf("column %d: expected %q, got %q", i, expected[i], schema[i])
+		}
+	}
+
+	var block int64
+	var amount float64
+	var ok int
+	var tags string
+	if err := db.QueryRow(`SELECT block, amount, ok, tags FROM transfers WHERE block = 1`).Scan(&block, &amount, &ok, &tags); err != nil {
+		t.Fatal(err)
+	}
+	if amount != 1.5 || ok != 1 || tags != `["a"]` {
+		t.Errorf("unexpected row %d %v %d %s", block, amount, ok, tags)
+	}
+}
@@ -0,0 +1,523 @@
+package types
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// ErrExportCancelled is returned by an export stopped with CancelExport
+var ErrExportCancelled = errors.New("export cancelled")
+
+// ExportChunkSize is the number of rows written between progress reports and cancellation checks
+const ExportChunkSize = 2000
+
+// ExportChunkFunc returns up to count rows starting at first, or none when the rows run out
+type ExportChunkFunc[T any] func(first, count int) []*T
+
+// ExportProgressFunc receives a report after each chunk and once when the export ends
+type ExportProgressFunc func(progress ExportProgressPayload)
+
+var (
+	runningExports   = make(map[string]context.CancelFunc)
+	runningExportsMu sync.Mutex
+)
+
+func exportKey(payload *Payload) string {
+	return fmt.Sprintf("%s_%s_%s_%s", payload.Collection, payload.DataFacet, payload.ActiveChain, payload.ActiveAddress)
+}
+
+// beginExport registers a running export so that it can be cancelled
+func beginExport(payload *Payload) (context.Context, func(), error) {
+	runningExportsMu.Lock()
+	defer runningExportsMu.Unlock()
+	key := exportKey(payload)
+	if _, running := runningExports[key]; running {
+		return nil, nil, fmt.Errorf("an export of %s %s is already running", payload.Collection, payload.DataFacet)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	runningExports[key] = cancel
+	end := func() {
+		runningExportsMu.Lock()
+		defer runningExportsMu.Unlock()
+		delete(runningExports, key)
+		cancel()
+	}
+	return ctx, end, nil
+}
+
+// CancelExport stops the running export of the payload's facet, returning false if there is none
+func CancelExport(payload *Payload) bool {
+	runningExportsMu.Lock()
+	defer runningExportsMu.Unlock()
+	cancel, running := runningExports[exportKey(payload)]
+	if running {
+		cancel()
+	}
+	return running
+}
+
+// ExportValuesFunc returns the values an exported row adds to its model, keyed by column
+type ExportValuesFunc func(item any) map[string]any
+
+// exportValues are columns computed at export time for a collection's facet
+type exportValues struct {
+	columns []string
+	values  func(payload *Payload) ExportValuesFunc
+}
+
+var (
+	exportValuesByFacet   = make(map[string]exportValues)
+	exportValuesByFacetMu sync.RWMutex
+)
+
+// RegisterExportValues adds columns that are computed at export time, such as fiat values,
+// to the rows of a collection's facet. values is called once per export and returns nil if
+// the columns do not apply to it.
+func RegisterExportValues(collection string, facet DataFacet, columns []string, values func(payload *Payload) ExportValuesFunc) {
+	exportValuesByFacetMu.Lock()
+	defer exportValuesByFacetMu.Unlock()
+	exportValuesByFacet[collection+"_"+string(facet)] = exportValues{columns: columns, values: values}
+}
+
+// exportValuesFor returns the computed columns of the payload's facet and their values, or
+// nothing if it has none
+func exportValuesFor(payload *Payload) ([]string, ExportValuesFunc) {
+	exportValuesByFacetMu.RLock()
+	ev, ok := exportValuesByFacet[payload.Collection+"_"+string(payload.DataFacet)]
+	exportValuesByFacetMu.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	values := ev.values(payload)
+	if values == nil {
+		return nil, nil
+	}
+	return ev.columns, values
+}
+
+// addColumns appends columns the model lacks to its order and sets the given values
+func addColumns(model sdk.Model, columns []string, values map[string]any) sdk.Model {
+	if model.Data == nil {
+		model.Data = make(map[string]any, len(values))
+	}
+	for _, name := range columns {
+		if !slices.Contains(model.Order, name) {
+			model.Order = append(model.Order, name)
+		}
+		if value, ok := values[name]; ok {
+			model.Data[name] = value
+		}
+	}
+	return model
+}
+
+// StreamExport writes a facet's rows to its export file one chunk at a time so that only a
+// chunk is held in memory. Text files are gzipped if the payload asks for it and are removed
+// if the export fails or is cancelled. SQLite exports write the facet as a table in a file
+// shared by all of the collection's facets. A payload that selects an export profile gets a
+// CSV file in the profile's columns and ignores Columns. Otherwise a payload with Columns
+// writes only those the rows have, in that order.
+// Columns registered with RegisterExportValues follow the model's own. It returns the file
+// and the number of rows written.
+func StreamExport[T any](payload *Payload, typeName string, total int, next ExportChunkFunc[T], report ExportProgressFunc) (path string, written int, err error) {
+	profile, err := exportProfile(payload)
+	if err != nil {
+		return "", 0, err
+	}
+	if profile != nil {
+		profiled := *payload
+		profiled.Profile = profile.Name
+		profiled.Format = "csv"
+		profiled.Columns = nil // a profile's rows have the profile's columns
+		payload = &profiled
+	}
+
+	format := payload.Format
+	if format == "" {
+		format = "csv"
+	}
+
+	if path, err = exportPath(payload, format); err != nil {
+		return "", 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return path, 0, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	ctx, end, err := beginExport(payload)
+	if err != nil {
+		return path, 0, err
+	}
+	defer end()
+
+	var extraColumns []string
+	var extraValues ExportValuesFunc
+	if profile == nil {
+		extraColumns, extraValues = exportValuesFor(payload)
+	}
+	schema := emptyModel[T]()
+	if extraValues != nil {
+		schema = addColumns(schema, extraColumns, nil)
+	}
+
+	columns := payload.Columns
+	if len(columns) > 0 {
+		sample := []sdk.Model{schema}
+		for _, item := range next(0, min(ExportChunkSize, total)) {
+			if modeler, ok := any(item).(sdk.Modeler); ok {
+				sample = append(sample, modeler.Model("csv", "", false, map[string]any{}))
+			}
+		}
+		columns = knownColumns(columns, sample...)
+	}
+
+	var rows rowWriter
+	switch format {
+	case "sqlite":
+		rows, err = newSQLiteRowWriter(path, string(payload.DataFacet), selectColumns(schema, columns))
+	default:
+		rows, err = newFileRowWriter(path, format, payload.Compress)
+	}
+	if err != nil {
+		return path, 0, err
+	}
+
+	progress := ExportProgressPayload{Payload: *payload, Path: path, Total: total}
+	emit := func() {
+		if report != nil {
+			report(progress)
+		}
+	}
+	defer func() {
+		progress.Done = true
+		if errors.Is(err, ErrExportCancelled) {
+			progress.Cancelled = true
+		} else if err != nil {
+			progress.Error = err.Error()
+		}
+		emit()
+	}()
+
+	toModels := func(item *T) []sdk.Model {
+		if modeler, ok := any(item).(sdk.Modeler); ok {
+			model := modeler.Model(rows.modelFormat(), "", false, map[string]any{})
+			if extraValues != nil {
+				model = addColumns(model, extraColumns, extraValues(item))
+			}
+			return []sdk.Model{model}
+		}
+		return nil
+	}
+	emptyOrder := func() []string { return schema.Order }
+	if profile != nil {
+		toModels = func(item *T) []sdk.Model {
+			var models []sdk.Model
+			for _, row := range profile.Rows(item, payload) {
+				models = append(models, sdk.Model{Data: row, Order: profile.Columns})
+			}
+			return models
+		}
+		emptyOrder = func() []string { return profile.Columns }
+	}
+
+	modelled := 0
+	err = func() error {
+		for progress.Written < total {
+			select {
+			case <-ctx.Done():
+				return ErrExportCancelled
+			default:
+			}
+			chunk := next(progress.Written, min(ExportChunkSize, total-progress.Written))
+			if len(chunk) == 0 {
+				break
+			}
+			for _, item := range chunk {
+				for _, model := range toModels(item) {
+					if err := rows.write(selectColumns(model, columns)); err != nil {
+						return err
+					}
+					modelled++
+				}
+			}
+			progress.Written += len(chunk)
+			emit()
+		}
+		if modelled == 0 {
+			order := emptyOrder()
+			if len(columns) > 0 {
+				order = columns
+			}
+			return rows.writeEmpty(order, typeName)
+		}
+		return nil
+	}()
+	if err != nil {
+		rows.abort()
+		return path, 0, err
+	}
+	if err = rows.finish(); err != nil {
+		return path, 0, err
+	}
+	return path, modelled, nil
+}
+
+// emptyModel returns the model of T's zero value, which gives the field order and types of
+// an export even when it has no rows
+func emptyModel[T any]() sdk.Model {
+	var dummy T
+	if modeler, ok := any(&dummy).(sdk.Modeler); ok {
+		return modeler.Model("csv", "", false, map[string]any{})
+	}
+	return sdk.Model{}
+}
+
+// knownColumns keeps the requested columns that at least one of the models has, in the
+// requested order. Names no row can fill, such as the frontend's own columns, are dropped.
+func knownColumns(columns []string, models ...sdk.Model) []string {
+	known := make(map[string]bool)
+	for _, model := range models {
+		for name := range model.Data {
+			known[name] = true
+		}
+		for _, name := range model.Order {
+			known[name] = true
+		}
+	}
+	var ret []string
+	for _, name := range columns {
+		if known[name] {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// selectColumns limits a model to the given columns, which come from knownColumns, in the
+// given order. A row without a value for one of them writes it empty so that every row
+// lines up under the same header. No columns leaves the model as it is.
+func selectColumns(model sdk.Model, columns []string) sdk.Model {
+	if len(columns) == 0 {
+		return model
+	}
+	data := make(map[string]any, len(columns))
+	for _, name := range columns {
+		if value, ok := model.Data[name]; ok {
+			data[name] = value
+		}
+	}
+	return sdk.Model{Data: data, Order: columns}
+}
+
+// rowWriter writes one model at a time in an export format
+type rowWriter interface {
+	modelFormat() string
+	write(model sdk.Model) error
+	writeEmpty(order []string, typeName string) error
+	finish() error
+	abort()
+}
+
+// exportFile is the buffered, optionally gzipped, file under a text export format
+type exportFile struct {
+	path     string
+	file     *os.File
+	buffered *bufio.Writer
+	zipper   *gzip.Writer
+	out      io.Writer
+}
+
+func newExportFile(path string, compress bool) (*exportFile, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	f := &exportFile{path: path, file: file, buffered: bufio.NewWriter(file)}
+	f.out = f.buffered
+	if compress {
+		f.zipper = gzip.NewWriter(f.buffered)
+		f.out = f.zipper
+	}
+	return f, nil
+}
+
+func (f *exportFile) finish() error {
+	var err error
+	if f.zipper != nil {
+		err = f.zipper.Close()
+	}
+	if err == nil {
+		err = f.buffered.Flush()
+	}
+	if closeErr := f.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.path)
+	}
+	return err
+}
+
+func (f *exportFile) abort() {
+	_ = f.file.Close()
+	_ = os.Remove(f.path)
+}
+
+func newFileRowWriter(path, format string, compress bool) (rowWriter, error) {
+	f, err := newExportFile(path, compress)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case "json":
+		return &jsonRowWriter{exportFile: f}, nil
+	case "ndjson":
+		return &ndjsonRowWriter{exportFile: f}, nil
+	default:
+		return newCSVRowWriter(f, format), nil
+	}
+}
+
+// csvRowWriter writes comma (csv) or tab (txt) separated rows under a header taken from
+// the first row's field order
+type csvRowWriter struct {
+	*exportFile
+	writer    *csv.Writer
+	delimiter string
+	count     int
+}
+
+func newCSVRowWriter(f *exportFile, format string) *csvRowWriter {
+	w := &csvRowWriter{exportFile: f, writer: csv.NewWriter(f.out), delimiter: ","}
+	if format == "txt" {
+		w.writer.Comma = '\t'
+		w.delimiter = "\t"
+	}
+	return w
+}
+
+func (w *csvRowWriter) modelFormat() string {
+	return "csv"
+}
+
+func (w *csvRowWriter) write(model sdk.Model) error {
+	if w.count == 0 {
+		if len(model.Order) == 0 {
+			return fmt.Errorf("no field order specified")
+		}
+		if _, err := io.WriteString(w.out, strings.Join(model.Order, w.delimiter)+"\n"); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+	w.count++
+
+	row := make([]string, len(model.Order))
+	for j, fieldName := range model.Order {
+		if value, exists := model.Data[fieldName]; exists {
+			row[j] = fmt.Sprintf("%v", value)
+		}
+	}
+	if err := w.writer.Write(row); err != nil {
+		return fmt.Errorf("failed to write CSV row %d: %w", w.count, err)
+	}
+	return nil
+}
+
+func (w *csvRowWriter) writeEmpty(order []string, typeName string) error {
+	if len(order) > 0 {
+		if _, err := io.WriteString(w.out, strings.Join(order, w.delimiter)+"\n"); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+	_, err := fmt.Fprintf(w.out, "# No %s data available\n", typeName)
+	return err
+}
+
+func (w *csvRowWriter) finish() error {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		w.exportFile.abort()
+		return err
+	}
+	return w.exportFile.finish()
+}
+
+// jsonRowWriter writes an indented JSON array one object at a time
+type jsonRowWriter struct {
+	*exportFile
+	count int
+}
+
+func (w *jsonRowWriter) modelFormat() string {
+	return "json"
+}
+
+func (w *jsonRowWriter) write(model sdk.Model) error {
+	data, err := json.MarshalIndent(model.Data, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	sep := ",\n  "
+	if w.count == 0 {
+		sep = "[\n  "
+	}
+	w.count++
+	if _, err := io.WriteString(w.out, sep); err != nil {
+		return err
+	}
+	_, err = w.out.Write(data)
+	return err
+}
+
+func (w *jsonRowWriter) writeEmpty(order []string, typeName string) error {
+	_, err := io.WriteString(w.out, "[]")
+	return err
+}
+
+func (w *jsonRowWriter) finish() error {
+	if w.count > 0 {
+		if _, err := io.WriteString(w.out, "\n]"); err != nil {
+			w.exportFile.abort()
+			return err
+		}
+	}
+	return w.exportFile.finish()
+}
+
+// ndjsonRowWriter writes one compact JSON object per line
+type ndjsonRowWriter struct {
+	*exportFile
+}
+
+func (w *ndjsonRowWriter) modelFormat() string {
+	return "json"
+}
+
+func (w *ndjsonRowWriter) write(model sdk.Model) error {
+	data, err := json.Marshal(model.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	if _, err := w.out.Write(data); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w.out, "\n")
+	return err
+}
+
+func (w *ndjsonRowWriter) writeEmpty(order []string, typeName string) error {
+	return nil
+}
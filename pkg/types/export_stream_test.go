@@ -0,0 +1,229 @@
+package types
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+type exportRow struct {
+	ID   int
+	Name string
+}
+
+func (r *exportRow) Model(chain, format string, verbose bool, extraOptions map[string]any) sdk.Model {
+	return sdk.Model{
+		Data:  map[string]any{"id": r.ID, "name": r.Name},
+		Order: []string{"id", "name"},
+	}
+}
+
+func exportRows(n int) []exportRow {
+	rows := make([]exportRow, n)
+	for i := range rows {
+		rows[i] = exportRow{ID: i, Name: "row"}
+	}
+	return rows
+}
+
+func exportPayload(t *testing.T, format string) *Payload {
+	t.Helper()
+	return &Payload{
+		Collection:    "exports",
+		DataFacet:     "logs",
+		ActiveAddress: "0xf503017d7baf7fbc0fff7492b751025c6a78179b",
+		Format:        format,
+		ProjectPath:   filepath.Join(t.TempDir(), "test.tbx"),
+	}
+}
+
+func chunksOf[T any](data []T) ExportChunkFunc[T] {
+	return func(first, count int) []*T {
+		var chunk []*T
+		for i := first; i < len(data) && i < first+count; i++ {
+			chunk = append(chunk, &data[i])
+		}
+		return chunk
+	}
+}
+
+func TestStreamExportCSV(t *testing.T) {
+	rows := exportRows(ExportChunkSize*2 + 10)
+	var reports []ExportProgressPayload
+	path, _, err := StreamExport(exportPayload(t, "csv"), "logs", len(rows), chunksOf(rows), func(p ExportProgressPayload) {
+		reports = append(reports, p)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != len(rows)+1 || lines[0] != "id,name" || lines[1] != "0,row" {
+		t.Errorf("unexpected csv: %d lines starting %q", len(lines), lines[:2])
+	}
+
+	// One report per chunk plus the final one
+	if len(reports) != 4 {
+		t.Fatalf("expected 4 progress reports, got %d", len(reports))
+	}
+	if reports[0].Written != ExportChunkSize || reports[0].Done {
+		t.Errorf("unexpected first report %+v", reports[0])
+	}
+	if last := reports[3]; !last.Done || last.Written != len(rows) || last.Path != path || last.Error != "" {
+		t.Errorf("unexpected last report %+v", last)
+	}
+}
+
+func TestStreamExportGzipJSON(t *testing.T) {
+	payload := exportPayload(t, "json")
+	payload.Compress = true
+	rows := exportRows(3)
+	path, _, err := StreamExport(payload, "logs", len(rows), chunksOf(rows), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(path, ".json.gz") {
+		t.Errorf("expected a .json.gz file, got %s", path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("invalid json %q: %v", data, err)
+	}
+	if len(decoded) != 3 || decoded[2]["id"] != float64(2) {
+		t.Errorf("unexpected rows %v", decoded)
+	}
+}
+
+func TestStreamExportEmpty(t *testing.T) {
+	path, _, err := StreamExport(exportPayload(t, "txt"), "logs", 0, chunksOf([]exportRow{}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "id\tname\n# No logs data available\n" {
+		t.Errorf("unexpected empty export %q", data)
+	}
+
+	path, _, err = StreamExport(exportPayload(t, "json"), "logs", 0, chunksOf([]exportRow{}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "[]" {
+		t.Errorf("unexpected empty json export %q", data)
+	}
+}
+
+func TestStreamExportCancel(t *testing.T) {
+	payload := exportPayload(t, "csv")
+	rows := exportRows(ExportChunkSize * 3)
+	var last ExportProgressPayload
+	path, _, err := StreamExport(payload, "logs", len(rows), chunksOf(rows), func(p ExportProgressPayload) {
+		last = p
+		if !p.Done && !CancelExport(payload) {
+			t.Error("expected the export to be running")
+		}
+	})
+	if err != ErrExportCancelled {
+		t.Fatalf("expected ErrExportCancelled, got %v", err)
+	}
+	if !last.Done || !last.Cancelled || last.Written != ExportChunkSize {
+		t.Errorf("unexpected final report %+v", last)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected the partial file to be removed")
+	}
+	if CancelExport(payload) {
+		t.Error("expected no running export after cancellation")
+	}
+}
+
+func TestStreamExportColumns(t *testing.T) {
+	payload := exportPayload(t, "csv")
+	payload.Columns = []string{"name", "missing", "id"}
+	rows := exportRows(2)
+	path, _, err := StreamExport(payload, "logs", len(rows), chunksOf(rows), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "name,id\nrow,0\nrow,1\n" {
+		t.Errorf("unexpected csv %q", data)
+	}
+
+	payload = exportPayload(t, "ndjson")
+	payload.Columns = []string{"name"}
+	path, _, err = StreamExport(payload, "logs", len(rows), chunksOf(rows), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "{\"name\":\"row\"}\n{\"name\":\"row\"}\n" {
+		t.Errorf("unexpected ndjson %q", data)
+	}
+}
+
+func TestKnownColumns(t *testing.T) {
+	schema := sdk.Model{Data: map[string]any{"id": 0}, Order: []string{"id", "name"}}
+	named := sdk.Model{Data: map[string]any{"id": 1, "fromName": "x"}}
+	got := knownColumns([]string{"fromName", "actions", "name", "id"}, schema, named)
+	if strings.Join(got, ",") != "fromName,name,id" {
+		t.Errorf("unexpected columns %v", got)
+	}
+}
+
+func TestStreamExportValues(t *testing.T) {
+	RegisterExportValues("exports", "valued", []string{"value"}, func(payload *Payload) ExportValuesFunc {
+		return func(item any) map[string]any {
+			if row := item.(*exportRow); row.ID > 0 {
+				return map[string]any{"value": row.ID * 10}
+			}
+			return nil
+		}
+	})
+	defer func() {
+		exportValuesByFacetMu.Lock()
+		delete(exportValuesByFacet, "exports_valued")
+		exportValuesByFacetMu.Unlock()
+	}()
+
+	payload := exportPayload(t, "csv")
+	payload.DataFacet = "valued"
+	rows := exportRows(2)
+	path, _, err := StreamExport(payload, "valued", len(rows), chunksOf(rows), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "id,name,value\n0,row,\n1,row,10\n" {
+		t.Errorf("unexpected csv %q", data)
+	}
+
+	path, _, err = StreamExport(payload, "valued", 0, chunksOf[exportRow](nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); !strings.HasPrefix(string(data), "id,name,value\n") {
+		t.Errorf("expected the value column in an empty export, got %q", data)
+	}
+}
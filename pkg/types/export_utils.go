@@ -1,45 +1,44 @@
 package types
 
 import (
-	"encoding/csv"
-	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
-
-	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
 
 // ExportData is the unified export function that handles file creation with proper extension and format
 func ExportData[T any](data []T, payload *Payload, typeName string) (string, error) {
-	format := payload.Format
-	if format == "" {
-		format = "csv"
+	next := func(first, count int) []*T {
+		if first >= len(data) {
+			return nil
+		}
+		end := min(first+count, len(data))
+		chunk := make([]*T, 0, end-first)
+		for i := first; i < end; i++ {
+			chunk = append(chunk, &data[i])
+		}
+		return chunk
 	}
+	path, _, err := StreamExport(payload, typeName, len(data), next, nil)
+	return path, err
+}
 
+// exportPath returns the file an export of the payload's facet is written to
+func exportPath(payload *Payload, format string) (string, error) {
 	collection := payload.Collection
 	dataFacet := string(payload.DataFacet)
+	if payload.Profile != "" {
+		dataFacet += "-" + payload.Profile
+	}
 	address := payload.ActiveAddress
 
-	if payload.ProjectPath == "" {
-		return "", fmt.Errorf("project path not provided in payload")
+	outputDirPath, err := exportDir(payload)
+	if err != nil {
+		return "", err
 	}
 
-	projectDir := filepath.Dir(payload.ProjectPath)
-	projectName := filepath.Base(payload.ProjectPath)
-	projectNameWithoutExt := strings.TrimSuffix(projectName, filepath.Ext(projectName))
-	outputDirPath := filepath.Join(projectDir, projectNameWithoutExt+".Exports")
-
 	// Construct filename from payload information
-	addressPart := "noaddr"
-	if address != "" && address != "0x0" {
-		if len(address) >= 10 {
-			addressPart = address[:7] + "-" + address[len(address)-4:]
-		} else {
-			addressPart = address
-		}
-	}
+	addressPart := exportAddressPart(address)
 
 	fileExtension := "." + format
 	rawFilename := fmt.Sprintf("%s-%s-%s%s",
@@ -47,27 +46,38 @@ func ExportData[T any](data []T, payload *Payload, typeName string) (string, err
 		dataFacet,
 		addressPart,
 		fileExtension)
+	if format == "sqlite" {
+		// All of a collection's facets share one database, a table per facet
+		rawFilename = fmt.Sprintf("%s-%s%s", collection, addressPart, fileExtension)
+	}
 
 	exportFilename := normalizeFilename(rawFilename, fileExtension)
-	finalPath := filepath.Join(outputDirPath, exportFilename)
-
-	dir := filepath.Dir(finalPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return finalPath, fmt.Errorf("failed to create directory: %w", err)
+	if payload.Compress && format != "sqlite" {
+		exportFilename += ".gz"
 	}
+	return filepath.Join(outputDirPath, exportFilename), nil
+}
 
-	file, err := os.Create(finalPath)
-	if err != nil {
-		return finalPath, fmt.Errorf("failed to create file: %w", err)
+// exportDir returns the folder next to the payload's project that exports are written to
+func exportDir(payload *Payload) (string, error) {
+	if payload.ProjectPath == "" {
+		return "", fmt.Errorf("project path not provided in payload")
 	}
-	defer func() { _ = file.Close() }()
+	projectDir := filepath.Dir(payload.ProjectPath)
+	projectName := filepath.Base(payload.ProjectPath)
+	projectNameWithoutExt := strings.TrimSuffix(projectName, filepath.Ext(projectName))
+	return filepath.Join(projectDir, projectNameWithoutExt+".Exports"), nil
+}
 
-	// Export based on format
-	if format == "json" {
-		return finalPath, writeDataToJSON(file, data, typeName)
-	} else {
-		return finalPath, writeDataToCSV(file, data, typeName, format)
+// exportAddressPart shortens an address for use in export filenames
+func exportAddressPart(address string) string {
+	if address == "" || address == "0x0" {
+		return "noaddr"
+	}
+	if len(address) >= 10 {
+		return address[:7] + "-" + address[len(address)-4:]
 	}
+	return address
 }
 
 // normalizeFilename makes the filename OS-valid by removing invalid characters
@@ -98,125 +108,3 @@ func normalizeFilename(rawFilename, fileExtension string) string {
 
 	return filename
 }
-
-// writeDataToJSON writes typed data to a JSON file using Model() method
-func writeDataToJSON[T any](file *os.File, data []T, typeName string) error {
-	_ = typeName // delint
-	if len(data) == 0 {
-		_, err := file.WriteString("[]")
-		return err
-	}
-
-	var modelObjects []map[string]interface{}
-	for i, item := range data {
-		itemPtr := &item
-		if modeler, ok := interface{}(itemPtr).(sdk.Modeler); ok {
-			model := modeler.Model("json", "", false, map[string]any{})
-			// Extract just the Data part of the Model object
-			modelObjects = append(modelObjects, model.Data)
-		} else {
-			// Skip items that don't implement sdk.Modeler interface
-			_ = i // Use i to avoid unused variable error
-		}
-	}
-
-	if len(modelObjects) == 0 {
-		_, err := file.WriteString("[]")
-		return err
-	}
-
-	jsonData, err := json.MarshalIndent(modelObjects, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal JSON: %w", err)
-	}
-
-	_, err = file.Write(jsonData)
-	return err
-}
-
-// writeCSVHeaderWithNoData writes the CSV header and a "no data" message for empty datasets
-func writeCSVHeaderWithNoData[T any](file *os.File, typeName string, format string) error {
-	delimiter := ","
-	if format == "txt" {
-		delimiter = "\t"
-	}
-
-	// Create a dummy instance to get the header structure
-	var dummy T
-	dummyPtr := &dummy
-	if modeler, ok := interface{}(dummyPtr).(sdk.Modeler); ok {
-		model := modeler.Model("csv", "", false, map[string]any{})
-		if len(model.Order) > 0 {
-			header := strings.Join(model.Order, delimiter)
-			_, err := file.WriteString(header + "\n")
-			if err != nil {
-				return fmt.Errorf("failed to write CSV header: %w", err)
-			}
-		}
-	}
-
-	_, err := fmt.Fprintf(file, "# No %s data available\n", typeName)
-	return err
-}
-
-// writeDataToCSV writes typed data to a CSV or TXT file using Model() method
-func writeDataToCSV[T any](file *os.File, data []T, typeName string, format string) error {
-	delimiter := ","
-	if format == "txt" {
-		delimiter = "\t"
-	}
-
-	if len(data) == 0 {
-		return writeCSVHeaderWithNoData[T](file, typeName, format)
-	}
-
-	var models []sdk.Model
-	for i, item := range data {
-		itemPtr := &item
-		if modeler, ok := interface{}(itemPtr).(sdk.Modeler); ok {
-			model := modeler.Model("csv", "", false, map[string]any{})
-			models = append(models, model)
-		} else {
-			// Skip items that don't implement sdk.Modeler interface
-			_ = i // Use i to avoid unused variable error
-		}
-	}
-
-	if len(models) == 0 {
-		_, err := fmt.Fprintf(file, "# No %s data available\n", typeName)
-		return err
-	}
-
-	firstModel := models[0]
-	if len(firstModel.Order) == 0 {
-		return fmt.Errorf("no field order specified for %s", typeName)
-	}
-
-	header := strings.Join(firstModel.Order, delimiter)
-	_, err := file.WriteString(header + "\n")
-	if err != nil {
-		return fmt.Errorf("failed to write CSV header: %w", err)
-	}
-
-	writer := csv.NewWriter(file)
-	if format == "txt" {
-		writer.Comma = '\t'
-	}
-	defer writer.Flush()
-
-	for i, model := range models {
-		row := make([]string, len(model.Order))
-		for j, fieldName := range model.Order {
-			if value, exists := model.Data[fieldName]; exists {
-				row[j] = fmt.Sprintf("%v", value)
-			} else {
-				row[j] = ""
-			}
-		}
-
-		if err := writer.Write(row); err != nil {
-			return fmt.Errorf("failed to write CSV row %d: %w", i+1, err)
-		}
-	}
-	return nil
-}
@@ -0,0 +1,224 @@
+package exports
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/names"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// ApprovalLogs series strategy constants
+const (
+	SeriesByToken   = "token"
+	SeriesBySpender = "spender"
+)
+
+const approvalTopic = "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+
+// unlimitedThreshold treats any allowance of at least 2^255 as unlimited. Wallets use
+// max uint256 and some tokens decrement it on transfer, so the top bit is the signal.
+var unlimitedThreshold = new(big.Int).Lsh(big.NewInt(1), 255)
+
+// approvalEvent is a decoded ERC-20 Approval(owner, spender, value) log
+type approvalEvent struct {
+	token   base.Address
+	owner   base.Address
+	spender base.Address
+	amount  *big.Int
+}
+
+// decodeApproval returns the approval carried by a log, or false if the log is not an ERC-20
+// Approval. ERC-721 shares the event's signature but indexes the token id as a fourth topic
+// and carries no data, so only logs with exactly three topics and a 32-byte value qualify.
+func decodeApproval(log *ApprovalLog) (approvalEvent, bool) {
+	if len(log.Topics) != 3 || log.Topics[0].Hex() != approvalTopic {
+		return approvalEvent{}, false
+	}
+	data := strings.TrimPrefix(log.Data, "0x")
+	if len(data) != 64 {
+		return approvalEvent{}, false
+	}
+	amount, ok := new(big.Int).SetString(data, 16)
+	if !ok {
+		return approvalEvent{}, false
+	}
+	return approvalEvent{
+		token:   log.Address,
+		owner:   base.BytesToAddress(log.Topics[1].Bytes()),
+		spender: base.BytesToAddress(log.Topics[2].Bytes()),
+		amount:  amount,
+	}, true
+}
+
+// approvalTracker holds the current allowance for every (token, owner, spender) seen so far
+// and the number of open and unlimited approvals in each series
+type approvalTracker struct {
+	buckets    *types.Buckets
+	allowances map[string]*big.Int
+	open       map[string]int
+	unlimited  map[string]int
+}
+
+func newApprovalTracker(buckets *types.Buckets) *approvalTracker {
+	return &approvalTracker{
+		buckets:    buckets,
+		allowances: make(map[string]*big.Int),
+		open:       make(map[string]int),
+		unlimited:  make(map[string]int),
+	}
+}
+
+// apply records the event against its series and returns that series' outstanding counts
+func (t *approvalTracker) apply(ev approvalEvent, series string) (open, unlimited int) {
+	key := fmt.Sprintf("%s_%s_%s", ev.token.Hex(), ev.owner.Hex(), ev.spender.Hex())
+	if prev, ok := t.allowances[key]; ok {
+		t.open[series] -= isOpen(prev)
+		t.unlimited[series] -= isUnlimited(prev)
+	}
+	t.allowances[key] = ev.amount
+	t.open[series] += isOpen(ev.amount)
+	t.unlimited[series] += isUnlimited(ev.amount)
+	return t.open[series], t.unlimited[series]
+}
+
+func isOpen(amount *big.Int) int {
+	if amount.Sign() > 0 {
+		return 1
+	}
+	return 0
+}
+
+func isUnlimited(amount *big.Int) int {
+	if amount.Cmp(unlimitedThreshold) >= 0 {
+		return 1
+	}
+	return 0
+}
+
+// approvalSeriesId names the series an approval belongs to under the given strategy
+func approvalSeriesId(token, spender base.Address, config types.FacetChartConfig) string {
+	addr := token
+	if config.SeriesStrategy == SeriesBySpender {
+		addr = spender
+	}
+	chars := config.SeriesPrefixLen
+	if chars < 8 {
+		chars = 8
+	}
+	if chars > 15 {
+		chars = 15
+	}
+	return addr.Hex()[:2+chars]
+}
+
+// updateApprovalLogsBucket processes a single ApprovalLog and updates the approval activity
+// buckets incrementally. Granted and revoked are counts per bucket. Open and unlimited are the
+// outstanding totals after the bucket's last event, so logs are expected in chain order.
+func (c *ExportsCollection) updateApprovalLogsBucket(log *ApprovalLog) {
+	if log == nil {
+		return
+	}
+
+	ev, ok := decodeApproval(log)
+	if !ok {
+		return
+	}
+
+	config := types.FacetChartConfig{
+		SeriesStrategy:  SeriesByToken,
+		SeriesPrefixLen: 12,
+	}
+	if viewConfig, err := c.GetConfig(); err == nil {
+		if facetConfig, exists := viewConfig.Facets["approvallogs"]; exists && facetConfig.FacetChartConfig != nil {
+			config = *facetConfig.FacetChartConfig
+		}
+	}
+	seriesId := approvalSeriesId(ev.token, ev.spender, config)
+
+	c.approvallogsFacet.UpdateBuckets(func(buckets *types.Buckets) {
+		// The facet replaces its buckets on reset, so the allowances start over with them
+		c.approvalTrackerMu.Lock()
+		defer c.approvalTrackerMu.Unlock()
+		tracker := c.approvalTracker
+		if tracker == nil || tracker.buckets != buckets {
+			tracker = newApprovalTracker(buckets)
+			c.approvalTracker = tracker
+		}
+		open, unlimited := tracker.apply(ev, seriesId)
+
+		if _, ok := buckets.AssetNames[seriesId]; !ok {
+			addr := ev.token
+			if config.SeriesStrategy == SeriesBySpender {
+				addr = ev.spender
+			}
+			if name, _ := names.NameFromAddress(addr); name != nil {
+				buckets.SetAssetName(seriesId, name)
+			}
+		}
+
+		ix := getBucketIndex(c.approvallogsFacet, buckets)
+		bucketKey := timestampToBucket(int64(log.Timestamp), ix.period)
+		metricNames := []string{"granted", "revoked", "netOpen", "unlimited"}
+		for _, metricName := range metricNames {
+			seriesName := fmt.Sprintf("%s.%s", seriesId, metricName)
+			buckets.EnsureSeriesExists(seriesName)
+
+			series := buckets.GetSeries(seriesName)
+			bucketIndex := ix.find(&series, seriesName, bucketKey)
+			if series[bucketIndex].StartBlock == 0 || uint64(log.BlockNumber) < series[bucketIndex].StartBlock {
+				series[bucketIndex].StartBlock = uint64(log.BlockNumber)
+			}
+			if uint64(log.BlockNumber) > series[bucketIndex].EndBlock {
+				series[bucketIndex].EndBlock = uint64(log.BlockNumber)
+			}
+
+			switch metricName {
+			case "granted":
+				if ev.amount.Sign() > 0 {
+					series[bucketIndex].Total += 1.0
+				}
+			case "revoked":
+				if ev.amount.Sign() == 0 {
+					series[bucketIndex].Total += 1.0
+				}
+			case "netOpen":
+				series[bucketIndex].Total = float64(open) // absolute, not cumulative
+			case "unlimited":
+				series[bucketIndex].Total = float64(unlimited) // absolute, not cumulative
+			}
+
+			buckets.SetSeries(seriesName, series)
+		}
+	})
+}
+
+// padApprovalSeries applies edge padding to ApprovalLogs buckets. Counts are padded with
+// zero and outstanding totals carry forward to the current period.
+func (c *ExportsCollection) padApprovalSeries(originalBuckets *types.Buckets, period types.Period) *types.Buckets {
+	if originalBuckets == nil {
+		return originalBuckets
+	}
+
+	paddedBuckets := types.NewBuckets()
+	paddedBuckets.GridInfo = originalBuckets.GridInfo
+
+	c.approvallogsFacet.UpdateBuckets(func(facetBuckets *types.Buckets) {
+		for id, name := range facetBuckets.AssetNames {
+			paddedBuckets.AssetNames[id] = name
+		}
+		for seriesName, series := range facetBuckets.Series {
+			padded := padSeriesWithMetric(append([]types.Bucket{}, series...), period)
+			isCount := strings.HasSuffix(seriesName, ".granted") || strings.HasSuffix(seriesName, ".revoked")
+			if isCount && len(series) > 0 && len(padded) == len(series)+2 {
+				padded[len(padded)-1].Total = 0
+			}
+			paddedBuckets.Series[seriesName] = padded
+		}
+	})
+
+	return paddedBuckets
+}
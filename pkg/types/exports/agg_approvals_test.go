@@ -0,0 +1,107 @@
+package exports
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+func approvalLog(token, owner, spender string, amount string, day int, block base.Blknum) *ApprovalLog {
+	ts := time.Date(2024, 3, day, 12, 0, 0, 0, time.UTC).Unix()
+	return &ApprovalLog{
+		Address:     base.HexToAddress(token),
+		BlockNumber: block,
+		Timestamp:   base.Timestamp(ts),
+		Data:        fmt.Sprintf("0x%064s", amount),
+		Topics: []base.Hash{
+			base.HexToHash(approvalTopic),
+			base.HexToHash(owner),
+			base.HexToHash(spender),
+		},
+	}
+}
+
+func TestDecodeApproval(t *testing.T) {
+	log := approvalLog("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "0x1111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222", "ff", 1, 10)
+	ev, ok := decodeApproval(log)
+	if !ok {
+		t.Fatal("expected an approval")
+	}
+	if ev.owner.Hex() != "0x1111111111111111111111111111111111111111" ||
+		ev.spender.Hex() != "0x2222222222222222222222222222222222222222" || ev.amount.Int64() != 255 {
+		t.Errorf("unexpected decode: owner=%s spender=%s amount=%s", ev.owner.Hex(), ev.spender.Hex(), ev.amount)
+	}
+
+	log.Topics[0] = base.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	if _, ok := decodeApproval(log); ok {
+		t.Error("transfer log decoded as an approval")
+	}
+
+	nft := approvalLog("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "0x1111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222", "ff", 1, 10)
+	nft.Topics = append(nft.Topics, base.HexToHash("0x07"))
+	nft.Data = "0x"
+	if _, ok := decodeApproval(nft); ok {
+		t.Error("ERC-721 approval decoded as an ERC-20 approval")
+	}
+
+	short := approvalLog("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "0x1111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222", "ff", 1, 10)
+	short.Data = "0xff"
+	if _, ok := decodeApproval(short); ok {
+		t.Error("approval without a 32-byte value decoded")
+	}
+}
+
+func TestApprovalLogsBucketing(t *testing.T) {
+	c := NewExportsCollection(&types.Payload{Collection: "exports", ActiveChain: "mainnet", ActiveAddress: "0x1111111111111111111111111111111111111111"})
+
+	token := "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	owner := "0x1111111111111111111111111111111111111111"
+	unlimited := "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+	logs := []*ApprovalLog{
+		approvalLog(token, owner, "0x2222222222222222222222222222222222222222", unlimited, 1, 10),
+		approvalLog(token, owner, "0x3333333333333333333333333333333333333333", "64", 1, 11),
+		approvalLog(token, owner, "0x2222222222222222222222222222222222222222", "0", 3, 20),
+	}
+	for _, log := range logs {
+		c.updateApprovalLogsBucket(log)
+	}
+
+	buckets := c.approvallogsFacet.GetBuckets()
+	id := token[:14]
+	totals := func(metric string) map[string]float64 {
+		ret := make(map[string]float64)
+		for _, b := range buckets.GetSeries(id + "." + metric) {
+			ret[b.BucketKey] = b.Total
+		}
+		return ret
+	}
+
+	expected := map[string]map[string]float64{
+		"granted":   {"20240301": 2, "20240303": 0},
+		"revoked":   {"20240301": 0, "20240303": 1},
+		"netOpen":   {"20240301": 2, "20240303": 1},
+		"unlimited": {"20240301": 1, "20240303": 0},
+	}
+	for metric, want := range expected {
+		got := totals(metric)
+		for day, total := range want {
+			if got[day] != total {
+				t.Errorf("%s on %s: expected %v, got %v", metric, day, total, got[day])
+			}
+		}
+	}
+
+	// A reset replaces the buckets, so outstanding approvals start over
+	c.approvallogsFacet.ClearBuckets()
+	c.updateApprovalLogsBucket(logs[1])
+	if got := c.approvallogsFacet.GetBuckets().GetSeries(id + ".netOpen"); len(got) != 1 || got[0].Total != 1 {
+		t.Errorf("expected one open approval after reset, got %+v", got)
+	}
+}
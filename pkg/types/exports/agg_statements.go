@@ -48,27 +48,6 @@ func generateAssetIdentifier(asset, symbol string, config types.FacetChartConfig
 	}
 }
 
-// findOrCreateBucket finds an existing bucket by key or creates a new one
-func findOrCreateBucket(series *[]types.Bucket, bucketKey string) int {
-	// Search for existing bucket
-	for i, bucket := range *series {
-		if bucket.BucketKey == bucketKey {
-			return i
-		}
-	}
-
-	// Bucket doesn't exist, create it
-	newBucket := types.Bucket{
-		BucketKey:  bucketKey,
-		Total:      0,
-		StartBlock: 0,
-		EndBlock:   0,
-		ColorValue: 0,
-	}
-	*series = append(*series, newBucket)
-	return len(*series) - 1
-}
-
 // statementValueToFloat64 converts base.Wei to float64
 func statementValueToFloat64(wei *base.Wei, decimals int) float64 {
 	if wei == nil {
@@ -78,12 +57,6 @@ func statementValueToFloat64(wei *base.Wei, decimals int) float64 {
 	return ret.Float64()
 }
 
-// timestampToDailyBucket converts Unix timestamp to daily bucket identifier (YYYYMMDD)
-func timestampToDailyBucket(timestamp int64) string {
-	t := time.Unix(timestamp, 0).UTC()
-	return fmt.Sprintf("%04d%02d%02d", t.Year(), t.Month(), t.Day())
-}
-
 // updateStatementsBucket processes a single Statement and updates asset chart buckets incrementally
 func (c *ExportsCollection) updateStatementsBucket(statement *Statement) {
 	if statement == nil {
@@ -120,8 +93,8 @@ func (c *ExportsCollection) updateStatementsBucket(statement *Statement) {
 			}
 		}
 
-		// Calculate metrics for this single statement
-		dailyBucket := timestampToDailyBucket(int64(statement.Timestamp))
+		ix := getBucketIndex(c.assetchartsFacet, buckets)
+		bucketKey := timestampToBucket(int64(statement.Timestamp), ix.period)
 
 		// Get decimals for value calculations
 		decimals := 18 // Default for ETH
@@ -130,31 +103,15 @@ func (c *ExportsCollection) updateStatementsBucket(statement *Statement) {
 		}
 
 		// Update each metric series incrementally
-		metricNames := []string{"frequency", "volume", "endBalEth"}
-		for _, metricName := range metricNames {
-			seriesName := fmt.Sprintf("%s.%s", assetIdentifier, metricName)
+		for _, metric := range activeChartMetrics(config) {
+			seriesName := fmt.Sprintf("%s.%s", assetIdentifier, metric.Key)
 			buckets.EnsureSeriesExists(seriesName)
 
 			series := buckets.GetSeries(seriesName)
-			bucketIndex := findOrCreateBucket(&series, dailyBucket)
-
-			// Update the specific metric
-			switch metricName {
-			case "frequency":
-				series[bucketIndex].Total += 1.0
-			case "volume":
-				amountIn := statementValueToFloat64(&statement.AmountIn, decimals)
-				amountOut := statementValueToFloat64(&statement.AmountOut, decimals)
-				volume := amountIn + amountOut
-				series[bucketIndex].Total += volume
-			case "endBal":
-				endBal := statementValueToFloat64(&statement.EndBal, decimals)
-				series[bucketIndex].Total = endBal // EndBal is absolute, not cumulative
-				// case "neighbors":
-				//	 // Count unique counterparties (simplified - could track actual unique count)
-				//	 series[bucketIndex].Total += 1.0
-			}
-
+			bucketIndex := ix.find(&series, seriesName, bucketKey)
+			state := ix.state(seriesName, bucketKey)
+			state.Chain = c.chain
+			metric.Update(&series[bucketIndex], statement, decimals, state)
 			buckets.SetSeries(seriesName, series)
 		}
 	})
@@ -238,11 +195,10 @@ func (c *ExportsCollection) updateStatementsBucket(statement *Statement) {
 // }
 
 // padSeriesWithMetric adds front and back padding buckets for unified chart axes
-func padSeriesWithMetric(buckets []types.Bucket, seriesName string) []types.Bucket {
-	_ = seriesName // delint
-	nowDate := timestampToDailyBucket(time.Now().Unix())
+func padSeriesWithMetric(buckets []types.Bucket, period types.Period) []types.Bucket {
+	nowDate := timestampToBucket(time.Now().Unix(), period)
 	if len(buckets) == 0 {
-		nowMinusOne := timestampToDailyBucket(time.Now().Unix() - 86400)
+		nowMinusOne, _ := previousBucket(nowDate, period)
 		return []types.Bucket{
 			{
 				BucketKey:  nowMinusOne,
@@ -273,24 +229,17 @@ func padSeriesWithMetric(buckets []types.Bucket, seriesName string) []types.Buck
 		}
 	}
 
-	// Add front padding one day before earliest
+	// Add front padding one period before earliest
 	if firstKey.BucketKey != "" {
-		dayBefore := func(dateStr string) (string, error) {
-			t, err := time.Parse("20060102", dateStr)
-			if err != nil {
-				return "", fmt.Errorf("invalid date format: %v", err)
-			}
-			previousDay := t.AddDate(0, 0, -1)
-			return previousDay.Format("20060102"), nil
+		if before, err := previousBucket(firstKey.BucketKey, period); err == nil {
+			buckets = append([]types.Bucket{{
+				BucketKey:  before,
+				Total:      0,
+				StartBlock: 0,
+				EndBlock:   0,
+				ColorValue: 0,
+			}}, buckets...)
 		}
-		db, _ := dayBefore(firstKey.BucketKey)
-		buckets = append([]types.Bucket{{
-			BucketKey:  db,
-			Total:      0,
-			StartBlock: 0,
-			EndBlock:   0,
-			ColorValue: 0,
-		}}, buckets...)
 	}
 
 	if latestKey.BucketKey != "" && nowDate > latestKey.BucketKey {
@@ -140,7 +140,7 @@ func TestAssetChartsBucketing(t *testing.T) {
 		}
 
 		for _, tc := range testCases {
-			bucketKey := timestampToDailyBucket(tc.timestamp)
+			bucketKey := timestampToBucket(tc.timestamp, types.PeriodDaily)
 			if bucketKey != tc.expected {
 				t.Errorf("Timestamp %d: expected bucket %s, got %s", tc.timestamp, tc.expected, bucketKey)
 			}
@@ -155,9 +155,9 @@ func TestAssetChartsBucketing(t *testing.T) {
 		// Test that we can process statements individually (key streaming requirement)
 		if len(statements) > 0 {
 			firstStmt := statements[0]
-			bucketKey := timestampToDailyBucket(int64(firstStmt.Timestamp))
+			bucketKey := timestampToBucket(int64(firstStmt.Timestamp), types.PeriodDaily)
 			if bucketKey == "" {
-				t.Error("timestampToDailyBucket should produce valid bucket keys")
+				t.Error("timestampToBucket should produce valid bucket keys")
 			}
 			t.Logf("Example: Statement from timestamp %d → bucket %s", firstStmt.Timestamp, bucketKey)
 		}
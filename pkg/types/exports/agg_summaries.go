@@ -0,0 +1,115 @@
+package exports
+
+import (
+	"math/big"
+	"strings"
+
+	storePkg "github.com/TrueBlocks/trueblocks-approvals/pkg/store"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// PeriodTotal is a summary row holding one asset's totals for one period
+type PeriodTotal = storePkg.AggregateSummary
+
+// statementTotal converts a statement into its contribution to the period totals
+func statementTotal(s *Statement) storePkg.AggregateInput {
+	endBal := s.EndBal
+	gas := s.GasOut
+	return storePkg.AggregateInput{
+		Timestamp: int64(s.Timestamp),
+		Asset:     s.Asset.Hex(),
+		Symbol:    s.Symbol,
+		Decimals:  uint64(s.Decimals),
+		In:        s.TotalIn(),
+		Out:       s.TotalOutLessGas(),
+		Gas:       &gas,
+		Balance:   &endBal,
+	}
+}
+
+// transferTotal converts a transfer into its contribution to the period totals. Transfers carry
+// no timestamp of their own, so those without a transaction or log are skipped.
+func transferTotal(t *Transfer) (storePkg.AggregateInput, bool) {
+	var ts base.Timestamp
+	switch {
+	case t.Transaction != nil:
+		ts = t.Transaction.Timestamp
+	case t.Log != nil:
+		ts = t.Log.Timestamp
+	default:
+		return storePkg.AggregateInput{}, false
+	}
+	gas := t.GasOut
+	out := t.TotalOut()
+	return storePkg.AggregateInput{
+		Timestamp: int64(ts),
+		Asset:     t.Asset.Hex(),
+		Decimals:  t.Decimals,
+		In:        t.TotalIn(),
+		Out:       out.Sub(out, &gas),
+		Gas:       &gas,
+	}, true
+}
+
+// transactionTotal converts a transaction into the holder's ETH flow for the period totals.
+// A reverted transaction moves no value, so only its gas is counted.
+func transactionTotal(tx *Transaction, holder base.Address) storePkg.AggregateInput {
+	in := storePkg.AggregateInput{
+		Timestamp: int64(tx.Timestamp),
+		Asset:     base.FAKE_ETH_ADDRESS.Hex(),
+		Symbol:    "ETH",
+		Decimals:  18,
+	}
+	value := tx.Value
+	if tx.To == holder && !tx.IsError {
+		in.In = &value
+	}
+	if tx.From == holder {
+		if !tx.IsError {
+			in.Out = &value
+		}
+		gasUsed := tx.GasUsed
+		if tx.Receipt != nil {
+			gasUsed = tx.Receipt.GasUsed
+		}
+		cost := new(big.Int).Mul(new(big.Int).SetUint64(uint64(tx.GasPrice)), new(big.Int).SetUint64(uint64(gasUsed)))
+		in.Gas = (*base.Wei)(cost)
+	}
+	return in
+}
+
+// aggregateTransactions rebuilds the transaction totals for the given period
+func (c *ExportsCollection) aggregateTransactions(holder base.Address, period types.Period) {
+	store := c.transactionsFacet.GetStore()
+	summaries := store.GetSummaryManager()
+	summaries.Reset()
+	for _, tx := range store.GetItems(false) {
+		summaries.Aggregate(transactionTotal(tx, holder), period)
+	}
+}
+
+// pagePeriodTotals filters, sorts and paginates summary rows
+func pagePeriodTotals(rows []PeriodTotal, first, pageSize int, sortSpec sdk.SortSpec, filter string) ([]PeriodTotal, int) {
+	if filter != "" {
+		filtered := make([]PeriodTotal, 0, len(rows))
+		for _, row := range rows {
+			if strings.Contains(strings.ToLower(row.Asset), filter) || strings.Contains(strings.ToLower(row.Symbol), filter) {
+				filtered = append(filtered, row)
+			}
+		}
+		rows = filtered
+	}
+
+	// The sort spec may name a column of the raw facet; those rows keep their time order
+	_ = storePkg.SortAggregates(rows, sortSpec)
+
+	total := len(rows)
+	if first >= total {
+		return []PeriodTotal{}, total
+	}
+	end := min(first+pageSize, total)
+	return rows[first:end], total
+}
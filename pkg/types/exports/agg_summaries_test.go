@@ -0,0 +1,81 @@
+package exports
+
+import (
+	"testing"
+	"time"
+
+	storePkg "github.com/TrueBlocks/trueblocks-approvals/pkg/store"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+func TestTransactionTotals(t *testing.T) {
+	holder := base.HexToAddress("0x1111111111111111111111111111111111111111")
+	other := base.HexToAddress("0x2222222222222222222222222222222222222222")
+	ts := base.Timestamp(time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC).Unix())
+
+	txs := []*Transaction{
+		{From: other, To: holder, Value: *base.NewWei(1000), Timestamp: ts},
+		{From: holder, To: other, Value: *base.NewWei(400), GasPrice: 10, GasUsed: 21, Timestamp: ts},
+	}
+
+	sm := storePkg.NewSummaryManager[Transaction]()
+	for _, tx := range txs {
+		sm.Aggregate(transactionTotal(tx, holder), types.PeriodMonthly)
+	}
+
+	rows := sm.GetAggregates(types.PeriodMonthly)
+	if len(rows) != 1 {
+		t.Fatalf("expected one ETH row, got %d", len(rows))
+	}
+	row := rows[0]
+	if row.Count != 2 || row.SumIn.String() != "1000" || row.SumOut.String() != "400" ||
+		row.NetFlow.String() != "600" || row.GasSpent.String() != "210" {
+		t.Errorf("unexpected totals: count=%d in=%s out=%s net=%s gas=%s",
+			row.Count, row.SumIn.String(), row.SumOut.String(), row.NetFlow.String(), row.GasSpent.String())
+	}
+}
+
+func TestRevertedTransactionCountsOnlyGas(t *testing.T) {
+	holder := base.HexToAddress("0x1111111111111111111111111111111111111111")
+	other := base.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	out := transactionTotal(&Transaction{From: holder, To: other, Value: *base.NewWei(400), GasPrice: 10, GasUsed: 21, IsError: true}, holder)
+	if out.Out != nil || out.Gas == nil || out.Gas.String() != "210" {
+		t.Errorf("expected only gas for a reverted send, got out=%v gas=%v", out.Out, out.Gas)
+	}
+	in := transactionTotal(&Transaction{From: other, To: holder, Value: *base.NewWei(1000), IsError: true}, holder)
+	if in.In != nil {
+		t.Errorf("expected no value for a reverted receipt, got %s", in.In.String())
+	}
+}
+
+func TestTransferTotalNeedsTimestamp(t *testing.T) {
+	if _, ok := transferTotal(&Transfer{AmountIn: *base.NewWei(1)}); ok {
+		t.Errorf("expected a transfer without a transaction or log to be skipped")
+	}
+	total, ok := transferTotal(&Transfer{AmountIn: *base.NewWei(5), Log: &sdk.Log{Timestamp: 1700000000}})
+	if !ok || total.Timestamp != 1700000000 || total.In.String() != "5" {
+		t.Errorf("unexpected transfer total: %+v", total)
+	}
+}
+
+func TestPagePeriodTotals(t *testing.T) {
+	rows := []PeriodTotal{
+		{Asset: "0xaaa", Symbol: "AAA", Count: 3},
+		{Asset: "0xbbb", Symbol: "BBB", Count: 1},
+		{Asset: "0xccc", Symbol: "CCC", Count: 2},
+	}
+
+	page, total := pagePeriodTotals(rows, 0, 2, sdk.SortSpec{Fields: []string{"count"}, Order: []sdk.SortOrder{sdk.Asc}}, "")
+	if total != 3 || len(page) != 2 || page[0].Asset != "0xbbb" || page[1].Asset != "0xccc" {
+		t.Errorf("unexpected page: total=%d %+v", total, page)
+	}
+
+	page, total = pagePeriodTotals(rows, 0, 10, sdk.SortSpec{}, "bbb")
+	if total != 1 || page[0].Symbol != "BBB" {
+		t.Errorf("expected filter to match symbol, got %+v", page)
+	}
+}
@@ -24,6 +24,8 @@ func (c *ExportsCollection) GetBuckets(payload *types.Payload) (*types.Buckets,
 		facet = c.balancesFacet
 	case ExportsTransfers:
 		facet = c.transfersFacet
+	case ExportsCounterparties:
+		facet = c.counterpartiesFacet
 	case ExportsOpenApprovals:
 		facet = c.openapprovalsFacet
 	case ExportsApprovalTxs:
@@ -50,7 +52,16 @@ func (c *ExportsCollection) GetBuckets(payload *types.Payload) (*types.Buckets,
 	buckets := facet.GetBuckets()
 	// EXISTING_CODE
 	if payload.DataFacet == ExportsAssetCharts && c.assetchartsFacet != nil {
-		buckets = c.padSeries(buckets)
+		rebucket(c.assetchartsFacet, payload.ActivePeriod, func() []*Statement {
+			return c.assetchartsFacet.GetStore().GetItems(false)
+		}, c.updateStatementsBucket)
+		buckets = c.padSeries(c.assetchartsFacet.GetBuckets(), payload.ActivePeriod)
+	}
+	if payload.DataFacet == ExportsApprovalLogs && c.approvallogsFacet != nil {
+		rebucket(c.approvallogsFacet, payload.ActivePeriod, func() []*ApprovalLog {
+			return c.approvallogsFacet.GetStore().GetItems(false)
+		}, c.updateApprovalLogsBucket)
+		buckets = c.padApprovalSeries(c.approvallogsFacet.GetBuckets(), payload.ActivePeriod)
 	}
 	// EXISTING_CODE
 	return buckets, nil
@@ -58,7 +69,7 @@ func (c *ExportsCollection) GetBuckets(payload *types.Payload) (*types.Buckets,
 
 // EXISTING_CODE
 // padSeries applies edge padding to AssetCharts buckets
-func (c *ExportsCollection) padSeries(originalBuckets *types.Buckets) *types.Buckets {
+func (c *ExportsCollection) padSeries(originalBuckets *types.Buckets, period types.Period) *types.Buckets {
 	if originalBuckets == nil || c.assetchartsFacet == nil {
 		return originalBuckets
 	}
@@ -74,7 +85,7 @@ func (c *ExportsCollection) padSeries(originalBuckets *types.Buckets) *types.Buc
 	// Use UpdateBuckets (which locks the series map) to add the padding
 	c.assetchartsFacet.UpdateBuckets(func(facetBuckets *types.Buckets) {
 		for seriesName, series := range facetBuckets.Series {
-			paddedSeries := padSeriesWithMetric(series, seriesName)
+			paddedSeries := padSeriesWithMetric(series, period)
 			paddedBuckets.Series[seriesName] = paddedSeries
 		}
 	})
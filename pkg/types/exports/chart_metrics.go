@@ -0,0 +1,237 @@
+package exports
+
+import (
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/pricing"
+	storePkg "github.com/TrueBlocks/trueblocks-approvals/pkg/store"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// ChartMetric computes one asset chart series from the statements that fall in each bucket
+type ChartMetric struct {
+	Key     string
+	Label   string
+	Enabled func() bool // nil means always enabled
+	Update  func(bucket *types.Bucket, statement *Statement, decimals int, state *BucketState)
+}
+
+// BucketState carries per-bucket data a metric needs beyond the running total
+type BucketState struct {
+	Chain          string
+	Counterparties map[base.Address]struct{}
+}
+
+var (
+	chartMetrics   []ChartMetric
+	chartMetricsMu sync.RWMutex
+)
+
+// RegisterChartMetric adds a metric to the asset charts, replacing any metric with the same key
+func RegisterChartMetric(metric ChartMetric) {
+	chartMetricsMu.Lock()
+	defer chartMetricsMu.Unlock()
+	for i := range chartMetrics {
+		if chartMetrics[i].Key == metric.Key {
+			chartMetrics[i] = metric
+			return
+		}
+	}
+	chartMetrics = append(chartMetrics, metric)
+}
+
+// GetChartMetrics returns the registered metrics in registration order
+func GetChartMetrics() []ChartMetric {
+	chartMetricsMu.RLock()
+	defer chartMetricsMu.RUnlock()
+	return append([]ChartMetric{}, chartMetrics...)
+}
+
+// activeChartMetrics returns the enabled metrics named in the config, or all enabled metrics
+// if it names none
+func activeChartMetrics(config types.FacetChartConfig) []ChartMetric {
+	var all []ChartMetric
+	for _, metric := range GetChartMetrics() {
+		if metric.Enabled == nil || metric.Enabled() {
+			all = append(all, metric)
+		}
+	}
+	if len(config.Metrics) == 0 {
+		return all
+	}
+	ret := make([]ChartMetric, 0, len(config.Metrics))
+	for _, key := range config.Metrics {
+		for _, metric := range all {
+			if metric.Key == key {
+				ret = append(ret, metric)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+func init() {
+	RegisterChartMetric(ChartMetric{
+		Key:   "frequency",
+		Label: "Statement Frequency",
+		Update: func(bucket *types.Bucket, s *Statement, decimals int, state *BucketState) {
+			bucket.Total += 1.0
+		},
+	})
+	RegisterChartMetric(ChartMetric{
+		Key:   "volume",
+		Label: "Volume",
+		Update: func(bucket *types.Bucket, s *Statement, decimals int, state *BucketState) {
+			bucket.Total += statementValueToFloat64(&s.AmountIn, decimals) + statementValueToFloat64(&s.AmountOut, decimals)
+		},
+	})
+	RegisterChartMetric(ChartMetric{
+		Key:   "endBal",
+		Label: "Ending Balance",
+		Update: func(bucket *types.Bucket, s *Statement, decimals int, state *BucketState) {
+			bucket.Total = statementValueToFloat64(&s.EndBal, decimals) // absolute, not cumulative
+		},
+	})
+	RegisterChartMetric(ChartMetric{
+		Key:   "counterparties",
+		Label: "Unique Counterparties",
+		Update: func(bucket *types.Bucket, s *Statement, decimals int, state *BucketState) {
+			counterparty := s.Recipient
+			if s.Recipient == s.AccountedFor {
+				counterparty = s.Sender
+			}
+			if state.Counterparties == nil {
+				state.Counterparties = make(map[base.Address]struct{})
+			}
+			state.Counterparties[counterparty] = struct{}{}
+			bucket.Total = float64(len(state.Counterparties))
+		},
+	})
+	RegisterChartMetric(ChartMetric{
+		Key:   "gas",
+		Label: "Gas Spent",
+		Update: func(bucket *types.Bucket, s *Statement, decimals int, state *BucketState) {
+			bucket.Total += statementValueToFloat64(&s.GasOut, 18) // gas is always paid in ether
+		},
+	})
+}
+
+// chartPeriod maps the active period to a bucket size. Charts are never finer than a day.
+func chartPeriod(period types.Period) types.Period {
+	switch period {
+	case "", types.PeriodBlockly, types.PeriodHourly:
+		return types.PeriodDaily
+	}
+	return period
+}
+
+// timestampToBucket returns the bucket key (YYYYMMDD of the period's first day) for a timestamp
+func timestampToBucket(timestamp int64, period types.Period) string {
+	start := storePkg.NormalizeToPeriod(timestamp, chartPeriod(period))
+	return time.Unix(start, 0).In(storePkg.GetPeriodConfig().Location()).Format("20060102")
+}
+
+// previousBucket returns the key of the bucket before the given one
+func previousBucket(bucketKey string, period types.Period) (string, error) {
+	t, err := time.ParseInLocation("20060102", bucketKey, storePkg.GetPeriodConfig().Location())
+	if err != nil {
+		return "", err
+	}
+	return timestampToBucket(t.Unix()-1, period), nil
+}
+
+// bucketIndex locates buckets by key without scanning the series and remembers the bucket
+// size, period settings and price source a facet's buckets were built with
+type bucketIndex struct {
+	buckets      *types.Buckets
+	period       types.Period
+	periodConfig types.PeriodConfig
+	pricing      uint64
+	positions    map[string]map[string]int
+	states       map[string]map[string]*BucketState
+}
+
+var (
+	bucketIndexes   = make(map[types.BucketInterface]*bucketIndex)
+	bucketIndexesMu sync.Mutex
+)
+
+// getBucketIndex returns the facet's index, starting it over if the facet has replaced its buckets
+func getBucketIndex(facet types.BucketInterface, buckets *types.Buckets) *bucketIndex {
+	bucketIndexesMu.Lock()
+	defer bucketIndexesMu.Unlock()
+	ix := bucketIndexes[facet]
+	if ix == nil {
+		ix = &bucketIndex{period: types.PeriodDaily, periodConfig: storePkg.GetPeriodConfig()}
+		bucketIndexes[facet] = ix
+	}
+	if ix.buckets != buckets {
+		ix.buckets = buckets
+		ix.positions = make(map[string]map[string]int)
+		ix.states = make(map[string]map[string]*BucketState)
+	}
+	return ix
+}
+
+// find returns the position of the bucket with the given key, appending it if it is new
+func (ix *bucketIndex) find(series *[]types.Bucket, seriesName, bucketKey string) int {
+	positions := ix.positions[seriesName]
+	if positions == nil {
+		positions = make(map[string]int, len(*series))
+		for i, bucket := range *series {
+			positions[bucket.BucketKey] = i
+		}
+		ix.positions[seriesName] = positions
+	}
+	if i, ok := positions[bucketKey]; ok {
+		return i
+	}
+	*series = append(*series, types.Bucket{BucketKey: bucketKey})
+	positions[bucketKey] = len(*series) - 1
+	return len(*series) - 1
+}
+
+// state returns the metric state for one bucket of one series
+func (ix *bucketIndex) state(seriesName, bucketKey string) *BucketState {
+	states := ix.states[seriesName]
+	if states == nil {
+		states = make(map[string]*BucketState)
+		ix.states[seriesName] = states
+	}
+	st := states[bucketKey]
+	if st == nil {
+		st = &BucketState{}
+		states[bucketKey] = st
+	}
+	return st
+}
+
+// rebucket rebuilds a facet's buckets when the requested bucket size, the period settings or
+// the price source differs from the one they were built with, replaying the rows that fed them
+// through update
+func rebucket[T any](facet types.BucketInterface, period types.Period, items func() []*T, update func(*T)) {
+	period = chartPeriod(period)
+	periodConfig := storePkg.GetPeriodConfig()
+	generation := pricing.Generation()
+	current := true
+	facet.UpdateBuckets(func(buckets *types.Buckets) {
+		ix := getBucketIndex(facet, buckets)
+		current = ix.period == period && ix.periodConfig == periodConfig && ix.pricing == generation
+	})
+	if current {
+		return
+	}
+
+	facet.ClearBuckets()
+	facet.UpdateBuckets(func(buckets *types.Buckets) {
+		ix := getBucketIndex(facet, buckets)
+		ix.period, ix.periodConfig, ix.pricing = period, periodConfig, generation
+	})
+	for _, item := range items() {
+		update(item)
+	}
+}
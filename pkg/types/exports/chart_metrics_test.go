@@ -0,0 +1,120 @@
+package exports
+
+import (
+	"testing"
+	"time"
+
+	storePkg "github.com/TrueBlocks/trueblocks-approvals/pkg/store"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+func TestTimestampToBucket(t *testing.T) {
+	ts := time.Date(2024, 5, 15, 13, 0, 0, 0, time.UTC).Unix() // a Wednesday
+	testCases := []struct {
+		period   types.Period
+		expected string
+	}{
+		{types.PeriodBlockly, "20240515"},
+		{types.PeriodHourly, "20240515"},
+		{types.PeriodDaily, "20240515"},
+		{types.PeriodWeekly, "20240512"},
+		{types.PeriodMonthly, "20240501"},
+		{types.PeriodQuarterly, "20240401"},
+		{types.PeriodAnnual, "20240101"},
+	}
+	for _, tc := range testCases {
+		if got := timestampToBucket(ts, tc.period); got != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.period, tc.expected, got)
+		}
+	}
+
+	if got, _ := previousBucket("20240501", types.PeriodMonthly); got != "20240401" {
+		t.Errorf("expected the previous month to start 20240401, got %s", got)
+	}
+}
+
+func TestBucketIndexFind(t *testing.T) {
+	buckets := types.NewBuckets()
+	ix := getBucketIndex(&fakeBucketFacet{}, buckets)
+
+	series := []types.Bucket{{BucketKey: "20240101"}}
+	if i := ix.find(&series, "s", "20240101"); i != 0 {
+		t.Errorf("expected existing bucket at 0, got %d", i)
+	}
+	if i := ix.find(&series, "s", "20240102"); i != 1 || len(series) != 2 {
+		t.Errorf("expected new bucket at 1, got %d (len %d)", i, len(series))
+	}
+	if i := ix.find(&series, "s", "20240102"); i != 1 || len(series) != 2 {
+		t.Errorf("expected repeated key to reuse bucket 1, got %d (len %d)", i, len(series))
+	}
+}
+
+func TestAssetChartMetricsFollowPeriod(t *testing.T) {
+	c := NewExportsCollection(&types.Payload{Collection: "exports", ActiveChain: "mainnet", ActiveAddress: "0x1111111111111111111111111111111111111111"})
+
+	holder := base.HexToAddress("0x1111111111111111111111111111111111111111")
+	alice := base.HexToAddress("0x2222222222222222222222222222222222222222")
+	bob := base.HexToAddress("0x3333333333333333333333333333333333333333")
+	day := func(d int) base.Timestamp {
+		return base.Timestamp(time.Date(2024, 3, d, 12, 0, 0, 0, time.UTC).Unix())
+	}
+	statements := []*Statement{
+		{Asset: base.FAKE_ETH_ADDRESS, Symbol: "ETH", AccountedFor: holder, Sender: alice, Recipient: holder, Timestamp: day(1), AmountIn: *base.NewWei(1e18)},
+		{Asset: base.FAKE_ETH_ADDRESS, Symbol: "ETH", AccountedFor: holder, Sender: holder, Recipient: bob, Timestamp: day(1), AmountOut: *base.NewWei(2e18), GasOut: *base.NewWei(1e15)},
+		{Asset: base.FAKE_ETH_ADDRESS, Symbol: "ETH", AccountedFor: holder, Sender: alice, Recipient: holder, Timestamp: day(20), AmountIn: *base.NewWei(1e18)},
+	}
+	for _, s := range statements {
+		c.updateStatementsBucket(s)
+	}
+
+	id := generateAssetIdentifier(base.FAKE_ETH_ADDRESS.Hex(), "ETH", *getAssetChartsFacetConfig())
+	totals := func(metric string) map[string]float64 {
+		ret := make(map[string]float64)
+		for _, b := range c.assetchartsFacet.GetBuckets().GetSeries(id + "." + metric) {
+			ret[b.BucketKey] = b.Total
+		}
+		return ret
+	}
+
+	daily := totals("counterparties")
+	if daily["20240301"] != 2 || daily["20240320"] != 1 {
+		t.Errorf("unexpected daily counterparties: %v", daily)
+	}
+	if gas := totals("gas")["20240301"]; gas != 0.001 {
+		t.Errorf("expected 0.001 gas on the first, got %v", gas)
+	}
+
+	rebucket(c.assetchartsFacet, types.PeriodMonthly, func() []*Statement { return statements }, c.updateStatementsBucket)
+
+	monthly := totals("frequency")
+	if len(monthly) != 1 || monthly["20240301"] != 3 {
+		t.Errorf("expected one monthly bucket with three statements, got %v", monthly)
+	}
+	if got := totals("counterparties")["20240301"]; got != 2 {
+		t.Errorf("expected two unique counterparties in March, got %v", got)
+	}
+	if got := totals("volume")["20240301"]; got != 4 {
+		t.Errorf("expected a volume of 4 in March, got %v", got)
+	}
+
+	// Changing only the timezone still moves the bucket boundaries
+	saved := storePkg.GetPeriodConfig()
+	defer storePkg.SetPeriodConfig(saved)
+	rebucket(c.assetchartsFacet, types.PeriodDaily, func() []*Statement { return statements }, c.updateStatementsBucket)
+	storePkg.SetPeriodConfig(types.PeriodConfig{Timezone: "Pacific/Kiritimati"})
+	rebucket(c.assetchartsFacet, types.PeriodDaily, func() []*Statement { return statements }, c.updateStatementsBucket)
+	if daily := totals("frequency"); daily["20240302"] != 2 || daily["20240321"] != 1 {
+		t.Errorf("expected daily buckets in the new timezone, got %v", daily)
+	}
+}
+
+type fakeBucketFacet struct {
+	buckets *types.Buckets
+}
+
+func (f *fakeBucketFacet) GetBuckets() *types.Buckets            { return f.buckets }
+func (f *fakeBucketFacet) ClearBuckets()                         { f.buckets = types.NewBuckets() }
+func (f *fakeBucketFacet) SetBuckets(buckets *types.Buckets)     { f.buckets = buckets }
+func (f *fakeBucketFacet) UpdateBuckets(fn func(*types.Buckets)) { fn(f.buckets) }
@@ -77,6 +77,15 @@ func (c *ExportsCollection) buildStaticFacets() map[string]types.FacetConfig {
 			Actions:       []string{},
 			HeaderActions: []string{"export"},
 		},
+		"counterparties": {
+			Name:          "Counterparties",
+			Store:         "counterparties",
+			ViewType:      "table",
+			DividerBefore: false,
+			Fields:        getCounterpartiesFields(),
+			Actions:       []string{},
+			HeaderActions: []string{"export"},
+		},
 		"openapprovals": {
 			Name:          "Open Approvals",
 			Store:         "openapprovals",
@@ -98,14 +107,15 @@ func (c *ExportsCollection) buildStaticFacets() map[string]types.FacetConfig {
 			HeaderActions: []string{"export"},
 		},
 		"approvallogs": {
-			Name:          "Approval Logs",
-			Store:         "approvallogs",
-			ViewType:      "table",
-			Panel:         "custom",
-			DividerBefore: false,
-			Fields:        getApprovallogsFields(),
-			Actions:       []string{},
-			HeaderActions: []string{"export"},
+			Name:             "Approval Logs",
+			Store:            "approvallogs",
+			ViewType:         "table",
+			Panel:            "custom",
+			DividerBefore:    false,
+			Fields:           getApprovallogsFields(),
+			Actions:          []string{},
+			HeaderActions:    []string{"export"},
+			FacetChartConfig: getApprovalLogsFacetConfig(),
 		},
 		"transactions": {
 			Name:          "Transactions",
@@ -165,6 +175,7 @@ func (c *ExportsCollection) buildFacetOrder() []string {
 		"assetcharts",
 		"balances",
 		"transfers",
+		"counterparties",
 		"openapprovals",
 		"approvaltxs",
 		"approvallogs",
@@ -285,6 +296,8 @@ func getAssetsFields() []types.FieldConfig {
 		{Section: "Corrections", Key: "correctBegBalOut", Type: "int256", NoTable: true},
 		{Section: "Corrections", Key: "correctAmountOut", Type: "int256", NoTable: true},
 		{Section: "Corrections", Key: "correctEndBalOut", Type: "int256", NoTable: true},
+		{Section: "Value", Key: "fiatPrice", Type: "fiat", NoTable: true},
+		{Section: "Value", Key: "fiatValue", Type: "fiat"},
 		{Section: "", Key: "actions", Type: "actions", NoDetail: true},
 	}
 	types.NormalizeFields(&ret)
@@ -300,6 +313,23 @@ func getBalancesFields() []types.FieldConfig {
 		{Section: "Balance Info", Key: "addressName", Type: "string"},
 		{Section: "Balance Info", Key: "balance", Type: "wei"},
 		{Section: "Balance Info", Key: "diff", Type: "wei", NoTable: true},
+		{Section: "Value", Key: "fiatPrice", Type: "fiat", NoTable: true},
+		{Section: "Value", Key: "fiatValue", Type: "fiat"},
+		{Section: "", Key: "actions", Type: "actions", NoDetail: true},
+	}
+	types.NormalizeFields(&ret)
+	return ret
+}
+
+func getCounterpartiesFields() []types.FieldConfig {
+	ret := []types.FieldConfig{
+		{Section: "General", Key: "address", Type: "address"},
+		{Section: "General", Key: "addressName", Type: "string"},
+		{Section: "Activity", Key: "transfers", Type: "uint64"},
+		{Section: "Activity", Key: "sent", Type: "uint64"},
+		{Section: "Activity", Key: "received", Type: "uint64"},
+		{Section: "Activity", Key: "firstBlock", Type: "blknum"},
+		{Section: "Activity", Key: "lastBlock", Type: "blknum"},
 		{Section: "", Key: "actions", Type: "actions", NoDetail: true},
 	}
 	types.NormalizeFields(&ret)
@@ -344,6 +374,8 @@ func getOpenapprovalsFields() []types.FieldConfig {
 		{Section: "Data", Key: "lastAppLogID", Type: "lognum", NoTable: true},
 		{Section: "Data", Key: "lastAppTs", Type: "timestamp", NoTable: true},
 		{Section: "Data", Key: "lastAppTxID", Type: "txnum", NoTable: true},
+		{Section: "Value", Key: "fiatPrice", Type: "fiat", NoTable: true},
+		{Section: "Value", Key: "fiatValue", Type: "fiat"},
 		{Section: "", Key: "actions", Type: "actions", NoDetail: true},
 	}
 	types.NormalizeFields(&ret)
@@ -523,6 +555,14 @@ func getAssetChartsFacetConfig() *types.FacetChartConfig {
 	return &types.FacetChartConfig{
 		SeriesStrategy:  "address+symbol",
 		SeriesPrefixLen: 12,
+		Metrics:         []string{"frequency", "volume", "endBal", "counterparties", "gas", "endBalFiat", "volumeFiat"},
+	}
+}
+
+func getApprovalLogsFacetConfig() *types.FacetChartConfig {
+	return &types.FacetChartConfig{
+		SeriesStrategy:  "token",
+		SeriesPrefixLen: 12,
 	}
 }
 
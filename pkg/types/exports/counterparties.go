@@ -0,0 +1,123 @@
+package exports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/facets"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/names"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	coreTypes "github.com/TrueBlocks/trueblocks-chifra/v6/pkg/types"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// Counterparty totals the transfers between the exported address and one other address
+type Counterparty struct {
+	Address     base.Address `json:"address"`
+	AddressName string       `json:"addressName"`
+	Transfers   uint64       `json:"transfers"`
+	Sent        uint64       `json:"sent"`
+	Received    uint64       `json:"received"`
+	FirstBlock  base.Blknum  `json:"firstBlock"`
+	LastBlock   base.Blknum  `json:"lastBlock"`
+}
+
+// Model implements the sdk.Modeler interface for Counterparty
+func (c *Counterparty) Model(chain, format string, verbose bool, extraOptions map[string]any) coreTypes.Model {
+	return coreTypes.Model{
+		Data: map[string]any{
+			"address":     c.Address.Hex(),
+			"addressName": c.AddressName,
+			"transfers":   c.Transfers,
+			"sent":        c.Sent,
+			"received":    c.Received,
+			"firstBlock":  c.FirstBlock,
+			"lastBlock":   c.LastBlock,
+		},
+		Order: []string{"address", "addressName", "transfers", "sent", "received", "firstBlock", "lastBlock"},
+	}
+}
+
+// counterpartyOf returns the other side of a transfer and whether the holder sent it
+func counterpartyOf(t *Transfer) (base.Address, bool) {
+	if t.Sender == t.Holder {
+		return t.Recipient, true
+	}
+	return t.Sender, false
+}
+
+// counterpartyGroups groups transfers by the address on the other side of each one
+func counterpartyGroups() facets.GroupFuncs[Transfer, Counterparty] {
+	return facets.GroupFuncs[Transfer, Counterparty]{
+		Key: func(item *Transfer) string {
+			addr, _ := counterpartyOf(item)
+			return addr.Hex()
+		},
+		NewGroup: func(key string, first *Transfer) *Counterparty {
+			addr, _ := counterpartyOf(first)
+			return &Counterparty{
+				Address:     addr,
+				AddressName: names.NameAddress(addr),
+				FirstBlock:  first.BlockNumber,
+				LastBlock:   first.BlockNumber,
+			}
+		},
+		Fold: func(group *Counterparty, item *Transfer) *Counterparty {
+			folded := *group
+			folded.Transfers++
+			if _, sent := counterpartyOf(item); sent {
+				folded.Sent++
+			} else {
+				folded.Received++
+			}
+			folded.FirstBlock = min(folded.FirstBlock, item.BlockNumber)
+			folded.LastBlock = max(folded.LastBlock, item.BlockNumber)
+			return &folded
+		},
+	}
+}
+
+func (c *ExportsCollection) matchesCounterpartyFilter(item *Counterparty, filter string) bool {
+	return strings.Contains(strings.ToLower(item.Address.Hex()), filter) ||
+		strings.Contains(strings.ToLower(item.AddressName), filter)
+}
+
+// sortCounterparties orders counterparty rows by the first field of the sort spec, defaulting to
+// the most transfers first
+func sortCounterparties(items []Counterparty, spec sdk.SortSpec) error {
+	field := "transfers"
+	desc := true
+	if len(spec.Fields) > 0 && spec.Fields[0] != "" {
+		field = spec.Fields[0]
+		desc = len(spec.Order) > 0 && spec.Order[0] == sdk.Dec
+	}
+
+	var less func(a, b *Counterparty) bool
+	switch field {
+	case "address":
+		less = func(a, b *Counterparty) bool { return a.Address.Hex() < b.Address.Hex() }
+	case "addressName":
+		less = func(a, b *Counterparty) bool { return a.AddressName < b.AddressName }
+	case "transfers":
+		less = func(a, b *Counterparty) bool { return a.Transfers < b.Transfers }
+	case "sent":
+		less = func(a, b *Counterparty) bool { return a.Sent < b.Sent }
+	case "received":
+		less = func(a, b *Counterparty) bool { return a.Received < b.Received }
+	case "firstBlock":
+		less = func(a, b *Counterparty) bool { return a.FirstBlock < b.FirstBlock }
+	case "lastBlock":
+		less = func(a, b *Counterparty) bool { return a.LastBlock < b.LastBlock }
+	default:
+		return fmt.Errorf("unsupported sort field for counterparties: %s", field)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if desc {
+			return less(&items[j], &items[i])
+		}
+		return less(&items[i], &items[j])
+	})
+	return nil
+}
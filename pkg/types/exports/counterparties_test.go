@@ -0,0 +1,44 @@
+package exports
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+func TestCounterpartiesFacetGroupsTransfers(t *testing.T) {
+	holder := base.HexToAddress("0x00000000000000000000000000000000000c0de1")
+	alice := base.HexToAddress("0x00000000000000000000000000000000000a11ce")
+	bob := base.HexToAddress("0x0000000000000000000000000000000000000b0b")
+
+	payload := &types.Payload{Collection: "exports", DataFacet: ExportsCounterparties, ActiveChain: "mainnet", ActiveAddress: holder.Hex()}
+	c := NewExportsCollection(payload)
+
+	transfers := c.transfersFacet.GetStore()
+	transfers.AddItem(&Transfer{Holder: holder, Sender: holder, Recipient: alice, BlockNumber: 10}, 0)
+	transfers.AddItem(&Transfer{Holder: holder, Sender: alice, Recipient: holder, BlockNumber: 30}, 1)
+	transfers.AddItem(&Transfer{Holder: holder, Sender: bob, Recipient: holder, BlockNumber: 20}, 2)
+
+	page, err := c.GetPage(payload, 0, 10, sdk.SortSpec{}, "")
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	rows := page.(*ExportsPage).Counterparties
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 counterparties, got %d", len(rows))
+	}
+
+	first := rows[0]
+	if first.Address != alice || first.Transfers != 2 || first.Sent != 1 || first.Received != 1 {
+		t.Errorf("unexpected first row: %+v", first)
+	}
+	if first.FirstBlock != 10 || first.LastBlock != 30 {
+		t.Errorf("unexpected block span: %d-%d", first.FirstBlock, first.LastBlock)
+	}
+	if rows[1].Address != bob || rows[1].Received != 1 {
+		t.Errorf("unexpected second row: %+v", rows[1])
+	}
+}
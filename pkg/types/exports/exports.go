@@ -21,19 +21,20 @@ import (
 )
 
 const (
-	ExportsStatements    types.DataFacet = "statements"
-	ExportsAssets        types.DataFacet = "assets"
-	ExportsAssetCharts   types.DataFacet = "assetcharts"
-	ExportsBalances      types.DataFacet = "balances"
-	ExportsTransfers     types.DataFacet = "transfers"
-	ExportsOpenApprovals types.DataFacet = "openapprovals"
-	ExportsApprovalTxs   types.DataFacet = "approvaltxs"
-	ExportsApprovalLogs  types.DataFacet = "approvallogs"
-	ExportsTransactions  types.DataFacet = "transactions"
-	ExportsWithdrawals   types.DataFacet = "withdrawals"
-	ExportsReceipts      types.DataFacet = "receipts"
-	ExportsLogs          types.DataFacet = "logs"
-	ExportsTraces        types.DataFacet = "traces"
+	ExportsStatements     types.DataFacet = "statements"
+	ExportsAssets         types.DataFacet = "assets"
+	ExportsAssetCharts    types.DataFacet = "assetcharts"
+	ExportsBalances       types.DataFacet = "balances"
+	ExportsTransfers      types.DataFacet = "transfers"
+	ExportsCounterparties types.DataFacet = "counterparties"
+	ExportsOpenApprovals  types.DataFacet = "openapprovals"
+	ExportsApprovalTxs    types.DataFacet = "approvaltxs"
+	ExportsApprovalLogs   types.DataFacet = "approvallogs"
+	ExportsTransactions   types.DataFacet = "transactions"
+	ExportsWithdrawals    types.DataFacet = "withdrawals"
+	ExportsReceipts       types.DataFacet = "receipts"
+	ExportsLogs           types.DataFacet = "logs"
+	ExportsTraces         types.DataFacet = "traces"
 )
 
 func init() {
@@ -42,6 +43,7 @@ func init() {
 	types.RegisterDataFacet(ExportsAssetCharts)
 	types.RegisterDataFacet(ExportsBalances)
 	types.RegisterDataFacet(ExportsTransfers)
+	types.RegisterDataFacet(ExportsCounterparties)
 	types.RegisterDataFacet(ExportsOpenApprovals)
 	types.RegisterDataFacet(ExportsApprovalTxs)
 	types.RegisterDataFacet(ExportsApprovalLogs)
@@ -53,25 +55,34 @@ func init() {
 }
 
 type ExportsCollection struct {
-	statementsFacet    *facets.Facet[Statement]
-	assetsFacet        *facets.Facet[Asset]
-	assetchartsFacet   *facets.Facet[Statement]
-	balancesFacet      *facets.Facet[Balance]
-	transfersFacet     *facets.Facet[Transfer]
-	openapprovalsFacet *facets.Facet[OpenApproval]
-	approvaltxsFacet   *facets.Facet[ApprovalTx]
-	approvallogsFacet  *facets.Facet[ApprovalLog]
-	transactionsFacet  *facets.Facet[Transaction]
-	withdrawalsFacet   *facets.Facet[Withdrawal]
-	receiptsFacet      *facets.Facet[Receipt]
-	logsFacet          *facets.Facet[Log]
-	tracesFacet        *facets.Facet[Trace]
-	summary            types.Summary
-	summaryMutex       sync.RWMutex
+	statementsFacet     *facets.Facet[Statement]
+	assetsFacet         *facets.Facet[Asset]
+	assetchartsFacet    *facets.Facet[Statement]
+	balancesFacet       *facets.Facet[Balance]
+	transfersFacet      *facets.Facet[Transfer]
+	counterpartiesFacet *facets.Facet[Counterparty]
+	openapprovalsFacet  *facets.Facet[OpenApproval]
+	approvaltxsFacet    *facets.Facet[ApprovalTx]
+	approvallogsFacet   *facets.Facet[ApprovalLog]
+	transactionsFacet   *facets.Facet[Transaction]
+	withdrawalsFacet    *facets.Facet[Withdrawal]
+	receiptsFacet       *facets.Facet[Receipt]
+	logsFacet           *facets.Facet[Log]
+	tracesFacet         *facets.Facet[Trace]
+	summary             types.Summary
+	summaryMutex        sync.RWMutex
+	// EXISTING_CODE
+	chain             string // the chain of the payload the collection was made for
+	approvalTracker   *approvalTracker
+	approvalTrackerMu sync.Mutex
+	// EXISTING_CODE
 }
 
 func NewExportsCollection(payload *types.Payload) *ExportsCollection {
 	c := &ExportsCollection{}
+	// EXISTING_CODE
+	c.chain = payload.ActiveChain
+	// EXISTING_CODE
 	c.ResetSummary()
 	c.initializeFacets(payload)
 	return c
@@ -128,6 +139,16 @@ func (c *ExportsCollection) initializeFacets(payload *types.Payload) {
 		false,
 	)
 
+	c.counterpartiesFacet = facets.NewFacet(
+		ExportsCounterparties,
+		isCounterparty,
+		isDupCounterparty(),
+		c.getCounterpartiesStore(payload, ExportsCounterparties),
+		"exports",
+		c,
+		false,
+	)
+
 	c.openapprovalsFacet = facets.NewFacet(
 		ExportsOpenApprovals,
 		isOpenApproval,
@@ -239,6 +260,12 @@ func isTransfer(item *Transfer) bool {
 	// EXISTING_CODE
 }
 
+func isCounterparty(item *Counterparty) bool {
+	// EXISTING_CODE
+	return true
+	// EXISTING_CODE
+}
+
 func isOpenApproval(item *OpenApproval) bool {
 	// EXISTING_CODE
 	return true
@@ -311,6 +338,12 @@ func isDupBalance() func(existing []*Balance, newItem *Balance) bool {
 	// EXISTING_CODE
 }
 
+func isDupCounterparty() func(existing []*Counterparty, newItem *Counterparty) bool {
+	// EXISTING_CODE
+	return nil
+	// EXISTING_CODE
+}
+
 func isDupLog() func(existing []*Log, newItem *Log) bool {
 	// EXISTING_CODE
 	return nil
@@ -387,6 +420,16 @@ func (c *ExportsCollection) FetchByFacet(payload *types.Payload) {
 			if err := c.transfersFacet.FetchFacet(); err != nil {
 				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
 			}
+		case ExportsCounterparties:
+			// EXISTING_CODE
+			// the derived store fills from transfers, so load them as well
+			if err := c.transfersFacet.FetchFacet(); err != nil {
+				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
+			}
+			// EXISTING_CODE
+			if err := c.counterpartiesFacet.FetchFacet(); err != nil {
+				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
+			}
 		case ExportsOpenApprovals:
 			if err := c.openapprovalsFacet.FetchFacet(); err != nil {
 				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
@@ -438,6 +481,8 @@ func (c *ExportsCollection) Reset(payload *types.Payload) {
 		c.balancesFacet.Reset()
 	case ExportsTransfers:
 		c.transfersFacet.Reset()
+	case ExportsCounterparties:
+		c.counterpartiesFacet.Reset()
 	case ExportsOpenApprovals:
 		c.openapprovalsFacet.Reset()
 	case ExportsApprovalTxs:
@@ -471,6 +516,8 @@ func (c *ExportsCollection) NeedsUpdate(payload *types.Payload) bool {
 		return c.balancesFacet.NeedsUpdate()
 	case ExportsTransfers:
 		return c.transfersFacet.NeedsUpdate()
+	case ExportsCounterparties:
+		return c.counterpartiesFacet.NeedsUpdate()
 	case ExportsOpenApprovals:
 		return c.openapprovalsFacet.NeedsUpdate()
 	case ExportsApprovalTxs:
@@ -611,7 +658,7 @@ func (c *ExportsCollection) ResetSummary() {
 	}
 }
 
-func (c *ExportsCollection) ExportData(payload *types.Payload) (string, error) {
+func (c *ExportsCollection) ExportData(payload *types.Payload) (string, int, error) {
 	switch payload.DataFacet {
 	case ExportsStatements:
 		return c.statementsFacet.ExportData(payload, string(ExportsStatements))
@@ -623,6 +670,8 @@ func (c *ExportsCollection) ExportData(payload *types.Payload) (string, error) {
 		return c.balancesFacet.ExportData(payload, string(ExportsBalances))
 	case ExportsTransfers:
 		return c.transfersFacet.ExportData(payload, string(ExportsTransfers))
+	case ExportsCounterparties:
+		return c.counterpartiesFacet.ExportData(payload, string(ExportsCounterparties))
 	case ExportsOpenApprovals:
 		return c.openapprovalsFacet.ExportData(payload, string(ExportsOpenApprovals))
 	case ExportsApprovalTxs:
@@ -640,7 +689,7 @@ func (c *ExportsCollection) ExportData(payload *types.Payload) (string, error) {
 	case ExportsTraces:
 		return c.tracesFacet.ExportData(payload, string(ExportsTraces))
 	default:
-		return "", fmt.Errorf("[ExportData] unsupported exports facet: %s", payload.DataFacet)
+		return "", 0, fmt.Errorf("[ExportData] unsupported exports facet: %s", payload.DataFacet)
 	}
 }
 
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/pricing"
 	storePkg "github.com/TrueBlocks/trueblocks-approvals/pkg/store"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
@@ -22,23 +23,26 @@ import (
 // EXISTING_CODE
 
 type ExportsPage struct {
-	Facet         types.DataFacet  `json:"facet"`
-	ApprovalLogs  []ApprovalLog    `json:"approvallogs"`
-	ApprovalTxs   []ApprovalTx     `json:"approvaltxs"`
-	Assets        []Asset          `json:"assets"`
-	Balances      []Balance        `json:"balances"`
-	Logs          []Log            `json:"logs"`
-	OpenApprovals []OpenApproval   `json:"openapprovals"`
-	Receipts      []Receipt        `json:"receipts"`
-	Statements    []Statement      `json:"statements"`
-	Traces        []Trace          `json:"traces"`
-	Transactions  []Transaction    `json:"transactions"`
-	Transfers     []Transfer       `json:"transfers"`
-	Withdrawals   []Withdrawal     `json:"withdrawals"`
-	TotalItems    int              `json:"totalItems"`
-	ExpectedTotal int              `json:"expectedTotal"`
-	State         types.StoreState `json:"state"`
+	Facet          types.DataFacet  `json:"facet"`
+	ApprovalLogs   []ApprovalLog    `json:"approvallogs"`
+	ApprovalTxs    []ApprovalTx     `json:"approvaltxs"`
+	Assets         []Asset          `json:"assets"`
+	Balances       []Balance        `json:"balances"`
+	Counterparties []Counterparty   `json:"counterparties"`
+	Logs           []Log            `json:"logs"`
+	OpenApprovals  []OpenApproval   `json:"openapprovals"`
+	Receipts       []Receipt        `json:"receipts"`
+	Statements     []Statement      `json:"statements"`
+	Traces         []Trace          `json:"traces"`
+	Transactions   []Transaction    `json:"transactions"`
+	Transfers      []Transfer       `json:"transfers"`
+	Withdrawals    []Withdrawal     `json:"withdrawals"`
+	TotalItems     int              `json:"totalItems"`
+	ExpectedTotal  int              `json:"expectedTotal"`
+	State          types.StoreState `json:"state"`
 	// EXISTING_CODE
+	Totals []PeriodTotal       `json:"totals,omitempty"`
+	Values []pricing.Valuation `json:"values,omitempty"`
 	// EXISTING_CODE
 }
 
@@ -172,6 +176,25 @@ func (c *ExportsCollection) GetPage(
 			page.State = result.State
 		}
 		page.ExpectedTotal = facet.ExpectedCount()
+	case ExportsCounterparties:
+		facet := c.counterpartiesFacet
+		var filterFunc func(*Counterparty) bool
+		if filter != "" {
+			filterFunc = func(item *Counterparty) bool {
+				return c.matchesCounterpartyFilter(item, filter)
+			}
+		}
+		sortFunc := func(items []Counterparty, sort sdk.SortSpec) error {
+			return sortCounterparties(items, sort)
+		}
+		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+			return nil, types.NewStoreError("exports", dataFacet, "GetPage", err)
+		} else {
+			page.Counterparties = result.Items
+			page.TotalItems = result.TotalItems
+			page.State = result.State
+		}
+		page.ExpectedTotal = facet.ExpectedCount()
 	case ExportsOpenApprovals:
 		facet := c.openapprovalsFacet
 		var filterFunc func(*OpenApproval) bool
@@ -329,6 +352,9 @@ func (c *ExportsCollection) GetPage(
 			fmt.Errorf("[GetPage] unsupported dataFacet: %v", payload.DataFacet))
 	}
 
+	// EXISTING_CODE
+	c.valuePage(page, payload)
+	// EXISTING_CODE
 	return page, nil
 }
 
@@ -338,7 +364,7 @@ func (c *ExportsCollection) shouldSummarize(payload *types.Payload) bool {
 		return false
 	}
 	// EXISTING_CODE
-	if payload.DataFacet != ExportsAssets {
+	if payload.DataFacet != ExportsAssets && payload.DataFacet != ExportsCounterparties {
 		return true
 	}
 	// EXISTING_CODE
@@ -411,6 +437,21 @@ func (c *ExportsCollection) getSummaryPage(
 		}
 		page.Statements = valueSlice
 		page.TotalItems = total
+
+		totals := c.statementsFacet.GetStore().GetSummaryManager().GetAggregates(period)
+		page.Totals, _ = pagePeriodTotals(totals, 0, len(totals), sdk.SortSpec{}, filter)
+		c.valuePage(page, payload)
+		return page, nil
+
+	case ExportsTransfers:
+		totals := c.transfersFacet.GetStore().GetSummaryManager().GetAggregates(period)
+		page.Totals, page.TotalItems = pagePeriodTotals(totals, first, pageSize, sortSpec, filter)
+		return page, nil
+
+	case ExportsTransactions:
+		c.aggregateTransactions(base.HexToAddress(payload.ActiveAddress), period)
+		totals := c.transactionsFacet.GetStore().GetSummaryManager().GetAggregates(period)
+		page.Totals, page.TotalItems = pagePeriodTotals(totals, first, pageSize, sortSpec, filter)
 		return page, nil
 
 	case ExportsBalances:
@@ -453,6 +494,7 @@ func (c *ExportsCollection) getSummaryPage(
 
 		page.Balances = valueSlice
 		page.TotalItems = total
+		c.valuePage(page, payload)
 		return page, nil
 
 	// EXISTING_CODE
@@ -482,6 +524,7 @@ func (c *ExportsCollection) generateSummariesForPeriod(dataFacet types.DataFacet
 		for _, statement := range data {
 			normalizedTime := storePkg.NormalizeToPeriod(int64(statement.Timestamp), period)
 			periodGroups[normalizedTime] = append(periodGroups[normalizedTime], statement)
+			store.GetSummaryManager().Aggregate(statementTotal(statement), period)
 		}
 
 		// Create one summary statement per period
@@ -540,6 +583,20 @@ func (c *ExportsCollection) generateSummariesForPeriod(dataFacet types.DataFacet
 			balancesStore.GetSummaryManager().AddBalance(balance, period)
 		}
 		return nil
+
+	case ExportsTransfers:
+		store := c.transfersFacet.GetStore()
+		store.GetSummaryManager().Reset()
+		for _, transfer := range store.GetItems(false) {
+			if total, ok := transferTotal(transfer); ok {
+				store.GetSummaryManager().Aggregate(total, period)
+			}
+		}
+		return nil
+
+	case ExportsTransactions:
+		// Direction depends on the holder, which getSummaryPage passes to aggregateTransactions
+		return nil
 	// EXISTING_CODE
 	default:
 		return fmt.Errorf("[generateSummariesForPeriod] unsupported dataFacet for summary: %v", dataFacet)
@@ -0,0 +1,150 @@
+package exports
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	storePkg "github.com/TrueBlocks/trueblocks-approvals/pkg/store"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+const rangeDateLayout = "2006-01-02"
+
+var (
+	dateBlockCache   = make(map[string]sdk.NamedBlock)
+	dateBlockCacheMu sync.Mutex
+)
+
+// dateBounds converts the payload's inclusive date range to a half-open [from, to) span of
+// unix timestamps in the configured reporting timezone. Zero means unbounded.
+func dateBounds(payload *types.Payload, loc *time.Location) (from, to int64, err error) {
+	if payload.FirstDate != "" {
+		t, err := time.ParseInLocation(rangeDateLayout, payload.FirstDate, loc)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid first date %q: %w", payload.FirstDate, err)
+		}
+		from = t.Unix()
+	}
+	if payload.LastDate != "" {
+		t, err := time.ParseInLocation(rangeDateLayout, payload.LastDate, loc)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid last date %q: %w", payload.LastDate, err)
+		}
+		to = t.AddDate(0, 0, 1).Unix()
+	}
+	if from != 0 && to != 0 && from >= to {
+		return 0, 0, fmt.Errorf("first date %s is after last date %s", payload.FirstDate, payload.LastDate)
+	}
+	return from, to, nil
+}
+
+// rangeKey is the payload's range key in the current reporting timezone
+func rangeKey(payload *types.Payload) string {
+	return payload.RangeKey(storePkg.GetPeriodConfig().Location().String())
+}
+
+// blockAtOrBefore returns the last block produced at or before the given timestamp
+var blockAtOrBefore = func(chain string, ts int64) (sdk.NamedBlock, error) {
+	key := fmt.Sprintf("%s_%d", chain, ts)
+	dateBlockCacheMu.Lock()
+	block, ok := dateBlockCache[key]
+	dateBlockCacheMu.Unlock()
+	if ok {
+		return block, nil
+	}
+
+	opts := sdk.WhenOptions{
+		Globals:  sdk.Globals{Cache: true, Chain: chain},
+		BlockIds: []string{time.Unix(ts, 0).UTC().Format("2006-01-02T15:04:05")},
+	}
+	blocks, _, err := opts.When()
+	if err != nil {
+		return sdk.NamedBlock{}, err
+	}
+	if len(blocks) == 0 {
+		return sdk.NamedBlock{}, fmt.Errorf("no block found for %s", opts.BlockIds[0])
+	}
+	dateBlockCacheMu.Lock()
+	dateBlockCache[key] = blocks[0]
+	dateBlockCacheMu.Unlock()
+	return blocks[0], nil
+}
+
+// resolveBlockRange combines the payload's block and date bounds into the block range
+// passed to chifra. A zero last block means the chain head.
+func resolveBlockRange(payload *types.Payload, facet types.DataFacet) (first, last base.Blknum, err error) {
+	first, last = base.Blknum(payload.FirstBlock), base.Blknum(payload.LastBlock)
+	if last != 0 && first > last {
+		return 0, 0, types.NewValidationError("exports", facet, "resolveBlockRange",
+			fmt.Errorf("first block %d is after last block %d", first, last))
+	}
+
+	from, to, err := dateBounds(payload, storePkg.GetPeriodConfig().Location())
+	if err != nil {
+		return 0, 0, types.NewValidationError("exports", facet, "resolveBlockRange", err)
+	}
+
+	if from != 0 {
+		block, err := blockAtOrBefore(payload.ActiveChain, from)
+		if err != nil {
+			return 0, 0, types.NewSDKError("exports", facet, "resolveBlockRange", err)
+		}
+		start := block.BlockNumber
+		if int64(block.Timestamp) < from {
+			start++
+		}
+		first = max(first, start)
+	}
+
+	if to != 0 {
+		block, err := blockAtOrBefore(payload.ActiveChain, to)
+		if err != nil {
+			return 0, 0, types.NewSDKError("exports", facet, "resolveBlockRange", err)
+		}
+		end := block.BlockNumber
+		if int64(block.Timestamp) >= to && end > 0 {
+			end--
+		}
+		if last == 0 || end < last {
+			last = end
+		}
+	}
+
+	if last != 0 && first > last {
+		return 0, 0, types.NewValidationError("exports", facet, "resolveBlockRange",
+			fmt.Errorf("the block and date ranges do not overlap"))
+	}
+	return first, last, nil
+}
+
+// applyRange scopes an export query to the payload's block and date range
+func applyRange(payload *types.Payload, facet types.DataFacet, opts *sdk.ExportOptions) error {
+	if !payload.HasRange() {
+		return nil
+	}
+	first, last, err := resolveBlockRange(payload, facet)
+	if err != nil {
+		return err
+	}
+	opts.FirstBlock, opts.LastBlock = first, last
+	return nil
+}
+
+// applyTokensRange reports token state as of the end of the payload's range
+func applyTokensRange(payload *types.Payload, facet types.DataFacet, opts *sdk.TokensOptions) error {
+	if !payload.HasRange() {
+		return nil
+	}
+	_, last, err := resolveBlockRange(payload, facet)
+	if err != nil {
+		return err
+	}
+	if last != 0 {
+		opts.BlockIds = []string{fmt.Sprintf("%d", last)}
+	}
+	return nil
+}
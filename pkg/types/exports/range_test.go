@@ -0,0 +1,106 @@
+package exports
+
+import (
+	"testing"
+	"time"
+
+	storePkg "github.com/TrueBlocks/trueblocks-approvals/pkg/store"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+func TestRangeScopesStoreKey(t *testing.T) {
+	unscoped := &types.Payload{ActiveChain: "mainnet", ActiveAddress: "0xabc"}
+	scoped := &types.Payload{ActiveChain: "mainnet", ActiveAddress: "0xabc", FirstDate: "2024-04-01", LastDate: "2025-03-31"}
+
+	if got := getStoreKey(unscoped); got != "mainnet_0xabc" {
+		t.Errorf("unscoped key changed: %s", got)
+	}
+	if getStoreKey(scoped) == getStoreKey(unscoped) {
+		t.Errorf("expected a scoped payload to use its own store key")
+	}
+	c := &ExportsCollection{}
+	if c.getStoreName(scoped, ExportsTransfers) == c.getStoreName(unscoped, ExportsTransfers) {
+		t.Errorf("expected a scoped payload to use its own store name")
+	}
+}
+
+func TestDateBounds(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	from, to, err := dateBounds(&types.Payload{FirstDate: "2024-04-01", LastDate: "2025-03-31"}, ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != time.Date(2024, 4, 1, 0, 0, 0, 0, ny).Unix() || to != time.Date(2025, 4, 1, 0, 0, 0, 0, ny).Unix() {
+		t.Errorf("unexpected bounds: %v - %v", time.Unix(from, 0), time.Unix(to, 0))
+	}
+
+	if _, _, err := dateBounds(&types.Payload{FirstDate: "2025-01-02", LastDate: "2025-01-01"}, time.UTC); err == nil {
+		t.Errorf("expected error for reversed dates")
+	}
+	if _, _, err := dateBounds(&types.Payload{FirstDate: "01/02/2025"}, time.UTC); err == nil {
+		t.Errorf("expected error for malformed date")
+	}
+}
+
+func TestResolveBlockRange(t *testing.T) {
+	saved := blockAtOrBefore
+	defer func() { blockAtOrBefore = saved }()
+
+	// One block every 10 seconds starting at timestamp 0
+	blockAtOrBefore = func(chain string, ts int64) (sdk.NamedBlock, error) {
+		n := ts / 10
+		return sdk.NamedBlock{BlockNumber: base.Blknum(n), Timestamp: base.Timestamp(n * 10)}, nil
+	}
+
+	day := time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC).Unix() // 86400, exactly on block 8640
+	first, last, err := resolveBlockRange(&types.Payload{FirstDate: "1970-01-02", LastDate: "1970-01-02"}, ExportsTransfers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != base.Blknum(day/10) || last != base.Blknum(2*day/10-1) {
+		t.Errorf("unexpected range %d-%d", first, last)
+	}
+
+	// Explicit block bounds narrow the date range further
+	first, last, err = resolveBlockRange(&types.Payload{FirstDate: "1970-01-02", LastDate: "1970-01-02", FirstBlock: 9000, LastBlock: 9100}, ExportsTransfers)
+	if err != nil || first != 9000 || last != 9100 {
+		t.Errorf("unexpected range %d-%d (%v)", first, last, err)
+	}
+
+	if _, _, err := resolveBlockRange(&types.Payload{FirstBlock: 10, LastBlock: 5}, ExportsTransfers); err == nil {
+		t.Errorf("expected error for reversed blocks")
+	}
+
+	opts := sdk.ExportOptions{}
+	if err := applyRange(&types.Payload{FirstBlock: 100}, ExportsTransfers, &opts); err != nil || opts.FirstBlock != 100 || opts.LastBlock != 0 {
+		t.Errorf("unexpected options: %+v (%v)", opts, err)
+	}
+}
+
+func TestRangeKeyFollowsTimezone(t *testing.T) {
+	if _, err := time.LoadLocation("Asia/Tokyo"); err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	saved := storePkg.GetPeriodConfig()
+	defer storePkg.SetPeriodConfig(saved)
+
+	dated := &types.Payload{ActiveChain: "mainnet", ActiveAddress: "0xabc", FirstDate: "2024-04-01", LastDate: "2025-03-31"}
+	blocks := &types.Payload{ActiveChain: "mainnet", ActiveAddress: "0xabc", FirstBlock: 100, LastBlock: 200}
+
+	storePkg.SetPeriodConfig(types.PeriodConfig{})
+	utcDated, utcBlocks := getStoreKey(dated), getStoreKey(blocks)
+
+	storePkg.SetPeriodConfig(types.PeriodConfig{Timezone: "Asia/Tokyo"})
+	if getStoreKey(dated) == utcDated {
+		t.Errorf("expected a date range to use a different store key in another timezone")
+	}
+	if getStoreKey(blocks) != utcBlocks {
+		t.Errorf("expected a block range to keep its store key across timezones")
+	}
+}
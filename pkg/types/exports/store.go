@@ -13,7 +13,9 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/facets"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/logging"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/signatures"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/store"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types/names"
@@ -53,6 +55,9 @@ var (
 	balancesStore   = make(map[string]*store.Store[Balance])
 	balancesStoreMu sync.Mutex
 
+	counterpartiesStore   = make(map[string]*store.Store[Counterparty])
+	counterpartiesStoreMu sync.Mutex
+
 	logsStore   = make(map[string]*store.Store[Log])
 	logsStoreMu sync.Mutex
 
@@ -96,6 +101,9 @@ func (c *ExportsCollection) getApprovalLogsStore(payload *types.Payload, facet t
 				Addrs:      []string{payload.ActiveAddress},
 				Articulate: true,
 			}
+			if err := applyRange(payload, ExportsApprovalLogs, &opts); err != nil {
+				return err
+			}
 			if _, _, err := opts.ExportApprovalsLogs(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsApprovalLogs, "fetch", err)
 				return wrappedErr
@@ -120,6 +128,7 @@ func (c *ExportsCollection) getApprovalLogsStore(payload *types.Payload, facet t
 				if err := it.EnsureCalcs(props, nil); err != nil {
 					logging.LogBEError(fmt.Sprintf("Failed to calculate fields during ingestion: %v", err))
 				}
+				c.updateApprovalLogsBucket(it)
 				return it
 			}
 			return nil
@@ -160,6 +169,9 @@ func (c *ExportsCollection) getApprovalTxsStore(payload *types.Payload, facet ty
 				Articulate: true,
 				Unripe:     true,
 			}
+			if err := applyRange(payload, ExportsApprovalTxs, &opts); err != nil {
+				return err
+			}
 			if _, _, err := opts.ExportApprovals(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsApprovalTxs, "fetch", err)
 				return wrappedErr
@@ -213,6 +225,9 @@ func (c *ExportsCollection) getAssetsStore(payload *types.Payload, facet types.D
 				Addrs:      []string{payload.ActiveAddress},
 				Accounting: true, // Enable accounting for statements
 			}
+			if err := applyRange(payload, ExportsStatements, &opts); err != nil {
+				return err
+			}
 			if _, _, err := opts.ExportStatements(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsStatements, "fetch", err)
 				logging.LogBEWarning(fmt.Sprintf("Exports statements SDK query error: %v", wrappedErr))
@@ -294,6 +309,9 @@ func (c *ExportsCollection) getBalancesStore(payload *types.Payload, facet types
 				RenderCtx: ctx,
 				Addrs:     []string{payload.ActiveAddress},
 			}
+			if err := applyRange(payload, ExportsBalances, &opts); err != nil {
+				return err
+			}
 			if _, _, err := opts.ExportBalances(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsBalances, "fetch", err)
 				logging.LogBEWarning(fmt.Sprintf("Exports balances SDK query error: %v", wrappedErr))
@@ -341,6 +359,26 @@ func (c *ExportsCollection) getBalancesStore(payload *types.Payload, facet types
 	return theStore
 }
 
+func (c *ExportsCollection) getCounterpartiesStore(payload *types.Payload, facet types.DataFacet) *store.Store[Counterparty] {
+	counterpartiesStoreMu.Lock()
+	defer counterpartiesStoreMu.Unlock()
+
+	storeKey := getStoreKey(payload)
+	theStore := counterpartiesStore[storeKey]
+	if theStore == nil {
+		// EXISTING_CODE
+		// counterparties are derived from the transfers store rather than queried from the sdk
+		storeName := c.getStoreName(payload, facet)
+		transfers := c.getTransfersStore(payload, ExportsTransfers)
+		theStore = facets.NewGroupByStore(storeName, transfers, counterpartyGroups())
+		// EXISTING_CODE
+
+		counterpartiesStore[storeKey] = theStore
+	}
+
+	return theStore
+}
+
 func (c *ExportsCollection) getLogsStore(payload *types.Payload, facet types.DataFacet) *store.Store[Log] {
 	logsStoreMu.Lock()
 	defer logsStoreMu.Unlock()
@@ -359,6 +397,9 @@ func (c *ExportsCollection) getLogsStore(payload *types.Payload, facet types.Dat
 				Addrs:      []string{payload.ActiveAddress},
 				Articulate: true,
 			}
+			if err := applyRange(payload, ExportsLogs, &opts); err != nil {
+				return err
+			}
 			if _, _, err := opts.ExportLogs(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsLogs, "fetch", err)
 				logging.LogBEWarning(fmt.Sprintf("Exports logs SDK query error: %v", wrappedErr))
@@ -372,6 +413,9 @@ func (c *ExportsCollection) getLogsStore(payload *types.Payload, facet types.Dat
 			if it, ok := item.(*Log); ok {
 				it.AddressName = names.NameAddress(it.Address)
 				// EXISTING_CODE
+				if it.ArticulatedLog == nil {
+					it.ArticulatedLog = signatures.DecodeLog(it)
+				}
 				// EXISTING_CODE
 				props := &sdk.ModelProps{
 					Chain:   payload.ActiveChain,
@@ -431,6 +475,9 @@ func (c *ExportsCollection) getOpenApprovalsStore(payload *types.Payload, facet
 				Addrs:     []string{payload.ActiveAddress},
 				NoZero:    true,
 			}
+			if err := applyTokensRange(payload, ExportsOpenApprovals, &opts); err != nil {
+				return err
+			}
 			if _, _, err := opts.TokensApprovals(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsOpenApprovals, "fetch", err)
 				logging.LogBEWarning(fmt.Sprintf("Exports openapprovals SDK query error: %v", wrappedErr))
@@ -486,6 +533,9 @@ func (c *ExportsCollection) getReceiptsStore(payload *types.Payload, facet types
 				Addrs:      []string{payload.ActiveAddress},
 				Articulate: true,
 			}
+			if err := applyRange(payload, ExportsReceipts, &opts); err != nil {
+				return err
+			}
 			if _, _, err := opts.ExportReceipts(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsReceipts, "fetch", err)
 				logging.LogBEWarning(fmt.Sprintf("Exports receipts SDK query error: %v", wrappedErr))
@@ -501,6 +551,11 @@ func (c *ExportsCollection) getReceiptsStore(payload *types.Payload, facet types
 				it.ToName = names.NameAddress(it.To)
 				it.ContractAddressName = names.NameAddress(it.ContractAddress)
 				// EXISTING_CODE
+				for i := range it.Logs {
+					if it.Logs[i].ArticulatedLog == nil {
+						it.Logs[i].ArticulatedLog = signatures.DecodeLog(&it.Logs[i])
+					}
+				}
 				// EXISTING_CODE
 				return it
 			}
@@ -541,6 +596,9 @@ func (c *ExportsCollection) getStatementsStore(payload *types.Payload, facet typ
 				Addrs:      []string{payload.ActiveAddress},
 				Accounting: true, // Enable accounting for statements
 			}
+			if err := applyRange(payload, ExportsStatements, &opts); err != nil {
+				return err
+			}
 			if _, _, err := opts.ExportStatements(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsStatements, "fetch", err)
 				logging.LogBEWarning(fmt.Sprintf("Exports statements SDK query error: %v", wrappedErr))
@@ -610,6 +668,9 @@ func (c *ExportsCollection) getTracesStore(payload *types.Payload, facet types.D
 				Addrs:      []string{payload.ActiveAddress},
 				Articulate: true,
 			}
+			if err := applyRange(payload, ExportsTraces, &opts); err != nil {
+				return err
+			}
 			if _, _, err := opts.ExportTraces(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsTraces, "fetch", err)
 				logging.LogBEWarning(fmt.Sprintf("Exports traces SDK query error: %v", wrappedErr))
@@ -622,6 +683,9 @@ func (c *ExportsCollection) getTracesStore(payload *types.Payload, facet types.D
 		processFunc := func(item interface{}) *Trace {
 			if it, ok := item.(*Trace); ok {
 				// EXISTING_CODE
+				if it.ArticulatedTrace == nil && it.Action != nil {
+					it.ArticulatedTrace = signatures.DecodeInput(it.Action.Input)
+				}
 				// EXISTING_CODE
 				return it
 			}
@@ -662,6 +726,9 @@ func (c *ExportsCollection) getTransactionsStore(payload *types.Payload, facet t
 				Addrs:      []string{payload.ActiveAddress},
 				Articulate: true,
 			}
+			if err := applyRange(payload, ExportsTransactions, &opts); err != nil {
+				return err
+			}
 			if _, _, err := opts.Export(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsTransactions, "fetch", err)
 				logging.LogBEWarning(fmt.Sprintf("Exports transactions SDK query error: %v", wrappedErr))
@@ -676,6 +743,9 @@ func (c *ExportsCollection) getTransactionsStore(payload *types.Payload, facet t
 				it.FromName = names.NameAddress(it.From)
 				it.ToName = names.NameAddress(it.To)
 				// EXISTING_CODE
+				if it.ArticulatedTx == nil {
+					it.ArticulatedTx = signatures.DecodeInput(it.Input)
+				}
 				// EXISTING_CODE
 				return it
 			}
@@ -716,6 +786,9 @@ func (c *ExportsCollection) getTransfersStore(payload *types.Payload, facet type
 				Addrs:      []string{payload.ActiveAddress},
 				Accounting: true, // Enable accounting for transfers
 			}
+			if err := applyRange(payload, ExportsTransfers, &opts); err != nil {
+				return err
+			}
 			if _, _, err := opts.ExportTransfers(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsTransfers, "fetch", err)
 				logging.LogBEWarning(fmt.Sprintf("Exports transfers SDK query error: %v", wrappedErr))
@@ -782,6 +855,9 @@ func (c *ExportsCollection) getWithdrawalsStore(payload *types.Payload, facet ty
 				RenderCtx: ctx,
 				Addrs:     []string{payload.ActiveAddress},
 			}
+			if err := applyRange(payload, ExportsWithdrawals, &opts); err != nil {
+				return err
+			}
 			if _, _, err := opts.ExportWithdrawals(); err != nil {
 				wrappedErr := types.NewSDKError("exports", ExportsTransfers, "fetch", err)
 				logging.LogBEWarning(fmt.Sprintf("Exports transfers SDK query error: %v", wrappedErr))
@@ -834,6 +910,8 @@ func (c *ExportsCollection) getStoreName(payload *types.Payload, facet types.Dat
 		name = "exports-balances"
 	case ExportsTransfers:
 		name = "exports-transfers"
+	case ExportsCounterparties:
+		name = "exports-counterparties"
 	case ExportsOpenApprovals:
 		name = "exports-openapprovals"
 	case ExportsApprovalTxs:
@@ -854,6 +932,13 @@ func (c *ExportsCollection) getStoreName(payload *types.Payload, facet types.Dat
 		return ""
 	}
 	name = fmt.Sprintf("%s-%s-%s", name, payload.ActiveChain, payload.ActiveAddress)
+
+	// EXISTING_CODE
+	if scope := rangeKey(payload); scope != "" {
+		name = fmt.Sprintf("%s-%s", name, scope)
+	}
+	// EXISTING_CODE
+
 	return name
 }
 
@@ -879,6 +964,9 @@ func GetExportsCollection(payload *types.Payload) *ExportsCollection {
 
 func getStoreKey(payload *types.Payload) string {
 	// EXISTING_CODE
+	if scope := rangeKey(payload); scope != "" {
+		return fmt.Sprintf("%s_%s_%s", payload.ActiveChain, payload.ActiveAddress, scope)
+	}
 	// EXISTING_CODE
 	return fmt.Sprintf("%s_%s", payload.ActiveChain, payload.ActiveAddress)
 }
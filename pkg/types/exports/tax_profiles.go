@@ -0,0 +1,166 @@
+package exports
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/pricing"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// taxRow is a statement reduced to what tax tools import. Amounts are exact decimal strings
+// in the asset's units, empty when zero.
+type taxRow struct {
+	Time     time.Time
+	Sent     string
+	Received string
+	Fee      string
+	Currency string
+	Hash     string
+	Worth    pricing.Valuation
+}
+
+// statementTaxRow reduces a statement, returning false if nothing moved. Gas is reported as
+// the fee and is not part of the sent amount.
+func statementTaxRow(s *Statement, payload *types.Payload) (taxRow, bool) {
+	decimals := statementDecimals(s)
+	row := taxRow{
+		Time:     time.Unix(int64(s.Timestamp), 0).UTC(),
+		Sent:     decimalAmount(s.TotalOutLessGas(), decimals),
+		Received: decimalAmount(s.TotalIn(), decimals),
+		Fee:      decimalAmount(&s.GasOut, decimals),
+		Currency: s.Symbol,
+		Hash:     s.TransactionHash.Hex(),
+	}
+	if row.Sent == "" && row.Received == "" && row.Fee == "" {
+		return row, false
+	}
+	if row.Currency == "" {
+		row.Currency = s.Asset.Hex()
+	}
+
+	if pricing.Enabled() {
+		// The worth of a transaction is what was received or, failing that, what was sent
+		amount := s.TotalIn()
+		if amount.IsZero() {
+			amount = s.TotalOutLessGas()
+		}
+		if v := statementValuation(payload.ActiveChain, s); v.Priced {
+			v.Value = pricing.Value(amount, decimals, pricing.Quote{Price: v.Price})
+			row.Worth = v
+		}
+	}
+	return row, true
+}
+
+// decimalAmount formats an amount in whole units without the rounding of a float
+func decimalAmount(amount *base.Wei, decimals int) string {
+	value := (*big.Int)(amount)
+	if value.Sign() == 0 {
+		return ""
+	}
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+	}
+	digits := new(big.Int).Abs(value).String()
+	if decimals <= 0 {
+		return sign + digits
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if frac == "" {
+		return sign + whole
+	}
+	return sign + whole + "." + frac
+}
+
+// statementProfile registers a profile for the statements facet built from taxRows
+func statementProfile(name, label string, columns []string, build func(row taxRow) map[string]any) {
+	types.RegisterExportProfile(types.ExportProfile{
+		Name:       name,
+		Label:      label,
+		Collection: "exports",
+		DataFacet:  ExportsStatements,
+		Columns:    columns,
+		Rows: func(item any, payload *types.Payload) []map[string]any {
+			s, ok := item.(*Statement)
+			if !ok {
+				return nil
+			}
+			row, ok := statementTaxRow(s, payload)
+			if !ok {
+				return nil
+			}
+			return []map[string]any{build(row)}
+		},
+	})
+}
+
+func init() {
+	// Koinly's universal CSV takes one row per transaction with its net worth in fiat
+	statementProfile("koinly", "Koinly universal",
+		[]string{"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency",
+			"Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash"},
+		func(row taxRow) map[string]any {
+			ret := map[string]any{
+				"Date":            row.Time.Format("2006-01-02 15:04:05 UTC"),
+				"Sent Amount":     row.Sent,
+				"Received Amount": row.Received,
+				"Fee Amount":      row.Fee,
+				"TxHash":          row.Hash,
+			}
+			if row.Sent == "" && row.Received == "" {
+				// Koinly records gas spent on its own as a cost
+				ret["Sent Amount"], ret["Fee Amount"], ret["Label"] = row.Fee, "", "cost"
+			}
+			for amount, currency := range map[string]string{"Sent Amount": "Sent Currency", "Received Amount": "Received Currency", "Fee Amount": "Fee Currency"} {
+				if ret[amount] != "" {
+					ret[currency] = row.Currency
+				}
+			}
+			if row.Worth.Priced {
+				ret["Net Worth Amount"] = fmt.Sprintf("%.2f", row.Worth.Value)
+				ret["Net Worth Currency"] = row.Worth.Currency
+			}
+			return ret
+		})
+
+	// CoinTracker's CSV dates are month first and in UTC
+	statementProfile("cointracker", "CoinTracker",
+		[]string{"Date", "Received Quantity", "Received Currency", "Sent Quantity", "Sent Currency",
+			"Fee Amount", "Fee Currency", "Tag"},
+		func(row taxRow) map[string]any {
+			ret := map[string]any{
+				"Date":              row.Time.Format("01/02/2006 15:04:05"),
+				"Received Quantity": row.Received,
+				"Sent Quantity":     row.Sent,
+				"Fee Amount":        row.Fee,
+			}
+			for amount, currency := range map[string]string{"Received Quantity": "Received Currency", "Sent Quantity": "Sent Currency", "Fee Amount": "Fee Currency"} {
+				if ret[amount] != "" {
+					ret[currency] = row.Currency
+				}
+			}
+			return ret
+		})
+
+	statementProfile("generic", "Generic (date, sent, received, fee)",
+		[]string{"date", "sent", "received", "fee", "currency", "txHash"},
+		func(row taxRow) map[string]any {
+			return map[string]any{
+				"date":     row.Time.Format(time.RFC3339),
+				"sent":     row.Sent,
+				"received": row.Received,
+				"fee":      row.Fee,
+				"currency": row.Currency,
+				"txHash":   row.Hash,
+			}
+		})
+}
@@ -0,0 +1,153 @@
+package exports
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/pricing"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+func TestDecimalAmount(t *testing.T) {
+	tests := []struct {
+		wei      int64
+		decimals int
+		want     string
+	}{
+		{0, 18, ""},
+		{1500000, 6, "1.5"},
+		{2000000, 6, "2"},
+		{42, 6, "0.000042"},
+		{-42, 2, "-0.42"},
+		{7, 0, "7"},
+	}
+	for _, tt := range tests {
+		w := base.NewWei(tt.wei)
+		if got := decimalAmount(w, tt.decimals); got != tt.want {
+			t.Errorf("decimalAmount(%d, %d) = %q, want %q", tt.wei, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func profileExport(t *testing.T, profile string, statements []Statement) []string {
+	t.Helper()
+	payload := &types.Payload{
+		Collection:    "exports",
+		DataFacet:     ExportsStatements,
+		ActiveChain:   "mainnet",
+		ActiveAddress: "0xf503017d7baf7fbc0fff7492b751025c6a78179b",
+		Format:        profile,
+		ProjectPath:   filepath.Join(t.TempDir(), "test.tbx"),
+	}
+	path, err := types.ExportData(statements, payload, "statements")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(filepath.Base(path), "statements-"+profile) || filepath.Ext(path) != ".csv" {
+		t.Errorf("unexpected export file %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func taxStatements() []Statement {
+	hash := base.HexToHash("0x01")
+	return []Statement{
+		// 1.5 ETH received
+		{Symbol: "ETH", Decimals: 18, Timestamp: 1700000000, AmountIn: tokens(3), TransactionHash: hash},
+		// Nothing moved
+		{Symbol: "ETH", Decimals: 18, Timestamp: 1700000100},
+		// 2 DAI sent, gas paid separately
+		{Symbol: "DAI", Decimals: 18, Timestamp: 1700000200, AmountOut: tokens(2)},
+		// Gas only
+		{Symbol: "ETH", Decimals: 18, Timestamp: 1700000300, GasOut: *base.NewWei(21000000000000)},
+	}
+}
+
+func TestKoinlyProfile(t *testing.T) {
+	defer pricing.Configure(nil, "")
+	statements := taxStatements()
+	statements[0].AmountIn = *base.NewWei(1500000000000000000)
+	statements[0].SpotPrice = *base.NewFloat(2000)
+
+	lines := profileExport(t, "koinly", statements)
+	hash := base.HexToHash("0x01")
+	want := []string{
+		"Date,Sent Amount,Sent Currency,Received Amount,Received Currency,Fee Amount,Fee Currency,Net Worth Amount,Net Worth Currency,Label,Description,TxHash",
+		"2023-11-14 22:13:20 UTC,,,1.5,ETH,,,,,,," + hash.Hex(),
+		"2023-11-14 22:16:40 UTC,2,DAI,,,,,,,,,0x0000000000000000000000000000000000000000000000000000000000000000",
+		"2023-11-14 22:18:20 UTC,0.000021,ETH,,,,,,,cost,,0x0000000000000000000000000000000000000000000000000000000000000000",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected koinly export:\n%s", strings.Join(lines, "\n"))
+	}
+
+	// With pricing on, the received amount is valued at the spot price
+	pricing.Configure(fixedSource{}, "USD")
+	lines = profileExport(t, "koinly", statements)
+	if !strings.Contains(lines[1], ",3000.00,USD,") {
+		t.Errorf("expected a net worth of 3000 USD, got %s", lines[1])
+	}
+}
+
+func TestCoinTrackerAndGenericProfiles(t *testing.T) {
+	lines := profileExport(t, "cointracker", taxStatements())
+	if len(lines) != 4 || lines[0] != "Date,Received Quantity,Received Currency,Sent Quantity,Sent Currency,Fee Amount,Fee Currency,Tag" {
+		t.Fatalf("unexpected cointracker export %q", lines)
+	}
+	if lines[1] != "11/14/2023 22:13:20,3,ETH,,,,," || lines[3] != "11/14/2023 22:18:20,,,,,0.000021,ETH," {
+		t.Errorf("unexpected cointracker rows %q", lines[1:])
+	}
+
+	lines = profileExport(t, "generic", taxStatements())
+	if lines[0] != "date,sent,received,fee,currency,txHash" || !strings.HasPrefix(lines[2], "2023-11-14T22:16:40Z,2,,,DAI,") {
+		t.Errorf("unexpected generic export %q", lines)
+	}
+}
+
+func TestExportProfileSelection(t *testing.T) {
+	payload := &types.Payload{Collection: "exports", DataFacet: ExportsStatements}
+	if profiles := types.GetExportProfiles(payload); len(profiles) != 3 || profiles[0].Name != "koinly" {
+		t.Errorf("unexpected statement profiles %v", profiles)
+	}
+
+	payload = &types.Payload{
+		Collection:  "exports",
+		DataFacet:   ExportsLogs,
+		Profile:     "koinly",
+		ProjectPath: filepath.Join(t.TempDir(), "test.tbx"),
+	}
+	if _, err := types.ExportData([]Log{}, payload, "logs"); err == nil {
+		t.Error("expected an error exporting logs with a statements profile")
+	}
+	payload.Profile = "nope"
+	if _, err := types.ExportData([]Log{}, payload, "logs"); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+
+	// A profile's columns win over the payload's
+	payload = &types.Payload{
+		Collection:  "exports",
+		DataFacet:   ExportsStatements,
+		Format:      "koinly",
+		Columns:     []string{"date", "asset"},
+		ProjectPath: filepath.Join(t.TempDir(), "test.tbx"),
+	}
+	if !types.SelectsExportProfile(payload) {
+		t.Error("expected a profile format to select the profile")
+	}
+	path, err := types.ExportData(taxStatements(), payload, "statements")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); !strings.HasPrefix(string(data), "Date,Sent Amount,") {
+		t.Errorf("expected the profile's columns, got %q", strings.SplitN(string(data), "\n", 2)[0])
+	}
+}
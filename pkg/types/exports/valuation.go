@@ -0,0 +1,174 @@
+package exports
+
+import (
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/pricing"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// statementValuation values a statement's ending balance. Chifra's own USD spot price is
+// used when the configured source has no price.
+func statementValuation(chain string, s *Statement) pricing.Valuation {
+	req := pricing.Request{
+		Chain:       chain,
+		Asset:       s.Asset,
+		Symbol:      s.Symbol,
+		BlockNumber: s.BlockNumber,
+		Timestamp:   int64(s.Timestamp),
+	}
+	decimals := statementDecimals(s)
+	v := pricing.Valuate(req, &s.EndBal, decimals)
+	if !v.Priced && s.SpotPrice.Float64() > 0 && pricing.Currency() == pricing.DefaultCurrency {
+		q := pricing.Quote{Price: s.SpotPrice.Float64(), Currency: pricing.DefaultCurrency, Source: s.PriceSource}
+		v = pricing.Valuation{
+			Currency: q.Currency,
+			Price:    q.Price,
+			Value:    pricing.Value(&s.EndBal, decimals, q),
+			Source:   q.Source,
+			Priced:   true,
+		}
+	}
+	return v
+}
+
+// balanceValuation values a token balance
+func balanceValuation(chain string, b *Balance) pricing.Valuation {
+	req := pricing.Request{
+		Chain:       chain,
+		Asset:       b.Address,
+		Symbol:      b.Symbol,
+		BlockNumber: b.BlockNumber,
+		Timestamp:   int64(b.Timestamp),
+	}
+	return pricing.Valuate(req, &b.Balance, int(b.Decimals))
+}
+
+// approvalValuation values an open approval's exposure: the allowance, capped at the
+// owner's balance of the token. The balance also gives the token's decimals, so approvals
+// of tokens the owner is not known to hold are left unpriced.
+func approvalValuation(chain string, a *OpenApproval, holdings map[base.Address]*Balance) pricing.Valuation {
+	held, ok := holdings[a.Token]
+	if !ok {
+		return pricing.Valuation{Currency: pricing.Currency()}
+	}
+	req := pricing.Request{
+		Chain:       chain,
+		Asset:       a.Token,
+		Symbol:      held.Symbol,
+		BlockNumber: a.BlockNumber,
+		Timestamp:   int64(a.Timestamp),
+	}
+	exposure := &a.Allowance
+	if held.Balance.Cmp(exposure) < 0 {
+		exposure = &held.Balance
+	}
+	return pricing.Valuate(req, exposure, int(held.Decimals))
+}
+
+func statementDecimals(s *Statement) int {
+	if s.Decimals > 0 {
+		return int(s.Decimals)
+	}
+	return 18
+}
+
+// valuePage attaches a fiat valuation to each row of a statements, balances or open
+// approvals page. Values line up with the page's rows by index.
+func (c *ExportsCollection) valuePage(page *ExportsPage, payload *types.Payload) {
+	if !pricing.Enabled() {
+		return
+	}
+
+	chain := payload.ActiveChain
+	switch page.Facet {
+	case ExportsStatements:
+		page.Values = make([]pricing.Valuation, len(page.Statements))
+		for i := range page.Statements {
+			page.Values[i] = statementValuation(chain, &page.Statements[i])
+		}
+	case ExportsBalances:
+		page.Values = make([]pricing.Valuation, len(page.Balances))
+		for i := range page.Balances {
+			page.Values[i] = balanceValuation(chain, &page.Balances[i])
+		}
+	case ExportsOpenApprovals:
+		holdings := c.holdings()
+		page.Values = make([]pricing.Valuation, len(page.OpenApprovals))
+		for i := range page.OpenApprovals {
+			page.Values[i] = approvalValuation(chain, &page.OpenApprovals[i], holdings)
+		}
+	}
+}
+
+// holdings are the collection's token balances by token
+func (c *ExportsCollection) holdings() map[base.Address]*Balance {
+	ret := make(map[base.Address]*Balance)
+	for _, b := range c.balancesFacet.GetStore().GetItems(false) {
+		ret[b.Address] = b
+	}
+	return ret
+}
+
+// fiatColumns are the columns an export of a valued facet adds to its rows
+var fiatColumns = []string{"fiatPrice", "fiatValue"}
+
+// fiatValues returns a valuation's export columns, empty if it is unpriced
+func fiatValues(v pricing.Valuation) map[string]any {
+	if !v.Priced {
+		return nil
+	}
+	return map[string]any{"fiatPrice": v.Price, "fiatValue": v.Value}
+}
+
+// exportValuation values the rows of an export of a statements, balances or open approvals
+// facet the same way valuePage values them on screen
+func exportValuation(payload *types.Payload) types.ExportValuesFunc {
+	if !pricing.Enabled() {
+		return nil
+	}
+	chain := payload.ActiveChain
+	switch payload.DataFacet {
+	case ExportsStatements:
+		return func(item any) map[string]any {
+			return fiatValues(statementValuation(chain, item.(*Statement)))
+		}
+	case ExportsBalances:
+		return func(item any) map[string]any {
+			return fiatValues(balanceValuation(chain, item.(*Balance)))
+		}
+	case ExportsOpenApprovals:
+		holdings := GetExportsCollection(payload).holdings()
+		return func(item any) map[string]any {
+			return fiatValues(approvalValuation(chain, item.(*OpenApproval), holdings))
+		}
+	}
+	return nil
+}
+
+func init() {
+	for _, facet := range []types.DataFacet{ExportsStatements, ExportsBalances, ExportsOpenApprovals} {
+		types.RegisterExportValues("exports", facet, fiatColumns, exportValuation)
+	}
+
+	RegisterChartMetric(ChartMetric{
+		Key:     "endBalFiat",
+		Label:   "Ending Balance (Fiat)",
+		Enabled: pricing.Enabled,
+		Update: func(bucket *types.Bucket, s *Statement, decimals int, state *BucketState) {
+			bucket.Total = statementValuation(state.Chain, s).Value // absolute, not cumulative
+		},
+	})
+	RegisterChartMetric(ChartMetric{
+		Key:     "volumeFiat",
+		Label:   "Volume (Fiat)",
+		Enabled: pricing.Enabled,
+		Update: func(bucket *types.Bucket, s *Statement, decimals int, state *BucketState) {
+			v := statementValuation(state.Chain, s)
+			if v.Priced {
+				bucket.Total += pricing.Value(&s.AmountIn, decimals, pricing.Quote{Price: v.Price}) +
+					pricing.Value(&s.AmountOut, decimals, pricing.Quote{Price: v.Price})
+			}
+		},
+	})
+}
@@ -0,0 +1,145 @@
+package exports
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/pricing"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// fixedSource prices every asset in its map at a fixed price
+type fixedSource map[base.Address]float64
+
+func (s fixedSource) Name() string {
+	return "fixed"
+}
+
+func (s fixedSource) Quote(req pricing.Request) (pricing.Quote, error) {
+	if price, ok := s[req.Asset]; ok {
+		return pricing.Quote{Price: price, Source: s.Name()}, nil
+	}
+	return pricing.Quote{}, pricing.ErrNoPrice
+}
+
+func tokens(n int64) base.Wei {
+	w := new(big.Int).Mul(big.NewInt(n), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	return base.Wei(*w)
+}
+
+func TestStatementValuation(t *testing.T) {
+	defer pricing.Configure(nil, "")
+	dai := base.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+	weth := base.HexToAddress("0xc02aaa39b223fe8d0a0e5c4f27ead083c756cc2")
+	pricing.Configure(fixedSource{dai: 1.5}, "usd")
+
+	s := &Statement{Asset: dai, Decimals: 18, EndBal: tokens(4)}
+	if v := statementValuation("mainnet", s); !v.Priced || v.Value != 6 || v.Currency != "USD" || v.Source != "fixed" {
+		t.Errorf("unexpected valuation %+v", v)
+	}
+
+	// Falls back to chifra's spot price for assets the source cannot price
+	s = &Statement{Asset: weth, EndBal: tokens(2), PriceSource: "uniswap"}
+	s.SpotPrice = *base.NewFloat(2000)
+	if v := statementValuation("mainnet", s); !v.Priced || v.Value != 4000 || v.Source != "uniswap" {
+		t.Errorf("unexpected spot price valuation %+v", v)
+	}
+
+	// ...but only when the requested currency is USD
+	pricing.Configure(fixedSource{}, "EUR")
+	if v := statementValuation("mainnet", s); v.Priced {
+		t.Errorf("expected no EUR valuation from a USD spot price, got %+v", v)
+	}
+}
+
+func TestApprovalValuation(t *testing.T) {
+	defer pricing.Configure(nil, "")
+	token := base.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+	pricing.Configure(fixedSource{token: 2}, "USD")
+
+	unlimited := base.Wei(*new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)))
+	testCases := []struct {
+		name      string
+		allowance base.Wei
+		holdings  map[base.Address]*Balance
+		value     float64
+		priced    bool
+	}{
+		{"allowance below balance", tokens(3), map[base.Address]*Balance{token: {Address: token, Balance: tokens(10), Decimals: 18}}, 6, true},
+		{"allowance capped at balance", tokens(30), map[base.Address]*Balance{token: {Address: token, Balance: tokens(10), Decimals: 18}}, 20, true},
+		{"unlimited capped at balance", unlimited, map[base.Address]*Balance{token: {Address: token, Balance: tokens(1), Decimals: 18}}, 2, true},
+		{"six decimal token", base.Wei(*big.NewInt(5_000_000)), map[base.Address]*Balance{token: {Address: token, Balance: base.Wei(*big.NewInt(8_000_000)), Decimals: 6}}, 10, true},
+		{"limited without balance", tokens(5), nil, 0, false},
+		{"unlimited without balance", unlimited, nil, 0, false},
+	}
+	for _, tc := range testCases {
+		a := &OpenApproval{Token: token, Allowance: tc.allowance}
+		v := approvalValuation("mainnet", a, tc.holdings)
+		if v.Priced != tc.priced || v.Value != tc.value {
+			t.Errorf("%s: unexpected valuation %+v", tc.name, v)
+		}
+	}
+}
+
+func TestFiatChartMetricsNeedPricing(t *testing.T) {
+	defer pricing.Configure(nil, "")
+	config := *getAssetChartsFacetConfig()
+
+	has := func(key string) bool {
+		for _, metric := range activeChartMetrics(config) {
+			if metric.Key == key {
+				return true
+			}
+		}
+		return false
+	}
+
+	if has("endBalFiat") || has("volumeFiat") || !has("endBal") {
+		t.Error("expected only non-fiat metrics without a price source")
+	}
+	pricing.Configure(fixedSource{}, "USD")
+	if !has("endBalFiat") || !has("volumeFiat") {
+		t.Error("expected fiat metrics once a price source is configured")
+	}
+}
+
+func TestValuePage(t *testing.T) {
+	defer pricing.Configure(nil, "")
+	token := base.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+	c := NewExportsCollection(&types.Payload{Collection: "exports", ActiveChain: "mainnet", ActiveAddress: "0x1111111111111111111111111111111111111111"})
+	page := &ExportsPage{
+		Facet:    ExportsBalances,
+		Balances: []Balance{{Address: token, Balance: tokens(2), Decimals: 18}, {Balance: tokens(1), Decimals: 18}},
+	}
+
+	c.valuePage(page, &types.Payload{ActiveChain: "mainnet"})
+	if page.Values != nil {
+		t.Error("expected no values without a price source")
+	}
+
+	pricing.Configure(fixedSource{token: 3}, "USD")
+	c.valuePage(page, &types.Payload{ActiveChain: "mainnet"})
+	if len(page.Values) != 2 || page.Values[0].Value != 6 || page.Values[1].Priced {
+		t.Errorf("expected values aligned with rows, got %+v", page.Values)
+	}
+}
+
+func TestExportValuation(t *testing.T) {
+	defer pricing.Configure(nil, "")
+	token := base.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+	payload := &types.Payload{Collection: "exports", DataFacet: ExportsBalances, ActiveChain: "mainnet"}
+	if exportValuation(payload) != nil {
+		t.Error("expected no export values without a price source")
+	}
+
+	pricing.Configure(fixedSource{token: 3}, "USD")
+	values := exportValuation(payload)
+	if got := values(&Balance{Address: token, Balance: tokens(2), Decimals: 18}); got["fiatPrice"] != 3.0 || got["fiatValue"] != 6.0 {
+		t.Errorf("unexpected export values %v", got)
+	}
+	if got := values(&Balance{Balance: tokens(1), Decimals: 18}); got != nil {
+		t.Errorf("expected no values for an unpriced row, got %v", got)
+	}
+}
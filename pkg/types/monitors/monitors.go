@@ -182,12 +182,12 @@ func (c *MonitorsCollection) ResetSummary() {
 	}
 }
 
-func (c *MonitorsCollection) ExportData(payload *types.Payload) (string, error) {
+func (c *MonitorsCollection) ExportData(payload *types.Payload) (string, int, error) {
 	switch payload.DataFacet {
 	case MonitorsMonitors:
 		return c.monitorsFacet.ExportData(payload, string(MonitorsMonitors))
 	default:
-		return "", fmt.Errorf("[ExportData] unsupported monitors facet: %s", payload.DataFacet)
+		return "", 0, fmt.Errorf("[ExportData] unsupported monitors facet: %s", payload.DataFacet)
 	}
 }
 
@@ -88,6 +88,8 @@ func (c *MonitorsCollection) GetPage(
 			fmt.Errorf("[GetPage] unsupported dataFacet: %v", payload.DataFacet))
 	}
 
+	// EXISTING_CODE
+	// EXISTING_CODE
 	return page, nil
 }
 
@@ -96,6 +96,10 @@ func (c *MonitorsCollection) getStoreName(payload *types.Payload, facet types.Da
 		return ""
 	}
 	name = fmt.Sprintf("%s-%s-%s", name, payload.ActiveChain, payload.ActiveAddress)
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
 	return name
 }
 
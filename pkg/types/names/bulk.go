@@ -0,0 +1,307 @@
+package names
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/msgs"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/crud"
+)
+
+// ImportStatus says how an imported name compares to the names database
+type ImportStatus string
+
+const (
+	ImportAdd       ImportStatus = "add"
+	ImportUpdate    ImportStatus = "update"
+	ImportConflict  ImportStatus = "conflict"
+	ImportUnchanged ImportStatus = "unchanged"
+	ImportInvalid   ImportStatus = "invalid"
+)
+
+// ImportRow is a name read from an import file along with what importing it would do. Apply
+// is the user's choice for the row; it defaults to true for additions and updates only.
+type ImportRow struct {
+	Line     int          `json:"line"`
+	Address  string       `json:"address"`
+	Name     string       `json:"name"`
+	Tags     string       `json:"tags"`
+	Source   string       `json:"source"`
+	Status   ImportStatus `json:"status"`
+	Reason   string       `json:"reason,omitempty"`
+	Existing *Name        `json:"existing,omitempty"`
+	Apply    bool         `json:"apply"`
+}
+
+// ImportReport counts what importing a set of rows did
+type ImportReport struct {
+	Added   int `json:"added"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+// bulkColumns are the columns of an import or export file, in their default order
+var bulkColumns = []string{"address", "name", "tags", "source"}
+
+// bulkEntry is a name in a JSON import or export file
+type bulkEntry struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+	Tags    string `json:"tags"`
+	Source  string `json:"source"`
+}
+
+// ReadNamesFile reads the names in a CSV or JSON file. JSON files hold an array of objects, or
+// an object with the array in its data field as chifra writes it. A CSV file's first line
+// names its columns if it has an address column; otherwise the columns are address, name,
+// tags and source.
+func ReadNamesFile(path string) ([]*ImportRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return readNamesJSON(f)
+	}
+	return readNamesCSV(f)
+}
+
+func readNamesCSV(r io.Reader) ([]*ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	columns := map[string]int{}
+	for i, col := range bulkColumns {
+		columns[col] = i
+	}
+	field := func(record []string, col string) string {
+		if i, ok := columns[col]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []*ImportRow
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if first {
+			header := map[string]int{}
+			for i, name := range record {
+				header[strings.ToLower(strings.TrimSpace(name))] = i
+			}
+			if _, ok := header["address"]; ok {
+				columns = header
+				continue
+			}
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, &ImportRow{
+			Line:    line,
+			Address: field(record, "address"),
+			Name:    field(record, "name"),
+			Tags:    field(record, "tags"),
+			Source:  field(record, "source"),
+		})
+	}
+}
+
+func readNamesJSON(r io.Reader) ([]*ImportRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var entries []bulkEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		var wrapped struct {
+			Data []bulkEntry `json:"data"`
+		}
+		if err2 := json.Unmarshal(data, &wrapped); err2 != nil || wrapped.Data == nil {
+			return nil, fmt.Errorf("not a list of names: %w", err)
+		}
+		entries = wrapped.Data
+	}
+
+	rows := make([]*ImportRow, 0, len(entries))
+	for i, e := range entries {
+		rows = append(rows, &ImportRow{
+			Line:    i + 1,
+			Address: strings.TrimSpace(e.Address),
+			Name:    strings.TrimSpace(e.Name),
+			Tags:    strings.TrimSpace(e.Tags),
+			Source:  strings.TrimSpace(e.Source),
+		})
+	}
+	return rows, nil
+}
+
+// PreviewImport sets each row's status against the names lookup finds. A row that renames an
+// existing entry is a conflict and is not applied unless the user chooses to. Empty tags
+// or source keep the existing entry's values.
+func PreviewImport(rows []*ImportRow, lookup func(base.Address) (*Name, bool)) {
+	seen := map[base.Address]int{}
+	for _, row := range rows {
+		row.Status, row.Reason, row.Existing, row.Apply = "", "", nil, false
+		if !base.IsValidAddress(row.Address) || len(row.Address) != 42 {
+			row.Status, row.Reason = ImportInvalid, "not an address"
+			continue
+		}
+		if row.Name == "" {
+			row.Status, row.Reason = ImportInvalid, "no name"
+			continue
+		}
+		address := base.HexToAddress(row.Address)
+		if line, ok := seen[address]; ok {
+			row.Status, row.Reason = ImportInvalid, fmt.Sprintf("duplicates line %d", line)
+			continue
+		}
+		seen[address] = row.Line
+
+		existing, found := lookup(address)
+		if !found || existing == nil {
+			row.Status, row.Apply = ImportAdd, true
+			continue
+		}
+		copied := *existing
+		row.Existing = &copied
+		merged := row.merged()
+		switch {
+		case existing.Name != row.Name:
+			row.Status, row.Reason = ImportConflict, fmt.Sprintf("named %q", existing.Name)
+		case existing.IsCustom && merged.Tags == existing.Tags && merged.Source == existing.Source:
+			row.Status = ImportUnchanged
+		default:
+			row.Status, row.Apply = ImportUpdate, true
+		}
+	}
+}
+
+// merged is the name the row would write, keeping the existing entry's other fields
+func (row *ImportRow) merged() *Name {
+	name := &Name{}
+	if row.Existing != nil {
+		*name = *row.Existing
+	}
+	name.Address = base.HexToAddress(row.Address)
+	name.Name = row.Name
+	if row.Tags != "" {
+		name.Tags = row.Tags
+	}
+	if row.Source != "" {
+		name.Source = row.Source
+	}
+	name.IsCustom = true
+	return name
+}
+
+// ImportNames writes the rows the user chose to apply to the custom names database
+func (c *NamesCollection) ImportNames(payload *types.Payload, rows []*ImportRow) (*ImportReport, error) {
+	report := &ImportReport{}
+	customPayload := *payload
+	customPayload.DataFacet = NamesCustom
+	for _, row := range rows {
+		if !row.Apply || row.Status == ImportInvalid || row.Status == ImportUnchanged {
+			report.Skipped++
+			continue
+		}
+		op := crud.Create
+		if row.Existing != nil && row.Existing.IsCustom {
+			op = crud.Update
+		}
+		if err := c.Crud(&customPayload, op, row.merged()); err != nil {
+			report.Failed++
+			continue
+		}
+		if row.Existing != nil {
+			report.Updated++
+		} else {
+			report.Added++
+		}
+	}
+
+	c.resetFacets()
+	msgs.EmitStatus(fmt.Sprintf("imported names: %d added, %d updated, %d skipped, %d failed",
+		report.Added, report.Updated, report.Skipped, report.Failed))
+	return report, nil
+}
+
+// CustomNames returns the names in the custom names database sorted by address
+func CustomNames() []*Name {
+	if !ensureLoadedSync() {
+		return nil
+	}
+	store := namesStore[getStoreKey(&types.Payload{})]
+	if store == nil {
+		return nil
+	}
+	var ret []*Name
+	for _, name := range store.GetItems(false) {
+		if isCustom(name) || name.IsCustom {
+			ret = append(ret, name)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Address.Hex() < ret[j].Address.Hex()
+	})
+	return ret
+}
+
+// ExportCustomNames writes the custom names to path as JSON if it ends in .json and as CSV
+// otherwise
+func ExportCustomNames(path string) error {
+	format := "csv"
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		format = "json"
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := WriteNames(f, format, CustomNames()); err != nil {
+		return err
+	}
+	msgs.EmitStatus(fmt.Sprintf("exported custom names to %s", path))
+	return nil
+}
+
+// WriteNames writes names as CSV or JSON with the columns ReadNamesFile reads
+func WriteNames(w io.Writer, format string, names []*Name) error {
+	switch format {
+	case "json":
+		entries := make([]bulkEntry, 0, len(names))
+		for _, name := range names {
+			entries = append(entries, bulkEntry{name.Address.Hex(), name.Name, name.Tags, name.Source})
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	case "csv":
+		writer := csv.NewWriter(w)
+		_ = writer.Write(bulkColumns)
+		for _, name := range names {
+			_ = writer.Write([]string{name.Address.Hex(), name.Name, name.Tags, name.Source})
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("names cannot be written as %s", format)
+	}
+}
@@ -0,0 +1,135 @@
+package names
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	vitalik = "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"
+	uniswap = "0x1f9840a85d5af5bf1d1762f925bdaddc4201f984"
+	tornado = "0x722122df12d4e14e13ac3b6895a86e84145b6967"
+)
+
+func TestReadNamesFile(t *testing.T) {
+	t.Run("CSVWithHeader", func(t *testing.T) {
+		csv := "Source,Name,Address\nclient list,Vitalik,  " + vitalik + "\n\n\"crm, export\",\"Uniswap, UNI\"," + uniswap + "\n"
+		rows, err := readNamesCSV(strings.NewReader(csv))
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, ImportRow{Line: 2, Address: vitalik, Name: "Vitalik", Source: "client list"}, *rows[0])
+		assert.Equal(t, "Uniswap, UNI", rows[1].Name)
+		assert.Equal(t, "crm, export", rows[1].Source)
+		assert.Equal(t, 4, rows[1].Line)
+	})
+
+	t.Run("CSVWithoutHeader", func(t *testing.T) {
+		rows, err := readNamesCSV(strings.NewReader(vitalik + ",Vitalik,30-Contracts:Individuals\n" + uniswap + ",Uniswap"))
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, "30-Contracts:Individuals", rows[0].Tags)
+		assert.Equal(t, "Uniswap", rows[1].Name)
+		assert.Empty(t, rows[1].Tags)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		dir := t.TempDir()
+		plain := filepath.Join(dir, "plain.json")
+		require.NoError(t, os.WriteFile(plain, []byte(`[{"address":"`+vitalik+`","name":"Vitalik","tags":"friends"}]`), 0644))
+		rows, err := ReadNamesFile(plain)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "friends", rows[0].Tags)
+
+		wrapped := filepath.Join(dir, "chifra.JSON")
+		require.NoError(t, os.WriteFile(wrapped, []byte(`{"data":[{"address":"`+uniswap+`","name":"Uniswap"}]}`), 0644))
+		rows, err = ReadNamesFile(wrapped)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "Uniswap", rows[0].Name)
+
+		bad := filepath.Join(dir, "bad.json")
+		require.NoError(t, os.WriteFile(bad, []byte(`{"name":"nope"}`), 0644))
+		_, err = ReadNamesFile(bad)
+		assert.Error(t, err)
+	})
+}
+
+func TestPreviewImport(t *testing.T) {
+	known := map[base.Address]*Name{
+		base.HexToAddress(uniswap): {Address: base.HexToAddress(uniswap), Name: "Uniswap", Tags: "50-Tokens:ERC20", Source: "EtherScan.io", Symbol: "UNI", Decimals: 18},
+		base.HexToAddress(tornado): {Address: base.HexToAddress(tornado), Name: "Tornado Cash", Tags: "sanctioned", IsCustom: true},
+	}
+	lookup := func(address base.Address) (*Name, bool) {
+		name, ok := known[address]
+		return name, ok
+	}
+
+	rows := []*ImportRow{
+		{Line: 1, Address: vitalik, Name: "Vitalik"},
+		{Line: 2, Address: uniswap, Name: "Uniswap", Tags: "counterparty"},
+		{Line: 3, Address: tornado, Name: "Tornado Router"},
+		{Line: 4, Address: tornado, Name: "Tornado Cash"},
+		{Line: 5, Address: "0x1234", Name: "Short"},
+		{Line: 6, Address: "vitalik.eth", Name: "Ens"},
+		{Line: 7, Address: vitalik, Name: "Vitalik Again"},
+		{Line: 8, Address: uniswap},
+	}
+	PreviewImport(rows, lookup)
+
+	statuses := make([]ImportStatus, len(rows))
+	applied := make([]bool, len(rows))
+	for i, row := range rows {
+		statuses[i], applied[i] = row.Status, row.Apply
+	}
+	assert.Equal(t, []ImportStatus{ImportAdd, ImportUpdate, ImportConflict, ImportInvalid, ImportInvalid, ImportInvalid, ImportInvalid, ImportInvalid}, statuses)
+	assert.Equal(t, []bool{true, true, false, false, false, false, false, false}, applied)
+	assert.Equal(t, `named "Tornado Cash"`, rows[2].Reason)
+	assert.Equal(t, "duplicates line 3", rows[3].Reason)
+	assert.Equal(t, "duplicates line 1", rows[6].Reason)
+	assert.Equal(t, "no name", rows[7].Reason)
+
+	merged := rows[1].merged()
+	assert.Equal(t, "counterparty", merged.Tags)
+	assert.Equal(t, "EtherScan.io", merged.Source, "an empty source keeps the existing one")
+	assert.Equal(t, "UNI", merged.Symbol)
+	assert.True(t, merged.IsCustom)
+	assert.False(t, known[base.HexToAddress(uniswap)].IsCustom, "the existing entry is not changed")
+
+	unchanged := []*ImportRow{{Line: 1, Address: tornado, Name: "Tornado Cash", Tags: "sanctioned"}}
+	PreviewImport(unchanged, lookup)
+	assert.Equal(t, ImportUnchanged, unchanged[0].Status)
+	assert.False(t, unchanged[0].Apply)
+}
+
+func TestWriteNames(t *testing.T) {
+	names := []*Name{
+		{Address: base.HexToAddress(vitalik), Name: "Vitalik", Tags: "friends"},
+		{Address: base.HexToAddress(uniswap), Name: "Uniswap, UNI", Source: "crm"},
+	}
+	dir := t.TempDir()
+	for _, format := range []string{"csv", "json"} {
+		var buf bytes.Buffer
+		require.NoError(t, WriteNames(&buf, format, names), format)
+		path := filepath.Join(dir, "names."+format)
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+
+		rows, err := ReadNamesFile(path)
+		require.NoError(t, err, format)
+		require.Len(t, rows, 2, format)
+		for i, row := range rows {
+			assert.Equal(t, names[i].Address, base.HexToAddress(row.Address), format)
+			assert.Equal(t, names[i].Name, row.Name, format)
+			assert.Equal(t, names[i].Tags, row.Tags, format)
+			assert.Equal(t, names[i].Source, row.Source, format)
+		}
+	}
+	assert.Error(t, WriteNames(&bytes.Buffer{}, "xlsx", names))
+}
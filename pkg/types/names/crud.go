@@ -50,18 +50,7 @@ func AutonameAddress(address string) error {
 		DataFacet:  NamesCustom, // Use custom facet as it's most commonly used for autoname
 	}
 	collection := GetNamesCollection(payload)
-
-	reset := func(c *NamesCollection, f types.DataFacet) {
-		payload := &types.Payload{DataFacet: f}
-		c.Reset(payload)
-	}
-	// Reset all facets to ensure consistency across all Names views
-	reset(collection, NamesAll)
-	reset(collection, NamesCustom)
-	reset(collection, NamesRegular)
-	// Note: Prefund and Baddress typically don't change with autoname, but reset for consistency
-	reset(collection, NamesPrefund)
-	reset(collection, NamesBaddress)
+	collection.resetFacets()
 
 	msgs.EmitStatus(fmt.Sprintf("completed autoname operation for address: %s", address))
 	return nil
@@ -150,6 +139,13 @@ func (c *NamesCollection) Crud(
 	return nil
 }
 
+// resetFacets resets all facets to ensure consistency across all Names views
+func (c *NamesCollection) resetFacets() {
+	for _, facet := range []types.DataFacet{NamesAll, NamesCustom, NamesRegular, NamesPrefund, NamesBaddress} {
+		c.Reset(&types.Payload{DataFacet: facet})
+	}
+}
+
 // updateNameInData handles the in-memory data update logic for all CRUD operations
 func (c *NamesCollection) updateNameInData(data []*Name, name *Name, op crud.Operation) []*Name {
 	switch op {
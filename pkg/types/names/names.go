@@ -270,7 +270,7 @@ func (c *NamesCollection) ResetSummary() {
 	}
 }
 
-func (c *NamesCollection) ExportData(payload *types.Payload) (string, error) {
+func (c *NamesCollection) ExportData(payload *types.Payload) (string, int, error) {
 	switch payload.DataFacet {
 	case NamesAll:
 		return c.allFacet.ExportData(payload, string(NamesAll))
@@ -283,7 +283,7 @@ func (c *NamesCollection) ExportData(payload *types.Payload) (string, error) {
 	case NamesBaddress:
 		return c.baddressFacet.ExportData(payload, string(NamesBaddress))
 	default:
-		return "", fmt.Errorf("[ExportData] unsupported names facet: %s", payload.DataFacet)
+		return "", 0, fmt.Errorf("[ExportData] unsupported names facet: %s", payload.DataFacet)
 	}
 }
 
@@ -0,0 +1,61 @@
+package names
+
+import (
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/project"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+)
+
+// Overlay holds private labels that take precedence over the names database, usually the
+// active project's
+type Overlay interface {
+	GetLabel(address base.Address) (project.Label, bool)
+}
+
+var (
+	overlay   func() Overlay
+	overlayMu sync.RWMutex
+)
+
+// ConfigureOverlay sets how the current overlay is found. The function may return nil when
+// there is none, such as before a project is open.
+func ConfigureOverlay(get func() Overlay) {
+	overlayMu.Lock()
+	defer overlayMu.Unlock()
+	overlay = get
+}
+
+func getOverlay() Overlay {
+	overlayMu.RLock()
+	defer overlayMu.RUnlock()
+	if overlay == nil {
+		return nil
+	}
+	return overlay()
+}
+
+// labelName is the overlay's label for an address as a name. The shared name, if there is
+// one, supplies the fields a label does not have.
+func labelName(address base.Address, shared *Name) (*Name, bool) {
+	o := getOverlay()
+	if o == nil {
+		return nil, false
+	}
+	label, ok := o.GetLabel(address)
+	if !ok {
+		return nil, false
+	}
+	ret := &Name{}
+	if shared != nil {
+		*ret = *shared
+	}
+	ret.Address = address
+	ret.Name = label.Name
+	if label.Tags != "" {
+		ret.Tags = label.Tags
+	}
+	ret.Source = "project"
+	return ret, true
+}
@@ -0,0 +1,44 @@
+package names
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/project"
+
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelName(t *testing.T) {
+	cold := base.HexToAddress(vitalik)
+	token := base.HexToAddress(uniswap)
+	p := project.NewProject("client", base.ZeroAddr, []string{"mainnet"})
+	require.NoError(t, p.PutLabel(project.Label{Address: cold, Name: "client cold wallet 3"}))
+	require.NoError(t, p.PutLabel(project.Label{Address: token, Name: "client's UNI", Tags: "holdings"}))
+
+	defer ConfigureOverlay(nil)
+	ConfigureOverlay(nil)
+	_, ok := labelName(cold, nil)
+	assert.False(t, ok, "no overlay is configured")
+
+	ConfigureOverlay(func() Overlay { return nil })
+	_, ok = labelName(cold, nil)
+	assert.False(t, ok, "no project is open")
+
+	ConfigureOverlay(func() Overlay { return p })
+	name, ok := labelName(cold, nil)
+	require.True(t, ok)
+	assert.Equal(t, Name{Address: cold, Name: "client cold wallet 3", Source: "project"}, *name)
+
+	shared := &Name{Address: token, Name: "Uniswap", Tags: "50-Tokens:ERC20", Symbol: "UNI", Decimals: 18, IsCustom: true}
+	name, ok = labelName(token, shared)
+	require.True(t, ok)
+	assert.Equal(t, "client's UNI", name.Name)
+	assert.Equal(t, "holdings", name.Tags)
+	assert.Equal(t, "UNI", name.Symbol, "the shared name fills in what the label lacks")
+	assert.Equal(t, "Uniswap", shared.Name, "the shared name is not changed")
+
+	_, ok = labelName(base.HexToAddress(tornado), nil)
+	assert.False(t, ok)
+}
@@ -164,6 +164,8 @@ func (c *NamesCollection) GetPage(
 			fmt.Errorf("[GetPage] unsupported dataFacet: %v", payload.DataFacet))
 	}
 
+	// EXISTING_CODE
+	// EXISTING_CODE
 	return page, nil
 }
 
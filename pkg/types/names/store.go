@@ -105,6 +105,10 @@ func (c *NamesCollection) getStoreName(payload *types.Payload, facet types.DataF
 		return ""
 	}
 	name = fmt.Sprintf("%s-%s-%s", name, payload.ActiveChain, payload.ActiveAddress)
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
 	return name
 }
 
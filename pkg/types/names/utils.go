@@ -24,7 +24,19 @@ func NameAddressStr(address string) string {
 	return ""
 }
 
+// NameFromAddress returns the name of an address, looking in the active project's labels
+// before the names database
 func NameFromAddress(address base.Address) (*Name, bool) {
+	shared, found := SharedNameFromAddress(address)
+	if labeled, ok := labelName(address, shared); ok {
+		return labeled, true
+	}
+	return shared, found
+}
+
+// SharedNameFromAddress returns the name of an address in the names database, ignoring the
+// project's labels. Use it for anything written back to the database.
+func SharedNameFromAddress(address base.Address) (*Name, bool) {
 	if !ensureLoadedSync() {
 		return nil, false
 	}
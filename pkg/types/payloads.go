@@ -1,5 +1,9 @@
 package types
 
+import (
+	"fmt"
+)
+
 type Payload struct {
 	Collection       string    `json:"collection"`
 	DataFacet        DataFacet `json:"dataFacet"`
@@ -12,12 +16,39 @@ type Payload struct {
 	TargetSwitch     bool      `json:"targetSwitch,omitempty"`
 	Format           string    `json:"format,omitempty"`
 	ProjectPath      string    `json:"projectPath,omitempty"`
+	FirstBlock       uint64    `json:"firstBlock,omitempty"`
+	LastBlock        uint64    `json:"lastBlock,omitempty"`
+	FirstDate        string    `json:"firstDate,omitempty"` // YYYY-MM-DD, inclusive
+	LastDate         string    `json:"lastDate,omitempty"`  // YYYY-MM-DD, inclusive
+	Compress         bool      `json:"compress,omitempty"`  // gzip exported files
+	Profile          string    `json:"profile,omitempty"`   // export profile, see ExportProfile
+	Template         string    `json:"template,omitempty"`  // saved export template the columns come from
+	Columns          []string  `json:"columns,omitempty"`   // exported columns in order, empty for all
 }
 
 func (p *Payload) ShouldSummarize() bool {
 	return p.ActivePeriod != PeriodBlockly
 }
 
+// HasRange returns true if the payload limits queries to a block or date range
+func (p *Payload) HasRange() bool {
+	return p.FirstBlock != 0 || p.LastBlock != 0 || p.FirstDate != "" || p.LastDate != ""
+}
+
+// RangeKey identifies the payload's block and date range for use in store keys. It is
+// empty when the payload is unscoped so existing keys are unchanged. Dates resolve to
+// blocks in the reporting timezone, so it is part of the key whenever dates are set.
+func (p *Payload) RangeKey(timezone string) string {
+	if !p.HasRange() {
+		return ""
+	}
+	key := fmt.Sprintf("%d-%d_%s-%s", p.FirstBlock, p.LastBlock, p.FirstDate, p.LastDate)
+	if p.FirstDate != "" || p.LastDate != "" {
+		key = fmt.Sprintf("%s_%s", key, timezone)
+	}
+	return key
+}
+
 type DataLoadedPayload struct {
 	Payload
 	CurrentCount  int        `json:"currentCount"`
@@ -30,6 +61,16 @@ type DataLoadedPayload struct {
 	Operation     string     `json:"operation,omitempty"`
 }
 
+type ExportProgressPayload struct {
+	Payload
+	Path      string `json:"path"`
+	Written   int    `json:"written"`
+	Total     int    `json:"total"`
+	Done      bool   `json:"done"`
+	Cancelled bool   `json:"cancelled,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
 type ProjectPayload struct {
 	HasProject     bool                 `json:"hasProject"`
 	ActiveChain    string               `json:"activeChain"`
@@ -1,17 +1,24 @@
 package types
 
+import (
+	"fmt"
+	"time"
+)
+
 // Period represents different time aggregation levels
 type Period string
 
 // Period constants for different time aggregation levels
 const (
-	PeriodBlockly   Period = "blockly" // Default - no aggregation, raw data
-	PeriodHourly    Period = "hourly"
-	PeriodDaily     Period = "daily"
-	PeriodWeekly    Period = "weekly"
-	PeriodMonthly   Period = "monthly"
-	PeriodQuarterly Period = "quarterly"
-	PeriodAnnual    Period = "annual"
+	PeriodBlockly         Period = "blockly" // Default - no aggregation, raw data
+	PeriodHourly          Period = "hourly"
+	PeriodDaily           Period = "daily"
+	PeriodWeekly          Period = "weekly"
+	PeriodMonthly         Period = "monthly"
+	PeriodQuarterly       Period = "quarterly"
+	PeriodAnnual          Period = "annual"
+	PeriodFiscalQuarterly Period = "fiscalQuarterly"
+	PeriodFiscalAnnual    Period = "fiscalAnnual"
 )
 
 var AllPeriods = []struct {
@@ -25,4 +32,49 @@ var AllPeriods = []struct {
 	{PeriodMonthly, "MONTHLY"},
 	{PeriodQuarterly, "QUARTERLY"},
 	{PeriodAnnual, "ANNUAL"},
+	{PeriodFiscalQuarterly, "FISCAL_QUARTERLY"},
+	{PeriodFiscalAnnual, "FISCAL_ANNUAL"},
+}
+
+// PeriodConfig controls where period boundaries fall when aggregating by time. The zero
+// value means UTC, Sunday-start weeks and a January fiscal year.
+type PeriodConfig struct {
+	Timezone        string       `json:"timezone,omitempty"`        // IANA name such as "America/New_York"; empty means UTC
+	WeekStart       time.Weekday `json:"weekStart,omitempty"`       // 0 = Sunday ... 6 = Saturday
+	FiscalYearStart time.Month   `json:"fiscalYearStart,omitempty"` // 1 = January ... 12 = December; 0 means January
+}
+
+// Location returns the configured timezone, falling back to UTC if it is empty or unknown
+func (c PeriodConfig) Location() *time.Location {
+	if c.Timezone == "" {
+		return time.UTC
+	}
+	if loc, err := time.LoadLocation(c.Timezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// Validate reports whether the timezone, week start and fiscal month are usable
+func (c PeriodConfig) Validate() error {
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+		}
+	}
+	if c.WeekStart < time.Sunday || c.WeekStart > time.Saturday {
+		return fmt.Errorf("invalid week start day: %d", c.WeekStart)
+	}
+	if c.FiscalYearStart < 0 || c.FiscalYearStart > time.December {
+		return fmt.Errorf("invalid fiscal year start month: %d", c.FiscalYearStart)
+	}
+	return nil
+}
+
+// FiscalStartMonth returns the first month of the fiscal year, defaulting to January
+func (c PeriodConfig) FiscalStartMonth() time.Month {
+	if c.FiscalYearStart < time.January || c.FiscalYearStart > time.December {
+		return time.January
+	}
+	return c.FiscalYearStart
 }
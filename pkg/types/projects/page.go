@@ -112,6 +112,8 @@ func (c *ProjectsCollection) GetPage(
 		}
 	}
 
+	// EXISTING_CODE
+	// EXISTING_CODE
 	return page, nil
 }
 
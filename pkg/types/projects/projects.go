@@ -182,13 +182,13 @@ func (c *ProjectsCollection) ResetSummary() {
 	}
 }
 
-func (c *ProjectsCollection) ExportData(payload *types.Payload) (string, error) {
+func (c *ProjectsCollection) ExportData(payload *types.Payload) (string, int, error) {
 	switch payload.DataFacet {
 	case ProjectsManage:
 		return c.manageFacet.ExportData(payload, string(ProjectsManage))
 	default:
 		// TODO: Export dynamic facet data
-		return "", fmt.Errorf("[ExportData] unsupported projects facet: %s", payload.DataFacet)
+		return "", 0, fmt.Errorf("[ExportData] unsupported projects facet: %s", payload.DataFacet)
 	}
 }
 
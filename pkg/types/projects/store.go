@@ -184,6 +184,10 @@ func (c *ProjectsCollection) getStoreName(payload *types.Payload, facet types.Da
 		return ""
 	}
 	name = fmt.Sprintf("%s-%s-%s", name, payload.ActiveChain, payload.ActiveAddress)
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
 	return name
 }
 
@@ -57,6 +57,15 @@ func (c *StatusCollection) buildStaticFacets() map[string]types.FacetConfig {
 			Actions:       []string{},
 			HeaderActions: []string{"export"},
 		},
+		"metrics": {
+			Name:          "Metrics",
+			Store:         "metrics",
+			ViewType:      "table",
+			DividerBefore: true,
+			Fields:        getMetricsFields(),
+			Actions:       []string{},
+			HeaderActions: []string{"export"},
+		},
 	}
 }
 
@@ -65,6 +74,7 @@ func (c *StatusCollection) buildFacetOrder() []string {
 		"status",
 		"caches",
 		"chains",
+		"metrics",
 	}
 }
 
@@ -103,6 +113,27 @@ func getChainsFields() []types.FieldConfig {
 	return ret
 }
 
+func getMetricsFields() []types.FieldConfig {
+	ret := []types.FieldConfig{
+		{Section: "General", Key: "storeName", Type: "string"},
+		{Section: "General", Key: "state", Type: "string"},
+		{Section: "Throughput", Key: "fetches", Type: "int64"},
+		{Section: "Throughput", Key: "items", Type: "int64"},
+		{Section: "Throughput", Key: "lastDurationMs", Type: "int64"},
+		{Section: "Throughput", Key: "timeToFirstMs", Type: "int64"},
+		{Section: "Throughput", Key: "itemsPerSecond", Type: "float64"},
+		{Section: "Failures", Key: "errors", Type: "int64"},
+		{Section: "Failures", Key: "retries", Type: "int64"},
+		{Section: "Failures", Key: "cancellations", Type: "int64"},
+		{Section: "Failures", Key: "lastError", Type: "string", NoTable: true},
+		{Section: "Memory", Key: "memoryBytes", Type: "int64"},
+		{Section: "Timestamps", Key: "lastFetchedAt", Type: "timestamp"},
+		{Section: "", Key: "actions", Type: "actions", NoDetail: true},
+	}
+	types.NormalizeFields(&ret)
+	return ret
+}
+
 func getStatusFields() []types.FieldConfig {
 	ret := []types.FieldConfig{
 		{Section: "Paths", Key: "cachePath", Type: "path"},
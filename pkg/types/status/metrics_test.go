@@ -0,0 +1,54 @@
+// Copyright 2016, 2026 The Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package status
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/store"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
+	"github.com/TrueBlocks/trueblocks-chifra/v6/pkg/output"
+	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
+)
+
+// TestMetricsPageFollowsLaterFetches checks that a loaded metrics facet shows stores that fetch after it loaded
+func TestMetricsPageFollowsLaterFetches(t *testing.T) {
+	payload := &types.Payload{Collection: "status", DataFacet: StatusMetrics, ActiveChain: "mainnet"}
+	c := NewStatusCollection(payload)
+	t.Cleanup(func() {
+		cachesStoreMu.Lock()
+		cachesStore = make(map[string]*store.Store[Cache])
+		cachesStoreMu.Unlock()
+		statusStoreMu.Lock()
+		statusStore = make(map[string]*store.Store[Status])
+		statusStoreMu.Unlock()
+	})
+	if err := c.metricsFacet.GetStore().Fetch(); err != nil {
+		t.Fatalf("metrics fetch failed: %v", err)
+	}
+
+	later := store.NewStore("status-metrics-later-store",
+		func(ctx *output.RenderCtx) error {
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+			}()
+			return nil
+		},
+		func(item interface{}) *Status { return nil },
+		nil)
+	if err := later.Fetch(); err != nil {
+		t.Fatalf("later fetch failed: %v", err)
+	}
+
+	page, err := c.GetPage(payload, 0, 1000, sdk.SortSpec{}, "later-store")
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	metrics := page.(*StatusPage).Metrics
+	if len(metrics) != 1 || metrics[0].StoreName != "status-metrics-later-store" {
+		t.Errorf("expected the later store in the metrics page, got %+v", metrics)
+	}
+}
@@ -11,8 +11,11 @@ package status
 // EXISTING_CODE
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/facets"
+	"github.com/TrueBlocks/trueblocks-approvals/pkg/store"
 	"github.com/TrueBlocks/trueblocks-approvals/pkg/types"
 	sdk "github.com/TrueBlocks/trueblocks-sdk/v6"
 )
@@ -23,6 +26,7 @@ type StatusPage struct {
 	Facet         types.DataFacet  `json:"facet"`
 	Caches        []Cache          `json:"caches"`
 	Chains        []Chain          `json:"chains"`
+	Metrics       []Metric         `json:"metrics"`
 	Status        []Status         `json:"status"`
 	TotalItems    int              `json:"totalItems"`
 	ExpectedTotal int              `json:"expectedTotal"`
@@ -123,11 +127,33 @@ func (c *StatusCollection) GetPage(
 			page.State = result.State
 		}
 		page.ExpectedTotal = facet.ExpectedCount()
+	case StatusMetrics:
+		facet := c.metricsFacet
+		refreshMetrics(facet)
+		var filterFunc func(*Metric) bool
+		if filter != "" {
+			filterFunc = func(item *Metric) bool {
+				return c.matchesMetricFilter(item, filter)
+			}
+		}
+		sortFunc := func(items []Metric, sort sdk.SortSpec) error {
+			return sortMetrics(items, sort)
+		}
+		if result, err := facet.GetPage(first, pageSize, filterFunc, sortSpec, sortFunc); err != nil {
+			return nil, types.NewStoreError("status", dataFacet, "GetPage", err)
+		} else {
+			page.Metrics = result.Items
+			page.TotalItems = result.TotalItems
+			page.State = result.State
+		}
+		page.ExpectedTotal = facet.ExpectedCount()
 	default:
 		return nil, types.NewValidationError("status", payload.DataFacet, "GetPage",
 			fmt.Errorf("[GetPage] unsupported dataFacet: %v", payload.DataFacet))
 	}
 
+	// EXISTING_CODE
+	// EXISTING_CODE
 	return page, nil
 }
 
@@ -218,6 +244,75 @@ func (c *StatusCollection) matchesChainFilter(item *Chain, filter string) bool {
 	return true
 }
 
+func (c *StatusCollection) matchesMetricFilter(item *Metric, filter string) bool {
+	return strings.Contains(strings.ToLower(item.StoreName), filter) ||
+		strings.Contains(strings.ToLower(item.LastError), filter)
+}
+
+// refreshMetrics replaces a loaded metrics facet's rows with a fresh snapshot so the view
+// follows fetches that finished after the facet first loaded
+func refreshMetrics(facet *facets.Facet[Metric]) {
+	if !facet.IsLoaded() {
+		return
+	}
+	fresh := store.GetAllFetchMetrics()
+	facet.GetStore().UpdateData(func([]*Metric) []*Metric {
+		ret := make([]*Metric, len(fresh))
+		for i := range fresh {
+			ret[i] = &fresh[i]
+		}
+		return ret
+	})
+	facet.SyncWithStore()
+}
+
+// sortMetrics orders metrics rows by the first field of the sort spec, defaulting to store name
+func sortMetrics(items []Metric, spec sdk.SortSpec) error {
+	field := "storeName"
+	if len(spec.Fields) > 0 && spec.Fields[0] != "" {
+		field = spec.Fields[0]
+	}
+	desc := len(spec.Order) > 0 && spec.Order[0] == sdk.Dec
+
+	var less func(a, b *Metric) bool
+	switch field {
+	case "storeName":
+		less = func(a, b *Metric) bool { return a.StoreName < b.StoreName }
+	case "state":
+		less = func(a, b *Metric) bool { return a.State < b.State }
+	case "fetches":
+		less = func(a, b *Metric) bool { return a.Fetches < b.Fetches }
+	case "items":
+		less = func(a, b *Metric) bool { return a.Items < b.Items }
+	case "lastDurationMs":
+		less = func(a, b *Metric) bool { return a.LastDurationMs < b.LastDurationMs }
+	case "timeToFirstMs":
+		less = func(a, b *Metric) bool { return a.TimeToFirstMs < b.TimeToFirstMs }
+	case "itemsPerSecond":
+		less = func(a, b *Metric) bool { return a.ItemsPerSecond < b.ItemsPerSecond }
+	case "errors":
+		less = func(a, b *Metric) bool { return a.Errors < b.Errors }
+	case "retries":
+		less = func(a, b *Metric) bool { return a.Retries < b.Retries }
+	case "cancellations":
+		less = func(a, b *Metric) bool { return a.Cancellations < b.Cancellations }
+	case "memoryBytes":
+		less = func(a, b *Metric) bool { return a.MemoryBytes < b.MemoryBytes }
+	case "lastFetchedAt":
+		less = func(a, b *Metric) bool { return a.LastFetchedAt < b.LastFetchedAt }
+	default:
+		return fmt.Errorf("unsupported sort field for metrics: %s", field)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if desc {
+			return less(&items[j], &items[i])
+		}
+		return less(&items[i], &items[j])
+	})
+	return nil
+}
+
 func (c *StatusCollection) matchesStatusFilter(item *Status, filter string) bool {
 	_ = item
 	_ = filter
@@ -21,21 +21,24 @@ import (
 )
 
 const (
-	StatusStatus types.DataFacet = "status"
-	StatusCaches types.DataFacet = "caches"
-	StatusChains types.DataFacet = "chains"
+	StatusStatus  types.DataFacet = "status"
+	StatusCaches  types.DataFacet = "caches"
+	StatusChains  types.DataFacet = "chains"
+	StatusMetrics types.DataFacet = "metrics"
 )
 
 func init() {
 	types.RegisterDataFacet(StatusStatus)
 	types.RegisterDataFacet(StatusCaches)
 	types.RegisterDataFacet(StatusChains)
+	types.RegisterDataFacet(StatusMetrics)
 }
 
 type StatusCollection struct {
 	statusFacet  *facets.Facet[Status]
 	cachesFacet  *facets.Facet[Cache]
 	chainsFacet  *facets.Facet[Chain]
+	metricsFacet *facets.Facet[Metric]
 	summary      types.Summary
 	summaryMutex sync.RWMutex
 }
@@ -77,6 +80,16 @@ func (c *StatusCollection) initializeFacets(payload *types.Payload) {
 		c,
 		false,
 	)
+
+	c.metricsFacet = facets.NewFacet(
+		StatusMetrics,
+		isMetric,
+		isDupMetric(),
+		c.getMetricsStore(payload, StatusMetrics),
+		"status",
+		c,
+		false,
+	)
 }
 
 func isStatus(item *Status) bool {
@@ -97,6 +110,12 @@ func isChain(item *Chain) bool {
 	// EXISTING_CODE
 }
 
+func isMetric(item *Metric) bool {
+	// EXISTING_CODE
+	return true
+	// EXISTING_CODE
+}
+
 func isDupCache() func(existing []*Cache, newItem *Cache) bool {
 	// EXISTING_CODE
 	return func(existing []*Cache, newItem *Cache) bool {
@@ -113,6 +132,14 @@ func isDupChain() func(existing []*Chain, newItem *Chain) bool {
 	// EXISTING_CODE
 }
 
+func isDupMetric() func(existing []*Metric, newItem *Metric) bool {
+	// EXISTING_CODE
+	return func(existing []*Metric, newItem *Metric) bool {
+		return false
+	}
+	// EXISTING_CODE
+}
+
 func isDupStatus() func(existing []*Status, newItem *Status) bool {
 	// EXISTING_CODE
 	return func(existing []*Status, newItem *Status) bool {
@@ -141,6 +168,10 @@ func (c *StatusCollection) FetchByFacet(payload *types.Payload) {
 			if err := c.chainsFacet.FetchFacet(); err != nil {
 				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
 			}
+		case StatusMetrics:
+			if err := c.metricsFacet.FetchFacet(); err != nil {
+				logging.LogError(fmt.Sprintf("LoadData.%s from store: %%v", dataFacet), err, facets.ErrAlreadyLoading)
+			}
 		default:
 			logging.LogError("LoadData: unexpected dataFacet: %v", fmt.Errorf("invalid dataFacet: %s", dataFacet), nil)
 			return
@@ -156,6 +187,8 @@ func (c *StatusCollection) Reset(payload *types.Payload) {
 		c.cachesFacet.Reset()
 	case StatusChains:
 		c.chainsFacet.Reset()
+	case StatusMetrics:
+		c.metricsFacet.Reset()
 	default:
 		return
 	}
@@ -169,6 +202,8 @@ func (c *StatusCollection) NeedsUpdate(payload *types.Payload) bool {
 		return c.cachesFacet.NeedsUpdate()
 	case StatusChains:
 		return c.chainsFacet.NeedsUpdate()
+	case StatusMetrics:
+		return c.metricsFacet.NeedsUpdate()
 	default:
 		return false
 	}
@@ -206,6 +241,10 @@ func (c *StatusCollection) AccumulateItem(item interface{}, summary *types.Summa
 		chainsCount++
 
 		summary.CustomData["chainsCount"] = chainsCount
+
+	case *Metric:
+		summary.TotalCount++
+		summary.FacetCounts[StatusMetrics]++
 	}
 	// EXISTING_CODE
 }
@@ -242,7 +281,7 @@ func (c *StatusCollection) ResetSummary() {
 	}
 }
 
-func (c *StatusCollection) ExportData(payload *types.Payload) (string, error) {
+func (c *StatusCollection) ExportData(payload *types.Payload) (string, int, error) {
 	switch payload.DataFacet {
 	case StatusStatus:
 		return c.statusFacet.ExportData(payload, string(StatusStatus))
@@ -250,8 +289,10 @@ func (c *StatusCollection) ExportData(payload *types.Payload) (string, error) {
 		return c.cachesFacet.ExportData(payload, string(StatusCaches))
 	case StatusChains:
 		return c.chainsFacet.ExportData(payload, string(StatusChains))
+	case StatusMetrics:
+		return c.metricsFacet.ExportData(payload, string(StatusMetrics))
 	default:
-		return "", fmt.Errorf("[ExportData] unsupported status facet: %s", payload.DataFacet)
+		return "", 0, fmt.Errorf("[ExportData] unsupported status facet: %s", payload.DataFacet)
 	}
 }
 
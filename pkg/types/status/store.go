@@ -23,6 +23,7 @@ import (
 
 type Cache = sdk.Cache
 type Chain = sdk.Chain
+type Metric = store.FetchMetrics
 type Status = sdk.Status
 
 // EXISTING_CODE
@@ -34,6 +35,9 @@ var (
 	chainsStore   = make(map[string]*store.Store[Chain])
 	chainsStoreMu sync.Mutex
 
+	metricsStore   = make(map[string]*store.Store[Metric])
+	metricsStoreMu sync.Mutex
+
 	statusStore   = make(map[string]*store.Store[Status])
 	statusStoreMu sync.Mutex
 )
@@ -138,6 +142,59 @@ func (c *StatusCollection) getChainsStore(payload *types.Payload, facet types.Da
 	return theStore
 }
 
+func (c *StatusCollection) getMetricsStore(payload *types.Payload, facet types.DataFacet) *store.Store[Metric] {
+	metricsStoreMu.Lock()
+	defer metricsStoreMu.Unlock()
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
+	storeKey := getStoreKey(payload)
+	theStore := metricsStore[storeKey]
+	if theStore == nil {
+		queryFunc := func(ctx *output.RenderCtx) error {
+			// EXISTING_CODE
+			go func() {
+				defer close(ctx.ModelChan)
+				defer close(ctx.ErrorChan)
+				for _, m := range store.GetAllFetchMetrics() {
+					select {
+					case ctx.ModelChan <- &m:
+					case <-ctx.Ctx.Done():
+						return
+					}
+				}
+			}()
+			// EXISTING_CODE
+			return nil
+		}
+
+		processFunc := func(item interface{}) *Metric {
+			if it, ok := item.(*Metric); ok {
+				// EXISTING_CODE
+				// EXISTING_CODE
+				return it
+			}
+			return nil
+		}
+
+		mappingFunc := func(item *Metric) (key string, includeInMap bool) {
+			return "", false
+		}
+
+		storeName := c.getStoreName(payload, facet)
+		theStore = store.NewStore(storeName, queryFunc, processFunc, mappingFunc)
+
+		// EXISTING_CODE
+		theStore.SetRetryPolicy(store.NoRetryPolicy)
+		// EXISTING_CODE
+
+		metricsStore[storeKey] = theStore
+	}
+
+	return theStore
+}
+
 func (c *StatusCollection) getStatusStore(payload *types.Payload, facet types.DataFacet) *store.Store[Status] {
 	statusStoreMu.Lock()
 	defer statusStoreMu.Unlock()
@@ -201,10 +258,16 @@ func (c *StatusCollection) getStoreName(payload *types.Payload, facet types.Data
 		name = "status-caches"
 	case StatusChains:
 		name = "status-chains"
+	case StatusMetrics:
+		name = "status-metrics"
 	default:
 		return ""
 	}
 	name = fmt.Sprintf("%s-%s-%s", name, payload.ActiveChain, payload.ActiveAddress)
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
 	return name
 }
 
@@ -230,7 +293,7 @@ func GetStatusCollection(payload *types.Payload) *StatusCollection {
 
 func getStoreKey(payload *types.Payload) string {
 	// EXISTING_CODE
-	if payload.DataFacet == StatusChains {
+	if payload.DataFacet == StatusChains || payload.DataFacet == StatusMetrics {
 		return "singleton"
 	}
 	// EXISTING_CODE
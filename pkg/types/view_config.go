@@ -99,8 +99,9 @@ func (vc *ViewConfig) IsDisabled() bool {
 
 // FacetChartConfig represents facet-level chart configuration
 type FacetChartConfig struct {
-	SeriesStrategy  string `json:"seriesStrategy,omitempty"`  // how to group data into series ("address", "symbol", "address+symbol")
-	SeriesPrefixLen int    `json:"seriesPrefixLen,omitempty"` // prefix length for collision avoidance (8-15)
+	SeriesStrategy  string   `json:"seriesStrategy,omitempty"`  // how to group data into series ("address", "symbol", "address+symbol")
+	SeriesPrefixLen int      `json:"seriesPrefixLen,omitempty"` // prefix length for collision avoidance (8-15)
+	Metrics         []string `json:"metrics,omitempty"`         // metric series to build; empty means every registered metric
 }
 
 // PanelChartConfig represents visualization panel configuration